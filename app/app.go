@@ -7,7 +7,10 @@ import (
 	"sort"
 
 	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/module"
 	"github.com/terra-project/core/update"
+	"github.com/terra-project/core/update/plan"
 	"github.com/terra-project/core/version"
 	"github.com/terra-project/core/x/budget"
 	"github.com/terra-project/core/x/market"
@@ -16,11 +19,7 @@ import (
 	"github.com/terra-project/core/x/pay"
 	"github.com/terra-project/core/x/treasury"
 
-	"github.com/terra-project/core/types/assets"
-
-	tdistr "github.com/terra-project/core/x/distribution"
-	tslashing "github.com/terra-project/core/x/slashing"
-	tstaking "github.com/terra-project/core/x/staking"
+	tauth "github.com/terra-project/core/x/auth"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -77,6 +76,8 @@ type TerraApp struct {
 	keyMarket        *sdk.KVStoreKey
 	keyBudget        *sdk.KVStoreKey
 	keyMint          *sdk.KVStoreKey
+	keyPay           *sdk.KVStoreKey
+	keyUpdate        *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountKeeper       auth.AccountKeeper
@@ -92,6 +93,11 @@ type TerraApp struct {
 	marketKeeper        market.Keeper
 	budgetKeeper        budget.Keeper
 	mintKeeper          mint.Keeper
+	payKeeper           pay.Keeper
+	updateKeeper        update.Keeper
+
+	// the module manager
+	mm *module.Manager
 }
 
 // NewTerraApp returns a reference to an initialized TerraApp.
@@ -120,6 +126,8 @@ func NewTerraApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest,
 		keyMarket:               sdk.NewKVStoreKey(market.StoreKey),
 		keyBudget:               sdk.NewKVStoreKey(budget.StoreKey),
 		keyMint:                 sdk.NewKVStoreKey(mint.StoreKey),
+		keyPay:                  sdk.NewKVStoreKey(pay.StoreKey),
+		keyUpdate:               sdk.NewKVStoreKey(update.StoreKey),
 	}
 
 	app.paramsKeeper = params.NewKeeper(
@@ -175,11 +183,28 @@ func NewTerraApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest,
 		stakingKeeper,
 		app.bankKeeper,
 		app.accountKeeper,
+		app.paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
+
+	// register the issuance operations and denoms allowed to each module; treasury only reads the
+	// issuance. Only market, which swaps Terra for Luna, may mint or burn Luna.
+	app.mintKeeper.RegisterPermission(market.ModuleName,
+		mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+			append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+	// budget mints grants, but also burns program deposits when they are paid, mints them back when
+	// they are refunded, and mints forfeited deposits and leftovers into the community pool
+	app.mintKeeper.RegisterPermission(budget.ModuleName,
+		mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn, mint.OperationPool}, assets.TerraDenoms))
+	app.mintKeeper.RegisterPermission(oracle.ModuleName,
+		mint.NewPermission([]string{mint.OperationPool}, assets.TerraDenoms))
+	// update plans only change the mint params, e.g. to start the clock on a live chain
+	app.mintKeeper.RegisterPermission(update.ModuleName,
+		mint.NewPermission([]string{mint.OperationParams}, nil))
+
 	app.oracleKeeper = oracle.NewKeeper(
 		app.cdc,
 		app.keyOracle,
-		app.mintKeeper,
+		app.mintKeeper.ForModule(oracle.ModuleName),
 		app.distrKeeper,
 		app.feeCollectionKeeper,
 		stakingKeeper.GetValidatorSet(),
@@ -189,14 +214,14 @@ func NewTerraApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest,
 		app.cdc,
 		app.keyMarket,
 		app.oracleKeeper,
-		app.mintKeeper,
+		app.mintKeeper.ForModule(market.ModuleName),
 		app.paramsKeeper.Subspace(market.DefaultParamspace),
 	)
 	app.treasuryKeeper = treasury.NewKeeper(
 		app.cdc,
 		app.keyTreasury,
 		stakingKeeper.GetValidatorSet(),
-		app.mintKeeper,
+		app.mintKeeper.ForModule(treasury.ModuleName),
 		app.marketKeeper,
 		app.paramsKeeper.Subspace(treasury.DefaultParamspace),
 	)
@@ -204,11 +229,36 @@ func NewTerraApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest,
 		app.cdc,
 		app.keyBudget,
 		app.marketKeeper,
-		app.mintKeeper,
+		app.mintKeeper.ForModule(budget.ModuleName),
 		app.treasuryKeeper,
-		stakingKeeper.GetValidatorSet(),
+		app.distrKeeper,
+		&stakingKeeper,
 		app.paramsKeeper.Subspace(budget.DefaultParamspace),
 	)
+	app.payKeeper = pay.NewKeeper(
+		app.cdc,
+		app.keyPay,
+		app.accountKeeper,
+		app.bankKeeper,
+		&stakingKeeper,
+		app.treasuryKeeper,
+		app.feeCollectionKeeper,
+	)
+	app.updateKeeper = update.NewKeeper(
+		app.cdc,
+		app.keyUpdate,
+		stakingKeeper.GetValidatorSet(),
+		plan.Keepers{
+			AccountKeeper: app.accountKeeper,
+			OracleKeeper:  app.oracleKeeper,
+			MarketKeeper:  app.marketKeeper,
+			MintKeeper:    app.mintKeeper.ForModule(update.ModuleName),
+		},
+		plan.DefaultRegistry(),
+	)
+
+	// every module holds its mint keeper; no permission can be registered from now on
+	app.mintKeeper.SealPermissions()
 
 	// register the staking hooks
 	// NOTE: The stakingKeeper above is passed by reference, so that it can be
@@ -216,31 +266,43 @@ func NewTerraApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest,
 	app.stakingKeeper = *stakingKeeper.SetHooks(
 		NewStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
 
-	// register the crisis routes
-	bank.RegisterInvariants(&app.crisisKeeper, app.accountKeeper)
-	distr.RegisterInvariants(&app.crisisKeeper, app.distrKeeper, app.stakingKeeper)
-	staking.RegisterInvariants(&app.crisisKeeper, app.stakingKeeper, app.feeCollectionKeeper, app.distrKeeper, app.accountKeeper)
-
-	// register message routes
-	app.Router().
-		AddRoute(bank.RouterKey, pay.NewHandler(app.bankKeeper, app.treasuryKeeper, app.feeCollectionKeeper)).
-		AddRoute(staking.RouterKey, staking.NewHandler(app.stakingKeeper)).
-		AddRoute(distr.RouterKey, distr.NewHandler(app.distrKeeper)).
-		AddRoute(slashing.RouterKey, slashing.NewHandler(app.slashingKeeper)).
-		AddRoute(oracle.RouterKey, oracle.NewHandler(app.oracleKeeper)).
-		AddRoute(budget.RouterKey, budget.NewHandler(app.budgetKeeper)).
-		AddRoute(market.RouterKey, market.NewHandler(app.marketKeeper)).
-		AddRoute(crisis.RouterKey, crisis.NewHandler(app.crisisKeeper))
-
-	app.QueryRouter().
-		AddRoute(auth.QuerierRoute, auth.NewQuerier(app.accountKeeper)).
-		AddRoute(distr.QuerierRoute, distr.NewQuerier(app.distrKeeper)).
-		AddRoute(slashing.QuerierRoute, slashing.NewQuerier(app.slashingKeeper, app.cdc)).
-		AddRoute(staking.QuerierRoute, staking.NewQuerier(app.stakingKeeper, app.cdc)).
-		AddRoute(treasury.QuerierRoute, treasury.NewQuerier(app.treasuryKeeper)).
-		AddRoute(market.QuerierRoute, market.NewQuerier(app.marketKeeper)).
-		AddRoute(oracle.QuerierRoute, oracle.NewQuerier(app.oracleKeeper)).
-		AddRoute(budget.QuerierRoute, budget.NewQuerier(app.budgetKeeper))
+	// NOTE: Any module instantiated in the module manager that is later modified
+	// must be passed by reference here.
+	app.mm = module.NewManager(
+		authModule{ak: app.accountKeeper, fck: app.feeCollectionKeeper},
+		bankModule{bk: app.bankKeeper, ak: app.accountKeeper, handler: pay.NewHandler(app.payKeeper)},
+		stakingModule{cdc: app.cdc, sk: app.stakingKeeper, ak: app.accountKeeper, fck: app.feeCollectionKeeper, dk: app.distrKeeper},
+		distrModule{dk: app.distrKeeper, sk: app.stakingKeeper},
+		slashingModule{cdc: app.cdc, k: app.slashingKeeper, sk: app.stakingKeeper},
+		crisisModule{k: &app.crisisKeeper},
+		oracle.NewAppModule(app.oracleKeeper),
+		treasury.NewAppModule(app.treasuryKeeper),
+		market.NewAppModule(app.marketKeeper),
+		budget.NewAppModule(app.budgetKeeper),
+		pay.NewAppModule(app.payKeeper),
+		mint.NewAppModule(app.mintKeeper, app.feeCollectionKeeper, app.distrKeeper),
+		update.NewAppModule(app.updateKeeper),
+	)
+
+	// the mint clock starts counting days by block time before any module reads them; the update
+	// begin blocker runs ahead of these, see BeginBlocker
+	app.mm.SetOrderBeginBlockers(mint.ModuleName, distrModuleName, slashingModuleName)
+
+	// the mint clock moves past the block after every module ending a day or an epoch
+	app.mm.SetOrderEndBlockers(stakingModuleName, oracle.ModuleName, budget.ModuleName, pay.ModuleName,
+		treasury.ModuleName, update.ModuleName, mint.ModuleName)
+
+	// distribution must init before staking, and slashing after it
+	app.mm.SetOrderInitGenesis(distrModuleName, stakingModuleName, authModuleName, bankModuleName,
+		slashingModuleName, crisisModuleName, treasury.ModuleName, market.ModuleName, budget.ModuleName,
+		oracle.ModuleName, pay.ModuleName, update.ModuleName, mint.ModuleName)
+
+	// register the crisis routes, then the message and query routes
+	app.mm.RegisterInvariants(&app.crisisKeeper)
+	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
+
+	// the terra account queries extend the auth ones under their own route
+	app.QueryRouter().AddRoute(tauth.QuerierRoute, tauth.NewQuerier(app.accountKeeper, app.cdc))
 
 	// initialize BaseApp
 	app.MountStores(
@@ -248,6 +310,7 @@ func NewTerraApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest,
 		app.keySlashing, app.keyFeeCollection, app.keyParams,
 		app.tkeyParams, app.tkeyStaking, app.tkeyDistr, app.keyMarket,
 		app.keyOracle, app.keyTreasury, app.keyBudget, app.keyMint,
+		app.keyPay, app.keyUpdate,
 	)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
@@ -283,17 +346,8 @@ func MakeCodec() *codec.Codec {
 	var cdc = codec.New()
 
 	// left codec for backward compatibility
-	pay.RegisterCodec(cdc)
-	tstaking.RegisterCodec(cdc)
-	tdistr.RegisterCodec(cdc)
-	tslashing.RegisterCodec(cdc)
-	auth.RegisterCodec(cdc)
+	ModuleBasics.RegisterCodec(cdc)
 	types.RegisterCodec(cdc)
-	oracle.RegisterCodec(cdc)
-	budget.RegisterCodec(cdc)
-	market.RegisterCodec(cdc)
-	treasury.RegisterCodec(cdc)
-	crisis.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 
@@ -305,48 +359,27 @@ func MakeCodec() *codec.Codec {
 	return cdc
 }
 
-// BeginBlocker application updates every end block
+// BeginBlocker application updates every begin block
 func (app *TerraApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// apply the scheduled software upgrade, or halt if this binary does not know it, before anything
+	// else runs; its tags still follow the slashing ones
+	updateTags := update.BeginBlocker(ctx, app.updateKeeper)
 
-	// distribute rewards for the previous block
-	distr.BeginBlocker(ctx, req, app.distrKeeper)
-
-	// slash anyone who double signed.
-	// NOTE: This should happen after distr.BeginBlocker so that
-	// there is nothing left over in the validator fee pool,
-	// so as to keep the CanWithdrawInvariant invariant.
-	// TODO: This should really happen at EndBlocker.
-	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+	res := app.mm.BeginBlock(ctx, req)
+	res.Tags = append(res.Tags, updateTags.ToKVPairs()...)
 
-	return abci.ResponseBeginBlock{
-		Tags: tags.ToKVPairs(),
-	}
+	return res
 }
 
 // EndBlocker application updates every end block
 func (app *TerraApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	validatorUpdates, tags := staking.EndBlocker(ctx, app.stakingKeeper)
-
-	oracleTags := oracle.EndBlocker(ctx, app.oracleKeeper)
-	tags = append(tags, oracleTags...)
-
-	budgetTags := budget.EndBlocker(ctx, app.budgetKeeper)
-	tags = append(tags, budgetTags...)
-
-	treasuryTags := treasury.EndBlocker(ctx, app.treasuryKeeper)
-	tags = append(tags, treasuryTags...)
-
-	updateTags := update.EndBlocker(ctx, app.accountKeeper, app.oracleKeeper, app.marketKeeper)
-	tags = append(tags, updateTags...)
+	res := app.mm.EndBlock(ctx, req)
 
 	if app.assertInvariantsBlockly {
 		app.assertRuntimeInvariants()
 	}
 
-	return abci.ResponseEndBlock{
-		ValidatorUpdates: validatorUpdates,
-		Tags:             tags,
-	}
+	return res
 }
 
 // initialize store from a genesis state
@@ -360,24 +393,8 @@ func (app *TerraApp) initFromGenesisState(ctx sdk.Context, genesisState GenesisS
 		app.accountKeeper.SetAccount(ctx, acc)
 	}
 
-	// initialize distribution (must happen before staking)
-	distr.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
-
-	// load the initial staking information
-	validators, err := staking.InitGenesis(ctx, app.stakingKeeper, genesisState.StakingData)
-	if err != nil {
-		panic(err) // TODO find a way to do this w/o panics
-	}
-
-	// initialize module-specific stores
-	auth.InitGenesis(ctx, app.accountKeeper, app.feeCollectionKeeper, genesisState.AuthData)
-	bank.InitGenesis(ctx, app.bankKeeper, genesisState.BankData)
-	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.SlashingData, genesisState.StakingData.Validators.ToSDKValidators())
-	crisis.InitGenesis(ctx, app.crisisKeeper, genesisState.CrisisData)
-	treasury.InitGenesis(ctx, app.treasuryKeeper, genesisState.TreasuryData)
-	market.InitGenesis(ctx, app.marketKeeper, genesisState.MarketData)
-	budget.InitGenesis(ctx, app.budgetKeeper, genesisState.BudgetData)
-	oracle.InitGenesis(ctx, app.oracleKeeper, genesisState.OracleData)
+	// initialize the modules in the init genesis order of the module manager
+	validators := app.mm.InitGenesis(ctx, genesisState.moduleGenesis(app.cdc))
 
 	// validate genesis state
 	if err := TerraValidateGenesisState(genesisState); err != nil {
@@ -387,7 +404,7 @@ func (app *TerraApp) initFromGenesisState(ctx sdk.Context, genesisState GenesisS
 	if len(genesisState.GenTxs) > 0 {
 		for _, genTx := range genesisState.GenTxs {
 			var tx auth.StdTx
-			err = app.cdc.UnmarshalJSON(genTx, &tx)
+			err := app.cdc.UnmarshalJSON(genTx, &tx)
 			if err != nil {
 				panic(err)
 			}
@@ -433,8 +450,8 @@ func (app *TerraApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abc
 		}
 	}
 
-	// GetIssuance needs to be called once to read account balances to the store
-	app.mintKeeper.GetIssuance(ctx, assets.MicroLunaDenom, sdk.ZeroInt())
+	// supply needs to be initialized once from the balances of accounts and pools
+	mint.InitSupply(ctx, app.mintKeeper, app.feeCollectionKeeper, app.distrKeeper)
 
 	// assert runtime invariants
 	app.assertRuntimeInvariants()
@@ -0,0 +1,335 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/update"
+	"github.com/terra-project/core/update/plan"
+	"github.com/terra-project/core/x/budget"
+	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
+	"github.com/terra-project/core/x/oracle"
+	"github.com/terra-project/core/x/pay"
+	"github.com/terra-project/core/x/treasury"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+)
+
+// legacyInitChainer inits the genesis as the app did before the module manager
+func legacyInitChainer(app *TerraApp) sdk.InitChainer {
+	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		var genesisState GenesisState
+		app.cdc.MustUnmarshalJSON(req.AppStateBytes, &genesisState)
+		genesisState.Sanitize()
+
+		for _, gacc := range genesisState.Accounts {
+			acc := gacc.ToAccount()
+			acc = app.accountKeeper.NewAccount(ctx, acc)
+			app.accountKeeper.SetAccount(ctx, acc)
+		}
+
+		distr.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
+		validators, err := staking.InitGenesis(ctx, app.stakingKeeper, genesisState.StakingData)
+		if err != nil {
+			panic(err)
+		}
+
+		auth.InitGenesis(ctx, app.accountKeeper, app.feeCollectionKeeper, genesisState.AuthData)
+		bank.InitGenesis(ctx, app.bankKeeper, genesisState.BankData)
+		slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.SlashingData, genesisState.StakingData.Validators.ToSDKValidators())
+		crisis.InitGenesis(ctx, app.crisisKeeper, genesisState.CrisisData)
+		treasury.InitGenesis(ctx, app.treasuryKeeper, genesisState.TreasuryData)
+		market.InitGenesis(ctx, app.marketKeeper, genesisState.MarketData)
+		budget.InitGenesis(ctx, app.budgetKeeper, genesisState.BudgetData)
+		oracle.InitGenesis(ctx, app.oracleKeeper, genesisState.OracleData)
+		pay.InitGenesis(ctx, app.payKeeper, genesisState.PayData)
+		update.InitGenesis(ctx, app.updateKeeper, genesisState.UpdateData)
+		mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
+
+		mint.InitSupply(ctx, app.mintKeeper, app.feeCollectionKeeper, app.distrKeeper)
+
+		return abci.ResponseInitChain{
+			Validators: validators,
+		}
+	}
+}
+
+// legacyBeginBlocker is the begin blocker of the app before the module manager. The baseline app
+// ran distribution then slashing; the update and mint begin blockers ran before them, with the
+// update tags last.
+func legacyBeginBlocker(app *TerraApp) sdk.BeginBlocker {
+	return func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		// apply the scheduled software upgrade, or halt if this binary does not know it
+		updateTags := update.BeginBlocker(ctx, app.updateKeeper)
+
+		// start counting days by block time once the transition height is reached
+		mint.BeginBlocker(ctx, app.mintKeeper)
+
+		// distribute rewards for the previous block
+		distr.BeginBlocker(ctx, req, app.distrKeeper)
+
+		// slash anyone who double signed
+		tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
+		tags = append(tags, updateTags...)
+
+		return abci.ResponseBeginBlock{
+			Tags: tags.ToKVPairs(),
+		}
+	}
+}
+
+// legacyEndBlocker is the end blocker of the app before the module manager. The baseline app ran
+// staking, oracle, budget, treasury then update; pay ran between budget and treasury, and mint
+// after every other module.
+func legacyEndBlocker(app *TerraApp) sdk.EndBlocker {
+	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+		validatorUpdates, tags := staking.EndBlocker(ctx, app.stakingKeeper)
+
+		oracleTags := oracle.EndBlocker(ctx, app.oracleKeeper)
+		tags = append(tags, oracleTags...)
+
+		budgetTags := budget.EndBlocker(ctx, app.budgetKeeper)
+		tags = append(tags, budgetTags...)
+
+		payTags := pay.EndBlocker(ctx, app.payKeeper)
+		tags = append(tags, payTags...)
+
+		treasuryTags := treasury.EndBlocker(ctx, app.treasuryKeeper)
+		tags = append(tags, treasuryTags...)
+
+		updateTags := update.EndBlocker(ctx, app.updateKeeper)
+		tags = append(tags, updateTags...)
+
+		// move the clock past this block, after every module ending a day or an epoch
+		mint.EndBlocker(ctx, app.mintKeeper)
+
+		if app.assertInvariantsBlockly {
+			app.assertRuntimeInvariants()
+		}
+
+		return abci.ResponseEndBlock{
+			ValidatorUpdates: validatorUpdates,
+			Tags:             tags,
+		}
+	}
+}
+
+// legacyExportGenesis exports the genesis as the app did before the module manager
+func legacyExportGenesis(t *testing.T, app *TerraApp) []byte {
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+
+	accounts := []GenesisAccount{}
+	app.accountKeeper.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+		accounts = append(accounts, NewGenesisAccountI(acc))
+		return false
+	})
+
+	genState := NewGenesisState(
+		accounts,
+		auth.ExportGenesis(ctx, app.accountKeeper, app.feeCollectionKeeper),
+		bank.ExportGenesis(ctx, app.bankKeeper),
+		staking.ExportGenesis(ctx, app.stakingKeeper),
+		distr.ExportGenesis(ctx, app.distrKeeper),
+		oracle.ExportGenesis(ctx, app.oracleKeeper),
+		budget.ExportGenesis(ctx, app.budgetKeeper),
+		crisis.ExportGenesis(ctx, app.crisisKeeper),
+		treasury.ExportGenesis(ctx, app.treasuryKeeper),
+		slashing.ExportGenesis(ctx, app.slashingKeeper),
+		market.ExportGenesis(ctx, app.marketKeeper),
+		pay.ExportGenesis(ctx, app.payKeeper),
+		update.ExportGenesis(ctx, app.updateKeeper),
+		mint.ExportGenesis(ctx, app.mintKeeper),
+	)
+
+	bz, err := codec.MarshalJSONIndent(app.cdc, genState)
+	require.NoError(t, err)
+
+	return bz
+}
+
+// normalizeGenesis decodes the genesis state {bz} and encodes it again
+func normalizeGenesis(t *testing.T, cdc *codec.Codec, bz []byte) string {
+	var genesisState GenesisState
+	require.NoError(t, cdc.UnmarshalJSON(bz, &genesisState))
+
+	bz, err := codec.MarshalJSONIndent(cdc, genesisState)
+	require.NoError(t, err)
+
+	return string(bz)
+}
+
+func newLegacyTerraApp(t *testing.T) *TerraApp {
+	app := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, false, false)
+	app.SetInitChainer(legacyInitChainer(app))
+	app.SetBeginBlocker(legacyBeginBlocker(app))
+	app.SetEndBlocker(legacyEndBlocker(app))
+	require.NoError(t, app.LoadLatestVersion(app.keyMain))
+
+	return app
+}
+
+func newTestGenesisState() GenesisState {
+	genesisState := NewDefaultGenesisState()
+
+	// start the mint clock during the test
+	genesisState.MintData.Params.ClockTransitionHeight = 5
+
+	// apply an upgrade during the test, so that the update begin blocker tags a block
+	genesisState.UpdateData.UpgradePlan = update.NewUpgradePlan(plan.TagUpdate230000, 10, "")
+
+	for i := 0; i < 3; i++ {
+		addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+		coins := sdk.Coins{
+			sdk.NewInt64Coin(assets.MicroLunaDenom, int64(i+1)*1000000),
+			sdk.NewInt64Coin(assets.MicroSDRDenom, int64(i+1)*2000000),
+		}
+
+		genesisState.Accounts = append(genesisState.Accounts,
+			NewGenesisAccount(&auth.BaseAccount{Address: addr, Coins: coins}))
+		genesisState.StakingData.Pool.NotBondedTokens = genesisState.StakingData.Pool.NotBondedTokens.
+			Add(coins.AmountOf(assets.MicroLunaDenom))
+	}
+
+	return genesisState
+}
+
+// blockResult is what a block of the test chain returned to Tendermint, encoded as Tendermint
+// stores it; nil and empty tags encode the same
+type blockResult struct {
+	AppHash    []byte
+	BeginBlock []byte
+	EndBlock   []byte
+}
+
+// runBlocks inits the chain of {app} from {appState} and returns the results of every block after
+func runBlocks(t *testing.T, app *TerraApp, appState []byte, blocks int64) (results []blockResult) {
+	app.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+
+	start := time.Unix(1560000000, 0)
+	for height := int64(1); height <= blocks; height++ {
+		header := abci.Header{
+			Height: height,
+			Time:   start.Add(time.Duration(height) * 6 * time.Second),
+		}
+
+		beginBlock := app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		endBlock := app.EndBlock(abci.RequestEndBlock{Height: height})
+		res := app.Commit()
+
+		beginBlockBz, err := beginBlock.Marshal()
+		require.NoError(t, err)
+		endBlockBz, err := endBlock.Marshal()
+		require.NoError(t, err)
+
+		results = append(results, blockResult{res.Data, beginBlockBz, endBlockBz})
+	}
+
+	return
+}
+
+func TestModuleManagerAppHash(t *testing.T) {
+	app := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, false)
+	legacyApp := newLegacyTerraApp(t)
+
+	appState, err := codec.MarshalJSONIndent(app.cdc, newTestGenesisState())
+	require.NoError(t, err)
+
+	// the app hashes, tags and validator updates of every block are the ones of the legacy blockers
+	results := runBlocks(t, app, appState, 20)
+	legacyResults := runBlocks(t, legacyApp, appState, 20)
+	require.Equal(t, legacyResults, results)
+
+	// the upgrade tags follow the ones of the other begin blockers
+	var beginBlock abci.ResponseBeginBlock
+	require.NoError(t, beginBlock.Unmarshal(results[9].BeginBlock))
+	tags := beginBlock.Tags
+	require.NotEmpty(t, tags)
+	require.Equal(t, plan.TagUpdate230000, string(tags[len(tags)-1].Key))
+
+	// the mint clock started during the blocks
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	_, found := app.mintKeeper.GetClock(ctx)
+	require.True(t, found)
+
+	// the genesis exported through the module manager is the one exported module by module. The module
+	// manager passes every module genesis through JSON, which turns empty lists into null; both decode
+	// to the same genesis state.
+	exported, _, err := app.ExportAppStateAndValidators(false, nil)
+	require.NoError(t, err)
+	require.Equal(t, normalizeGenesis(t, app.cdc, legacyExportGenesis(t, legacyApp)), normalizeGenesis(t, app.cdc, exported))
+}
+
+func TestModuleManagerOrders(t *testing.T) {
+	app := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, false)
+
+	// every module inits and exports its genesis
+	require.Equal(t, len(app.mm.Modules), len(app.mm.OrderInitGenesis))
+	require.Equal(t, len(app.mm.Modules), len(app.mm.OrderExportGenesis))
+
+	// every module with a genesis in the app genesis is in the module basics, and the other way round
+	genesis := NewDefaultGenesisState().moduleGenesis(app.cdc)
+	delete(genesis, "accounts")
+	delete(genesis, "gentxs")
+	require.Equal(t, len(genesis), len(ModuleBasics))
+	for _, b := range ModuleBasics {
+		_, found := genesis[b.Name()]
+		require.True(t, found, b.Name())
+
+		_, found = app.mm.Modules[b.Name()]
+		require.True(t, found, b.Name())
+	}
+
+	// the blockers run in the order of the legacy blockers
+	require.Equal(t, []string{mint.ModuleName, distrModuleName, slashingModuleName}, app.mm.OrderBeginBlockers)
+	require.Equal(t, []string{stakingModuleName, oracle.ModuleName, budget.ModuleName, pay.ModuleName,
+		treasury.ModuleName, update.ModuleName, mint.ModuleName}, app.mm.OrderEndBlockers)
+
+	require.NoError(t, TerraValidateGenesisState(NewDefaultGenesisState()))
+
+	// the genesis of oracle, budget and treasury is left unvalidated, as before the module manager
+	genesisState := NewDefaultGenesisState()
+	genesisState.OracleData.Params.VotePeriod = 0
+	require.Error(t, oracle.ValidateGenesis(genesisState.OracleData))
+	require.NoError(t, TerraValidateGenesisState(genesisState))
+}
+
+func TestMintPermissions(t *testing.T) {
+	app := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, false)
+	ctx := app.NewContext(true, abci.Header{})
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	// only market may mint Luna; budget and oracle are limited to Terra
+	lunaCoin := sdk.NewInt64Coin(assets.MicroLunaDenom, assets.MicroUnit)
+	for _, module := range []string{budget.ModuleName, oracle.ModuleName, treasury.ModuleName} {
+		err := app.mintKeeper.ForModule(module).Mint(ctx, addr, lunaCoin)
+		require.NotNil(t, err, module)
+		require.Equal(t, sdk.CodeUnauthorized, err.Code(), module)
+	}
+
+	err := app.mintKeeper.ForModule(oracle.ModuleName).ChangeIssuance(ctx, assets.MicroLunaDenom, lunaCoin.Amount)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	require.True(t, app.bankKeeper.GetCoins(ctx, addr).Empty())
+
+	require.Nil(t, app.mintKeeper.ForModule(budget.ModuleName).Mint(ctx, addr, sdk.NewInt64Coin(assets.MicroSDRDenom, assets.MicroUnit)))
+
+	// the permissions are sealed once the app is wired
+	require.Panics(t, func() {
+		app.mintKeeper.RegisterPermission(pay.ModuleName, mint.NewPermission([]string{mint.OperationMint}, assets.TerraDenoms))
+	})
+}
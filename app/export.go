@@ -4,17 +4,16 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/terra-project/core/update"
 	"github.com/terra-project/core/x/budget"
-	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
 	"github.com/terra-project/core/x/oracle"
+	"github.com/terra-project/core/x/pay"
 	"github.com/terra-project/core/x/treasury"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
-	"github.com/cosmos/cosmos-sdk/x/bank"
-	"github.com/cosmos/cosmos-sdk/x/crisis"
-	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -41,19 +40,11 @@ func (app *TerraApp) ExportAppStateAndValidators(forZeroHeight bool, jailWhiteLi
 	}
 	app.accountKeeper.IterateAccounts(ctx, appendAccount)
 
-	genState := NewGenesisState(
-		accounts,
-		auth.ExportGenesis(ctx, app.accountKeeper, app.feeCollectionKeeper),
-		bank.ExportGenesis(ctx, app.bankKeeper),
-		staking.ExportGenesis(ctx, app.stakingKeeper),
-		distr.ExportGenesis(ctx, app.distrKeeper),
-		oracle.ExportGenesis(ctx, app.oracleKeeper),
-		budget.ExportGenesis(ctx, app.budgetKeeper),
-		crisis.ExportGenesis(ctx, app.crisisKeeper),
-		treasury.ExportGenesis(ctx, app.treasuryKeeper),
-		slashing.ExportGenesis(ctx, app.slashingKeeper),
-		market.ExportGenesis(ctx, app.marketKeeper),
-	)
+	genState, err := newGenesisStateFromModules(app.cdc, accounts, app.mm.ExportGenesis(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
 		return nil, nil, err
@@ -84,6 +75,18 @@ func (app *TerraApp) prepForZeroHeightGenesis(ctx sdk.Context, jailWhiteList []s
 	/* Just to be safe, assert the invariants on current state. */
 	app.assertRuntimeInvariantsOnContext(ctx)
 
+	/* Settle the current epoch; the restarted chain starts on the next one. */
+
+	// reward the oracle ballot winners before the validator rewards are withdrawn
+	oracle.PrepForZeroHeightGenesis(ctx, app.oracleKeeper)
+
+	// pay the budget claims, then update the treasury policy, as at the end of the epoch
+	budget.PrepForZeroHeightGenesis(ctx, app.budgetKeeper)
+	treasury.PrepForZeroHeightGenesis(ctx, app.treasuryKeeper)
+
+	// move the clock to the next epoch; days are counted by block time from the restart
+	mint.PrepForZeroHeightGenesis(ctx, app.mintKeeper)
+
 	/* Handle fee distribution state. */
 
 	// withdraw all validator commission
@@ -188,10 +191,9 @@ func (app *TerraApp) prepForZeroHeightGenesis(ctx sdk.Context, jailWhiteList []s
 		},
 	)
 
-	// reset submit height on program infos
-	app.budgetKeeper.IteratePrograms(ctx, false, func(program budget.Program) (stop bool) {
-		program.SubmitBlock = 0
-		app.budgetKeeper.StoreProgram(ctx, program)
-		return false
-	})
+	/* Handle pay and update state. */
+
+	// make installments, timeouts and the upgrade height relative to the export
+	pay.PrepForZeroHeightGenesis(ctx, app.payKeeper)
+	update.PrepForZeroHeightGenesis(ctx, app.updateKeeper)
 }
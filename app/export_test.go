@@ -0,0 +1,99 @@
+package app
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+	"github.com/terra-project/core/update"
+	"github.com/terra-project/core/x/budget"
+	"github.com/terra-project/core/x/pay"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestZeroHeightExport(t *testing.T) {
+	genesisState := newTestGenesisState()
+	sender := genesisState.Accounts[0].Address
+	recipient := genesisState.Accounts[1].Address
+
+	// a candidate program, a scheduled payment and a hash time lock pending past the export, and
+	// an upgrade scheduled after it
+	genesisState.BudgetData.CandidatePrograms = budget.Programs{
+		budget.NewProgram(1, "program", "description", sender, recipient, 0,
+			sdk.NewInt64Coin(assets.MicroSDRDenom, 0),
+			sdk.NewInt64Coin(assets.MicroSDRDenom, 1000),
+			sdk.NewInt64Coin(assets.MicroSDRDenom, 0),
+			assets.MicroSDRDenom),
+	}
+
+	amount := sdk.Coins{sdk.NewInt64Coin(assets.MicroSDRDenom, 1000)}
+	hashLock := sha256.Sum256([]byte("secret"))
+	genesisState.PayData.ScheduledPayments = pay.ScheduledPayments{
+		pay.NewScheduledPayment(1, sender, recipient, amount, 100, 10, 2),
+	}
+	genesisState.PayData.HTLCs = pay.HTLCs{
+		pay.NewHTLC(1, sender, recipient, amount, hashLock[:], 200),
+	}
+	genesisState.Accounts = append(genesisState.Accounts, NewGenesisAccount(&auth.BaseAccount{
+		Address: pay.EscrowAddress,
+		Coins:   sdk.Coins{sdk.NewInt64Coin(assets.MicroSDRDenom, 3000)},
+	}))
+
+	genesisState.UpdateData.UpgradePlan = update.NewUpgradePlan("restart", 300, "")
+
+	appState, err := codec.MarshalJSONIndent(MakeCodec(), genesisState)
+	require.NoError(t, err)
+
+	app := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, true)
+	runBlocks(t, app, appState, 20)
+
+	exported, _, err := app.ExportAppStateAndValidators(true, nil)
+	require.NoError(t, err)
+
+	var exportedState GenesisState
+	require.NoError(t, app.cdc.UnmarshalJSON(exported, &exportedState))
+	require.NoError(t, TerraValidateGenesisState(exportedState))
+
+	// heights are relative to the export
+	require.Equal(t, 1, len(exportedState.BudgetData.CandidatePrograms))
+	require.Equal(t, int64(-20), exportedState.BudgetData.CandidatePrograms[0].SubmitBlock)
+	require.Equal(t, int64(80), exportedState.PayData.ScheduledPayments[0].NextPayHeight)
+	require.Equal(t, int64(180), exportedState.PayData.HTLCs[0].TimeoutHeight)
+	require.Equal(t, int64(280), exportedState.UpdateData.UpgradePlan.Height)
+
+	// the restarted chain starts on the next epoch, with the day of its first block; the clock
+	// transition height, reached at height 5, is rebased to the first block
+	require.NotNil(t, exportedState.MintData.Clock)
+	require.Equal(t, util.Clock{Day: util.DaysPerEpoch}, *exportedState.MintData.Clock)
+	require.Equal(t, int64(1), exportedState.MintData.Params.ClockTransitionHeight)
+
+	// the restarted chain runs with the invariants asserted at the end of every block
+	restarted := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, true)
+	runBlocks(t, restarted, exported, 10)
+
+	ctx := restarted.NewContext(true, abci.Header{Height: restarted.LastBlockHeight()})
+	require.Equal(t, sdk.OneInt(), restarted.mintKeeper.GetEpoch(ctx))
+
+	// the program is still a candidate
+	program, err := restarted.budgetKeeper.GetProgram(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, restarted.budgetKeeper.CandQueueHas(ctx,
+		program.SubmitBlock+genesisState.BudgetData.Params.VotePeriod, program.ProgramID))
+
+	// the payment and the lock are still pending
+	payment, err := restarted.payKeeper.GetScheduledPayment(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), payment.Paid)
+
+	_, err = restarted.payKeeper.GetHTLC(ctx, 1)
+	require.NoError(t, err)
+}
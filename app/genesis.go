@@ -13,9 +13,12 @@ import (
 
 	"github.com/terra-project/core/types"
 	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/update"
 	"github.com/terra-project/core/x/budget"
 	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
 	"github.com/terra-project/core/x/oracle"
+	"github.com/terra-project/core/x/pay"
 	"github.com/terra-project/core/x/treasury"
 
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -43,6 +46,9 @@ type GenesisState struct {
 	CrisisData   crisis.GenesisState   `json:"crisis"`
 	SlashingData slashing.GenesisState `json:"slashing"`
 	MarketData   market.GenesisState   `json:"market"`
+	PayData      pay.GenesisState      `json:"pay"`
+	UpdateData   update.GenesisState   `json:"update"`
+	MintData     mint.GenesisState     `json:"mint"`
 	GenTxs       []json.RawMessage     `json:"gentxs"`
 }
 
@@ -57,7 +63,10 @@ func NewGenesisState(accounts []GenesisAccount,
 	crisisData crisis.GenesisState,
 	treasuryData treasury.GenesisState,
 	slashingData slashing.GenesisState,
-	marketData market.GenesisState) GenesisState {
+	marketData market.GenesisState,
+	payData pay.GenesisState,
+	updateData update.GenesisState,
+	mintData mint.GenesisState) GenesisState {
 
 	return GenesisState{
 		Accounts:     accounts,
@@ -71,9 +80,46 @@ func NewGenesisState(accounts []GenesisAccount,
 		BudgetData:   budgetData,
 		SlashingData: slashingData,
 		MarketData:   marketData,
+		PayData:      payData,
+		UpdateData:   updateData,
+		MintData:     mintData,
 	}
 }
 
+// moduleGenesis returns the genesis of every module in the genesis state, by module name
+func (gs GenesisState) moduleGenesis(cdc *codec.Codec) map[string]json.RawMessage {
+	var genesis map[string]json.RawMessage
+	if err := json.Unmarshal(cdc.MustMarshalJSON(gs), &genesis); err != nil {
+		panic(err)
+	}
+
+	return genesis
+}
+
+// newGenesisStateFromModules builds the genesis state of {accounts} and of the genesis of every
+// module, by module name
+func newGenesisStateFromModules(cdc *codec.Codec, accounts []GenesisAccount,
+	moduleGenesis map[string]json.RawMessage) (genesisState GenesisState, err error) {
+
+	genesis := map[string]json.RawMessage{}
+	for name, bz := range moduleGenesis {
+		genesis[name] = bz
+	}
+
+	genesis["accounts"], err = cdc.MarshalJSON(accounts)
+	if err != nil {
+		return
+	}
+
+	bz, err := json.Marshal(genesis)
+	if err != nil {
+		return
+	}
+
+	err = cdc.UnmarshalJSON(bz, &genesisState)
+	return
+}
+
 // Sanitize sorts accounts and coin sets.
 func (gs GenesisState) Sanitize() {
 	sort.Slice(gs.Accounts, func(i, j int) bool {
@@ -100,6 +146,8 @@ type GenesisAccount struct {
 	EndTime              int64                       `json:"end_time"`               // vesting end time (UNIX Epoch time)
 	VestingSchedules     []types.VestingSchedule     `json:"vesting_schedules"`      // vesting schedule (clif: UNIX Epoch time, ratio: dec)
 	LazyVestingSchedules []types.LazyVestingSchedule `json:"lazy_vesting_schedules"` // lazy vesting schedule (start_time&end_time: UNIX Epoch time, ratio: dec)
+	Funder               sdk.AccAddress              `json:"funder"`                 // address allowed to claw back the lazy vesting coins
+	ClawbackTime         int64                       `json:"clawback_time"`          // time the lazy vesting was clawed back (UNIX Epoch time)
 }
 
 // NewGenesisAccount returns new genesis account
@@ -138,6 +186,12 @@ func NewGenesisAccountI(acc auth.Account) GenesisAccount {
 		if ok {
 			gacc.LazyVestingSchedules = lgvacc.GetLazyVestingSchedules()
 		}
+
+		cvacc, ok := vacc.(types.ClawbackVestingAccount)
+		if ok {
+			gacc.Funder = cvacc.GetFunder()
+			gacc.ClawbackTime = cvacc.GetClawbackTime()
+		}
 	}
 
 	return gacc
@@ -176,10 +230,20 @@ func (ga *GenesisAccount) ToAccount() auth.Account {
 				VestingSchedules:   ga.VestingSchedules,
 			}
 		} else if ga.LazyVestingSchedules != nil {
-			return &types.BaseLazyGradedVestingAccount{
+			lazyVestingAcc := &types.BaseLazyGradedVestingAccount{
 				BaseVestingAccount:   baseVestingAcc,
 				LazyVestingSchedules: ga.LazyVestingSchedules,
 			}
+
+			if !ga.Funder.Empty() {
+				return &types.BaseClawbackVestingAccount{
+					BaseLazyGradedVestingAccount: lazyVestingAcc,
+					Funder:                       ga.Funder,
+					ClawbackTime:                 ga.ClawbackTime,
+				}
+			}
+
+			return lazyVestingAcc
 		}
 	}
 
@@ -257,6 +321,9 @@ func NewDefaultGenesisState() GenesisState {
 		CrisisData:   crisis.DefaultGenesisState(),
 		SlashingData: slashing.DefaultGenesisState(),
 		MarketData:   market.DefaultGenesisState(),
+		PayData:      pay.DefaultGenesisState(),
+		UpdateData:   update.DefaultGenesisState(),
+		MintData:     mint.DefaultGenesisState(),
 		GenTxs:       nil,
 	}
 }
@@ -270,31 +337,58 @@ func TerraValidateGenesisState(genesisState GenesisState) error {
 		return err
 	}
 
+	if err := validateGenesisStateEscrow(genesisState); err != nil {
+		return err
+	}
+
 	// skip stakingData validation as genesis is created from txs
 	if len(genesisState.GenTxs) > 0 {
 		return nil
 	}
 
-	if err := auth.ValidateGenesis(genesisState.AuthData); err != nil {
-		return err
-	}
-	if err := bank.ValidateGenesis(genesisState.BankData); err != nil {
-		return err
-	}
-	if err := staking.ValidateGenesis(genesisState.StakingData); err != nil {
-		return err
+	// the genesis of oracle, budget and treasury has never been validated here, and genesis files
+	// exported from live chains are not required to pass their checks
+	genesis := genesisState.moduleGenesis(moduleCdc)
+	for _, b := range ModuleBasics {
+		if unvalidatedGenesis[b.Name()] {
+			continue
+		}
+
+		if err := b.ValidateGenesis(genesis[b.Name()]); err != nil {
+			return err
+		}
 	}
-	if err := distr.ValidateGenesis(genesisState.DistrData); err != nil {
+
+	return nil
+}
+
+// unvalidatedGenesis is the set of modules whose genesis TerraValidateGenesisState does not validate
+var unvalidatedGenesis = map[string]bool{
+	oracle.ModuleName:   true,
+	budget.ModuleName:   true,
+	treasury.ModuleName: true,
+}
+
+// validateGenesisStateEscrow ensures that the pay escrow account holds the coins escrowed for the
+// scheduled payments and hash time locks of the genesis
+func validateGenesisStateEscrow(genesisState GenesisState) error {
+	escrowed, err := pay.EscrowedCoins(genesisState.PayData)
+	if err != nil {
 		return err
 	}
-	if err := slashing.ValidateGenesis(genesisState.SlashingData); err != nil {
-		return err
+
+	balance := sdk.Coins{}
+	for _, acc := range genesisState.Accounts {
+		if acc.Address.Equals(pay.EscrowAddress) {
+			balance = acc.Coins
+		}
 	}
-	if err := crisis.ValidateGenesis(genesisState.CrisisData); err != nil {
-		return err
+
+	if !balance.IsAllGTE(escrowed) {
+		return fmt.Errorf("pay escrow holds %s, less than the %s escrowed by the genesis", balance, escrowed)
 	}
 
-	return market.ValidateGenesis(genesisState.MarketData)
+	return nil
 }
 
 // validateGenesisStateAccounts performs validation of genesis accounts. It
@@ -310,6 +404,11 @@ func validateGenesisStateAccounts(accs []GenesisAccount) error {
 			return fmt.Errorf("duplicate account found in genesis state; address: %s", addrStr)
 		}
 
+		// only lazy graded vesting accounts can be clawed back
+		if !acc.Funder.Empty() && (acc.OriginalVesting.IsZero() || len(acc.LazyVestingSchedules) == 0) {
+			return fmt.Errorf("funder set on an account without lazy vesting schedules; address: %s", addrStr)
+		}
+
 		// validate any vesting fields
 		if !acc.OriginalVesting.IsZero() {
 
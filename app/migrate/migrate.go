@@ -0,0 +1,67 @@
+// Package migrate converts the genesis exported by a version of Terra to the format the next versions expect.
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// MigrationFunc converts an app state in the format of the previous version to the format of its version
+type MigrationFunc func(cdc *codec.Codec, appState json.RawMessage) (json.RawMessage, error)
+
+// Migration is the conversion of the app state to the format of Version
+type Migration struct {
+	Version string
+	Migrate MigrationFunc
+}
+
+// Migrations lists every migration, oldest version first. Each migration takes the app state
+// produced by the previous one, so that a genesis can be migrated over several versions at once.
+var Migrations = []Migration{
+	{"v0.2.2", migrateV022},
+}
+
+// Versions returns the versions a genesis can be migrated to
+func Versions() (versions []string) {
+	for _, m := range Migrations {
+		versions = append(versions, m.Version)
+	}
+	return
+}
+
+// Migrate chains the migrations after {fromVersion} up to {targetVersion}. An empty {fromVersion}
+// applies every migration up to {targetVersion}.
+func Migrate(cdc *codec.Codec, appState json.RawMessage, fromVersion, targetVersion string) (json.RawMessage, error) {
+	from, target := -1, -1
+	for i, m := range Migrations {
+		if m.Version == fromVersion {
+			from = i
+		}
+		if m.Version == targetVersion {
+			target = i
+		}
+	}
+
+	if target == -1 {
+		return nil, fmt.Errorf("unknown target version %s; known versions are %v", targetVersion, Versions())
+	}
+
+	if len(fromVersion) != 0 && from == -1 {
+		return nil, fmt.Errorf("unknown source version %s; known versions are %v", fromVersion, Versions())
+	}
+
+	if from >= target {
+		return nil, fmt.Errorf("target version %s must be newer than source version %s", targetVersion, fromVersion)
+	}
+
+	for _, m := range Migrations[from+1 : target+1] {
+		var err error
+		if appState, err = m.Migrate(cdc, appState); err != nil {
+			return nil, fmt.Errorf("failed to migrate to %s: %s", m.Version, err)
+		}
+	}
+
+	return appState, nil
+}
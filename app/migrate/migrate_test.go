@@ -0,0 +1,147 @@
+package migrate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/terra-project/core/app"
+	"github.com/terra-project/core/app/migrate/v0_2_1"
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+)
+
+const secondsPerMonth = 30 * 24 * 60 * 60
+
+var genesisTime = time.Unix(1556085600, 0).UTC()
+
+func init() {
+	config := sdk.GetConfig()
+	config.SetBech32PrefixForAccount(util.Bech32PrefixAccAddr, util.Bech32PrefixAccPub)
+	config.SetBech32PrefixForValidator(util.Bech32PrefixValAddr, util.Bech32PrefixValPub)
+	config.SetBech32PrefixForConsensusNode(util.Bech32PrefixConsAddr, util.Bech32PrefixConsPub)
+}
+
+// loadAppState returns the app state of the genesis exported by a v0.2.1 chain
+func loadAppState(t *testing.T) map[string]json.RawMessage {
+	bz, err := ioutil.ReadFile("testdata/v0_2_1-export.json")
+	require.Nil(t, err)
+
+	var genDoc struct {
+		AppState map[string]json.RawMessage `json:"app_state"`
+	}
+	require.Nil(t, json.Unmarshal(bz, &genDoc))
+
+	return genDoc.AppState
+}
+
+func marshalAppState(t *testing.T, appState map[string]json.RawMessage) json.RawMessage {
+	bz, err := json.Marshal(appState)
+	require.Nil(t, err)
+	return bz
+}
+
+func requireEqualJSON(t *testing.T, expected, actual json.RawMessage) {
+	var expectedValue, actualValue interface{}
+	require.Nil(t, json.Unmarshal(expected, &expectedValue))
+	require.Nil(t, json.Unmarshal(actual, &actualValue))
+	require.Equal(t, expectedValue, actualValue)
+}
+
+func TestMigrateV022(t *testing.T) {
+	cdc := app.MakeCodec()
+	appState := loadAppState(t)
+
+	migrated, err := Migrate(cdc, marshalAppState(t, appState), "", "v0.2.2")
+	require.Nil(t, err)
+
+	var migratedState map[string]json.RawMessage
+	require.Nil(t, json.Unmarshal(migrated, &migratedState))
+
+	// The state of the modules is carried over as is
+	require.Equal(t, len(appState), len(migratedState))
+	for name, bz := range appState {
+		if name == v0_2_1.AccountsKey {
+			continue
+		}
+		requireEqualJSON(t, bz, migratedState[name])
+	}
+
+	var accounts []app.GenesisAccount
+	require.Nil(t, cdc.UnmarshalJSON(migratedState["accounts"], &accounts))
+	require.Equal(t, 2, len(accounts))
+
+	// The cliffs are spread over the following month, as update 230000 did
+	gradedAcc := accounts[0]
+	require.Nil(t, gradedAcc.VestingSchedules)
+	require.Equal(t, []types.LazyVestingSchedule{
+		types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+			types.NewLazySchedule(genesisTime.AddDate(0, 1, 0).Unix(), genesisTime.AddDate(0, 1, 0).Unix()+secondsPerMonth, sdk.NewDecWithPrec(50, 2)),
+			types.NewLazySchedule(genesisTime.AddDate(0, 2, 0).Unix(), genesisTime.AddDate(0, 2, 0).Unix()+secondsPerMonth, sdk.NewDecWithPrec(50, 2)),
+		}),
+	}, gradedAcc.LazyVestingSchedules)
+
+	lgvacc, ok := gradedAcc.ToAccount().(types.LazyGradedVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, uint64(12), lgvacc.GetSequence())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 3000000000)}, lgvacc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 5000000000)}, lgvacc.GetOriginalVesting())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 2000000000)}, lgvacc.GetDelegatedVesting())
+
+	// Other accounts are left untouched
+	var oldAccounts []v0_2_1.GenesisAccount
+	require.Nil(t, cdc.UnmarshalJSON(appState[v0_2_1.AccountsKey], &oldAccounts))
+
+	baseAcc := accounts[1]
+	require.Equal(t, oldAccounts[1].Address, baseAcc.Address)
+	require.Equal(t, oldAccounts[1].Coins, baseAcc.Coins)
+	require.Equal(t, oldAccounts[1].Sequence, baseAcc.Sequence)
+	require.Equal(t, oldAccounts[1].AccountNumber, baseAcc.AccountNumber)
+	require.Nil(t, baseAcc.LazyVestingSchedules)
+}
+
+func TestMigrateV022InvalidPreseedAccount(t *testing.T) {
+	cdc := app.MakeCodec()
+
+	// preseed accounts must have the exact preseed schedule
+	preseedAddr, err := sdk.AccAddressFromBech32("terra1p54hc4yy2ajg67j645dn73w3378j6k05v52cnk")
+	require.Nil(t, err)
+
+	coins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 1000000)}
+	appState := loadAppState(t)
+	appState[v0_2_1.AccountsKey], err = cdc.MarshalJSON([]v0_2_1.GenesisAccount{{
+		Address:         preseedAddr,
+		Coins:           coins,
+		OriginalVesting: coins,
+		VestingSchedules: []v0_2_1.VestingSchedule{{
+			Denom:     assets.MicroLunaDenom,
+			Schedules: []v0_2_1.Schedule{{Cliff: genesisTime.AddDate(0, 1, 0).Unix(), Ratio: sdk.OneDec()}},
+		}},
+	}})
+	require.Nil(t, err)
+
+	_, err = Migrate(cdc, marshalAppState(t, appState), "", "v0.2.2")
+	require.NotNil(t, err)
+}
+
+func TestMigrateVersions(t *testing.T) {
+	cdc := app.MakeCodec()
+	appState := marshalAppState(t, loadAppState(t))
+
+	_, err := Migrate(cdc, appState, "", "v0.0.1")
+	require.NotNil(t, err)
+
+	_, err = Migrate(cdc, appState, "v0.0.1", "v0.2.2")
+	require.NotNil(t, err)
+
+	_, err = Migrate(cdc, appState, "v0.2.2", "v0.2.2")
+	require.NotNil(t, err)
+
+	require.Equal(t, []string{"v0.2.2"}, Versions())
+}
@@ -0,0 +1,38 @@
+// Package v0_2_1 holds the genesis types of Terra v0.2.1 that the migrations read. They are frozen
+// to the format v0.2.1 exports, and must not follow the changes of the current types.
+package v0_2_1 // nolint
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccountsKey is the key of the accounts in the app state
+const AccountsKey = "accounts"
+
+// Schedule is a cliff of a graded vesting schedule
+type Schedule struct {
+	Cliff int64   `json:"cliff"`
+	Ratio sdk.Dec `json:"ratio"`
+}
+
+// VestingSchedule is the graded vesting schedule of a denom
+type VestingSchedule struct {
+	Denom     string     `json:"denom"`
+	Schedules []Schedule `json:"schedules"`
+}
+
+// GenesisAccount is an account of the genesis
+type GenesisAccount struct {
+	Address       sdk.AccAddress `json:"address"`
+	Coins         sdk.Coins      `json:"coins"`
+	Sequence      uint64         `json:"sequence_number"`
+	AccountNumber uint64         `json:"account_number"`
+
+	// vesting account fields
+	OriginalVesting  sdk.Coins         `json:"original_vesting"`
+	DelegatedFree    sdk.Coins         `json:"delegated_free"`
+	DelegatedVesting sdk.Coins         `json:"delegated_vesting"`
+	StartTime        int64             `json:"start_time"`
+	EndTime          int64             `json:"end_time"`
+	VestingSchedules []VestingSchedule `json:"vesting_schedules"`
+}
@@ -0,0 +1,39 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/terra-project/core/app/migrate/v0_2_1"
+	"github.com/terra-project/core/app/migrate/v0_2_2"
+)
+
+// migrateV022 converts the graded vesting accounts of a v0.2.1 genesis to lazy graded vesting accounts,
+// with the schedules update 230000 gave them on chain. The state of the modules is carried over as is.
+func migrateV022(cdc *codec.Codec, appState json.RawMessage) (migrated json.RawMessage, err error) {
+	var genesis map[string]json.RawMessage
+	if err = json.Unmarshal(appState, &genesis); err != nil {
+		return nil, err
+	}
+
+	var oldAccounts []v0_2_1.GenesisAccount
+	if err = cdc.UnmarshalJSON(genesis[v0_2_1.AccountsKey], &oldAccounts); err != nil {
+		return nil, err
+	}
+
+	// the schedule checks of update 230000 panic on unexpected preseed and seed accounts
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	delete(genesis, v0_2_1.AccountsKey)
+	if genesis[v0_2_2.AccountsKey], err = cdc.MarshalJSON(v0_2_2.MigrateAccounts(oldAccounts)); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(genesis)
+}
@@ -0,0 +1,74 @@
+package v0_2_2 // nolint
+
+import (
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/terra-project/core/app/migrate/v0_2_1"
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/update/plan"
+)
+
+// MigrateAccounts converts the graded vesting accounts to lazy graded vesting accounts, with the
+// schedules update 230000 gave them on chain. The other accounts are carried over as they are.
+//
+// CONTRACT: panics on the preseed and seed accounts whose schedules update 230000 does not expect
+func MigrateAccounts(oldAccounts []v0_2_1.GenesisAccount) (accounts []GenesisAccount) {
+	for _, oldAcc := range oldAccounts {
+		acc := GenesisAccount{
+			Address:          oldAcc.Address,
+			Coins:            oldAcc.Coins,
+			Sequence:         oldAcc.Sequence,
+			AccountNumber:    oldAcc.AccountNumber,
+			OriginalVesting:  oldAcc.OriginalVesting,
+			DelegatedFree:    oldAcc.DelegatedFree,
+			DelegatedVesting: oldAcc.DelegatedVesting,
+			StartTime:        oldAcc.StartTime,
+			EndTime:          oldAcc.EndTime,
+		}
+
+		if oldAcc.VestingSchedules != nil {
+			acc.LazyVestingSchedules = migrateVestingSchedules(oldAcc)
+		}
+
+		accounts = append(accounts, acc)
+	}
+
+	return
+}
+
+func migrateVestingSchedules(oldAcc v0_2_1.GenesisAccount) (lazyVestingSchedules []LazyVestingSchedule) {
+	var vestingSchedules []types.VestingSchedule
+	for _, vs := range oldAcc.VestingSchedules {
+		var schedules []types.Schedule
+		for _, s := range vs.Schedules {
+			schedules = append(schedules, types.NewSchedule(s.Cliff, s.Ratio))
+		}
+		vestingSchedules = append(vestingSchedules, types.NewVestingSchedule(vs.Denom, schedules))
+	}
+
+	gvacc := &types.BaseGradedVestingAccount{
+		BaseVestingAccount: &auth.BaseVestingAccount{
+			BaseAccount: &auth.BaseAccount{
+				Address:       oldAcc.Address,
+				Coins:         oldAcc.Coins,
+				AccountNumber: oldAcc.AccountNumber,
+				Sequence:      oldAcc.Sequence,
+			},
+			OriginalVesting:  oldAcc.OriginalVesting,
+			DelegatedFree:    oldAcc.DelegatedFree,
+			DelegatedVesting: oldAcc.DelegatedVesting,
+			EndTime:          oldAcc.EndTime,
+		},
+		VestingSchedules: vestingSchedules,
+	}
+
+	for _, lvs := range plan.LazyVestingSchedules(gvacc) {
+		var lazySchedules []LazySchedule
+		for _, s := range lvs.LazySchedules {
+			lazySchedules = append(lazySchedules, LazySchedule{StartTime: s.StartTime, EndTime: s.EndTime, Ratio: s.Ratio})
+		}
+		lazyVestingSchedules = append(lazyVestingSchedules, LazyVestingSchedule{Denom: lvs.Denom, LazySchedules: lazySchedules})
+	}
+
+	return
+}
@@ -0,0 +1,49 @@
+// Package v0_2_2 holds the genesis types of Terra v0.2.2 that the migrations write, and the
+// migration of the v0.2.1 types to them. The types are frozen to the format v0.2.2 expects, and
+// must not follow the changes of the current types.
+package v0_2_2 // nolint
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/terra-project/core/app/migrate/v0_2_1"
+)
+
+// AccountsKey is the key of the accounts in the app state
+const AccountsKey = "accounts"
+
+// Schedule is a cliff of a graded vesting schedule
+type Schedule = v0_2_1.Schedule
+
+// VestingSchedule is the graded vesting schedule of a denom
+type VestingSchedule = v0_2_1.VestingSchedule
+
+// LazySchedule is a period of a lazy graded vesting schedule, over which the ratio vests linearly
+type LazySchedule struct {
+	StartTime int64   `json:"start_time"`
+	EndTime   int64   `json:"end_time"`
+	Ratio     sdk.Dec `json:"ratio"`
+}
+
+// LazyVestingSchedule is the lazy graded vesting schedule of a denom
+type LazyVestingSchedule struct {
+	Denom         string         `json:"denom"`
+	LazySchedules []LazySchedule `json:"schedules"`
+}
+
+// GenesisAccount is an account of the genesis
+type GenesisAccount struct {
+	Address       sdk.AccAddress `json:"address"`
+	Coins         sdk.Coins      `json:"coins"`
+	Sequence      uint64         `json:"sequence_number"`
+	AccountNumber uint64         `json:"account_number"`
+
+	// vesting account fields
+	OriginalVesting      sdk.Coins             `json:"original_vesting"`
+	DelegatedFree        sdk.Coins             `json:"delegated_free"`
+	DelegatedVesting     sdk.Coins             `json:"delegated_vesting"`
+	StartTime            int64                 `json:"start_time"`
+	EndTime              int64                 `json:"end_time"`
+	VestingSchedules     []VestingSchedule     `json:"vesting_schedules"`
+	LazyVestingSchedules []LazyVestingSchedule `json:"lazy_vesting_schedules"`
+}
@@ -0,0 +1,336 @@
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/terra-project/core/types/module"
+	"github.com/terra-project/core/update"
+	"github.com/terra-project/core/x/budget"
+	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
+	"github.com/terra-project/core/x/oracle"
+	"github.com/terra-project/core/x/pay"
+	"github.com/terra-project/core/x/treasury"
+
+	tdistr "github.com/terra-project/core/x/distribution"
+	tslashing "github.com/terra-project/core/x/slashing"
+	tstaking "github.com/terra-project/core/x/staking"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// nolint
+const (
+	// names of the cosmos modules, which are also the keys of their genesis in the app genesis
+	authModuleName     = "auth"
+	bankModuleName     = "bank"
+	stakingModuleName  = "staking"
+	distrModuleName    = "distr"
+	slashingModuleName = "slashing"
+	crisisModuleName   = "crisis"
+)
+
+// ModuleBasics is the basics of every module of the app, used to build the codec and to validate
+// the genesis
+var ModuleBasics = module.NewBasicManager(
+	pay.AppModuleBasic{},
+	stakingModuleBasic{},
+	distrModuleBasic{},
+	slashingModuleBasic{},
+	authModuleBasic{},
+	bankModuleBasic{},
+	oracle.AppModuleBasic{},
+	budget.AppModuleBasic{},
+	market.AppModuleBasic{},
+	treasury.AppModuleBasic{},
+	crisisModuleBasic{},
+	mint.AppModuleBasic{},
+	update.AppModuleBasic{},
+)
+
+// moduleCdc encodes the genesis of the cosmos modules
+var moduleCdc = codec.New()
+
+func init() {
+	codec.RegisterCrypto(moduleCdc)
+}
+
+// cosmosModule implements the parts of module.AppModule the cosmos modules wired below do not use
+type cosmosModule struct{}
+
+func (cosmosModule) RegisterInvariants(_ *crisis.Keeper) {}
+func (cosmosModule) Route() string                       { return "" }
+func (cosmosModule) NewHandler() sdk.Handler             { return nil }
+func (cosmosModule) QuerierRoute() string                { return "" }
+func (cosmosModule) NewQuerierHandler() sdk.Querier      { return nil }
+func (cosmosModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return sdk.EmptyTags()
+}
+func (cosmosModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) ([]abci.ValidatorUpdate, sdk.Tags) {
+	return nil, sdk.EmptyTags()
+}
+
+//-----------------------------------------------------------------------------
+// Auth
+
+type authModuleBasic struct{}
+
+func (authModuleBasic) Name() string                   { return authModuleName }
+func (authModuleBasic) RegisterCodec(cdc *codec.Codec) { auth.RegisterCodec(cdc) }
+func (authModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data auth.GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return auth.ValidateGenesis(data)
+}
+
+type authModule struct {
+	authModuleBasic
+	cosmosModule
+	ak  auth.AccountKeeper
+	fck auth.FeeCollectionKeeper
+}
+
+func (am authModule) QuerierRoute() string           { return auth.QuerierRoute }
+func (am authModule) NewQuerierHandler() sdk.Querier { return auth.NewQuerier(am.ak) }
+func (am authModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data auth.GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	auth.InitGenesis(ctx, am.ak, am.fck, data)
+	return nil
+}
+func (am authModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(auth.ExportGenesis(ctx, am.ak, am.fck))
+}
+
+//-----------------------------------------------------------------------------
+// Bank
+
+// bankModuleBasic registers no types; the bank messages are registered by the pay module
+type bankModuleBasic struct{}
+
+func (bankModuleBasic) Name() string                 { return bankModuleName }
+func (bankModuleBasic) RegisterCodec(_ *codec.Codec) {}
+func (bankModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data bank.GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return bank.ValidateGenesis(data)
+}
+
+// bankModule routes the bank messages to {handler}, as the pay module handles them
+type bankModule struct {
+	bankModuleBasic
+	cosmosModule
+	bk      bank.Keeper
+	ak      auth.AccountKeeper
+	handler sdk.Handler
+}
+
+func (am bankModule) RegisterInvariants(ck *crisis.Keeper) { bank.RegisterInvariants(ck, am.ak) }
+func (am bankModule) Route() string                        { return bank.RouterKey }
+func (am bankModule) NewHandler() sdk.Handler              { return am.handler }
+func (am bankModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data bank.GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	bank.InitGenesis(ctx, am.bk, data)
+	return nil
+}
+func (am bankModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(bank.ExportGenesis(ctx, am.bk))
+}
+
+//-----------------------------------------------------------------------------
+// Staking
+
+type stakingModuleBasic struct{}
+
+func (stakingModuleBasic) Name() string                   { return stakingModuleName }
+func (stakingModuleBasic) RegisterCodec(cdc *codec.Codec) { tstaking.RegisterCodec(cdc) }
+func (stakingModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data staking.GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return staking.ValidateGenesis(data)
+}
+
+type stakingModule struct {
+	stakingModuleBasic
+	cosmosModule
+	cdc *codec.Codec
+	sk  staking.Keeper
+	ak  auth.AccountKeeper
+	fck auth.FeeCollectionKeeper
+	dk  distr.Keeper
+}
+
+func (am stakingModule) RegisterInvariants(ck *crisis.Keeper) {
+	staking.RegisterInvariants(ck, am.sk, am.fck, am.dk, am.ak)
+}
+func (am stakingModule) Route() string                  { return staking.RouterKey }
+func (am stakingModule) NewHandler() sdk.Handler        { return staking.NewHandler(am.sk) }
+func (am stakingModule) QuerierRoute() string           { return staking.QuerierRoute }
+func (am stakingModule) NewQuerierHandler() sdk.Querier { return staking.NewQuerier(am.sk, am.cdc) }
+func (am stakingModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data staking.GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	validators, err := staking.InitGenesis(ctx, am.sk, data)
+	if err != nil {
+		panic(err) // TODO find a way to do this w/o panics
+	}
+
+	return validators
+}
+func (am stakingModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(staking.ExportGenesis(ctx, am.sk))
+}
+func (am stakingModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) ([]abci.ValidatorUpdate, sdk.Tags) {
+	return staking.EndBlocker(ctx, am.sk)
+}
+
+//-----------------------------------------------------------------------------
+// Distribution
+
+type distrModuleBasic struct{}
+
+func (distrModuleBasic) Name() string                   { return distrModuleName }
+func (distrModuleBasic) RegisterCodec(cdc *codec.Codec) { tdistr.RegisterCodec(cdc) }
+func (distrModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data distr.GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return distr.ValidateGenesis(data)
+}
+
+type distrModule struct {
+	distrModuleBasic
+	cosmosModule
+	dk distr.Keeper
+	sk staking.Keeper
+}
+
+func (am distrModule) RegisterInvariants(ck *crisis.Keeper) {
+	distr.RegisterInvariants(ck, am.dk, am.sk)
+}
+func (am distrModule) Route() string                  { return distr.RouterKey }
+func (am distrModule) NewHandler() sdk.Handler        { return distr.NewHandler(am.dk) }
+func (am distrModule) QuerierRoute() string           { return distr.QuerierRoute }
+func (am distrModule) NewQuerierHandler() sdk.Querier { return distr.NewQuerier(am.dk) }
+func (am distrModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data distr.GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	distr.InitGenesis(ctx, am.dk, data)
+	return nil
+}
+func (am distrModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(distr.ExportGenesis(ctx, am.dk))
+}
+
+// BeginBlock distributes the rewards for the previous block
+func (am distrModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) sdk.Tags {
+	distr.BeginBlocker(ctx, req, am.dk)
+	return sdk.EmptyTags()
+}
+
+//-----------------------------------------------------------------------------
+// Slashing
+
+type slashingModuleBasic struct{}
+
+func (slashingModuleBasic) Name() string                   { return slashingModuleName }
+func (slashingModuleBasic) RegisterCodec(cdc *codec.Codec) { tslashing.RegisterCodec(cdc) }
+func (slashingModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data slashing.GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return slashing.ValidateGenesis(data)
+}
+
+type slashingModule struct {
+	slashingModuleBasic
+	cosmosModule
+	cdc *codec.Codec
+	k   slashing.Keeper
+	sk  staking.Keeper
+}
+
+func (am slashingModule) Route() string                  { return slashing.RouterKey }
+func (am slashingModule) NewHandler() sdk.Handler        { return slashing.NewHandler(am.k) }
+func (am slashingModule) QuerierRoute() string           { return slashing.QuerierRoute }
+func (am slashingModule) NewQuerierHandler() sdk.Querier { return slashing.NewQuerier(am.k, am.cdc) }
+
+// InitGenesis must run after the staking genesis, whose validators it tracks
+func (am slashingModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data slashing.GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	validators := staking.Validators(am.sk.GetAllValidators(ctx))
+	slashing.InitGenesis(ctx, am.k, data, validators.ToSDKValidators())
+	return nil
+}
+func (am slashingModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(slashing.ExportGenesis(ctx, am.k))
+}
+
+// BeginBlock slashes anyone who double signed.
+// NOTE: This should happen after the distribution begin blocker so that
+// there is nothing left over in the validator fee pool,
+// so as to keep the CanWithdrawInvariant invariant.
+// TODO: This should really happen at EndBlocker.
+func (am slashingModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) sdk.Tags {
+	return slashing.BeginBlocker(ctx, req, am.k)
+}
+
+//-----------------------------------------------------------------------------
+// Crisis
+
+type crisisModuleBasic struct{}
+
+func (crisisModuleBasic) Name() string                   { return crisisModuleName }
+func (crisisModuleBasic) RegisterCodec(cdc *codec.Codec) { crisis.RegisterCodec(cdc) }
+func (crisisModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data crisis.GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return crisis.ValidateGenesis(data)
+}
+
+// crisisModule refers to the keeper the invariants are registered on, so that its handler knows
+// every invariant registered before the routes are
+type crisisModule struct {
+	crisisModuleBasic
+	cosmosModule
+	k *crisis.Keeper
+}
+
+func (am crisisModule) Route() string           { return crisis.RouterKey }
+func (am crisisModule) NewHandler() sdk.Handler { return crisis.NewHandler(*am.k) }
+func (am crisisModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data crisis.GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	crisis.InitGenesis(ctx, *am.k, data)
+	return nil
+}
+func (am crisisModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(crisis.ExportGenesis(ctx, *am.k))
+}
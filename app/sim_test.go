@@ -0,0 +1,197 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/terra-project/core/simulation"
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/types/assets"
+	budgetsim "github.com/terra-project/core/x/budget/simulation"
+	marketsim "github.com/terra-project/core/x/market/simulation"
+	oraclesim "github.com/terra-project/core/x/oracle/simulation"
+	paysim "github.com/terra-project/core/x/pay/simulation"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+)
+
+var (
+	enabled   bool
+	numBlocks int
+	blockSize int
+	seed      int64
+	verbose   bool
+)
+
+func init() {
+	flag.BoolVar(&enabled, "SimulationEnabled", false, "Enable the simulation")
+	flag.IntVar(&numBlocks, "NumBlocks", 500, "Number of blocks")
+	flag.IntVar(&blockSize, "BlockSize", 100, "Operations per block")
+	flag.Int64Var(&seed, "Seed", 42, "Simulation random seed")
+	flag.BoolVar(&verbose, "Verbose", false, "Verbose log output")
+}
+
+// denoms the oracle votes prices of, and Luna can be swapped to
+var simDenoms = []string{
+	assets.MicroSDRDenom,
+	assets.MicroKRWDenom,
+	assets.MicroUSDDenom,
+	assets.MicroJPYDenom,
+}
+
+// appStateFn generates a random genesis: accounts holding Luna and Terra, some of them lazy vesting
+// and clawback accounts, random oracle prices and vote period, and validators created by gentxs
+func appStateFn(r *rand.Rand, accs []simulation.Account, genesisTime time.Time, chainID string) json.RawMessage {
+	cdc := MakeCodec()
+	genesisState := NewDefaultGenesisState()
+
+	// the first accounts operate the validators; they feed their own prices
+	numValidators := 4 + r.Intn(7)
+	if numValidators > len(accs) {
+		numValidators = len(accs)
+	}
+
+	for i, acc := range accs {
+		luna := sdk.NewInt(100 + r.Int63n(1000)).MulRaw(assets.MicroUnit)
+		coins := sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, luna)}
+		for _, denom := range simDenoms {
+			coins = append(coins, sdk.NewCoin(denom, sdk.NewInt(1+r.Int63n(1000)).MulRaw(assets.MicroUnit)))
+		}
+
+		gacc := NewGenesisAccount(&auth.BaseAccount{Address: acc.Address, Coins: coins.Sort()})
+
+		// the Luna of some accounts vests in two steps during the simulation; some of them can be
+		// clawed back by another account
+		if i >= numValidators && r.Intn(5) == 0 {
+			start := genesisTime.Unix()
+			cliff := start + 600 + r.Int63n(3000)
+			end := cliff + 600 + r.Int63n(3000)
+
+			gacc.OriginalVesting = sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, luna)}
+			gacc.LazyVestingSchedules = []types.LazyVestingSchedule{
+				types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+					types.NewLazySchedule(start, cliff, sdk.NewDecWithPrec(5, 1)),
+					types.NewLazySchedule(cliff, end, sdk.NewDecWithPrec(5, 1)),
+				}),
+			}
+
+			if r.Intn(2) == 0 {
+				gacc.Funder = simulation.RandomAcc(r, accs).Address
+			}
+		}
+
+		genesisState.Accounts = append(genesisState.Accounts, gacc)
+		genesisState.StakingData.Pool.NotBondedTokens = genesisState.StakingData.Pool.NotBondedTokens.Add(luna)
+
+		if i < numValidators {
+			genesisState.GenTxs = append(genesisState.GenTxs, genTx(r, cdc, acc, luna, chainID))
+		}
+	}
+
+	// prices of Luna around a random one for every denom
+	for _, denom := range simDenoms {
+		price := sdk.NewDecWithPrec(1+r.Int63n(1000000), 3)
+		genesisState.OracleData.Prices = append(genesisState.OracleData.Prices, sdk.NewDecCoinFromDec(denom, price))
+	}
+	genesisState.OracleData.Params.VotePeriod = 1 + r.Int63n(10)
+
+	// days count by block time from a random height
+	genesisState.MintData.Params.ClockTransitionHeight = 1 + r.Int63n(int64(numBlocks)+1)
+
+	appState, err := cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+
+	return appState
+}
+
+// genTx generates the gentx of {acc} creating a validator with a random part of its {luna}
+func genTx(r *rand.Rand, cdc *codec.Codec, acc simulation.Account, luna sdk.Int, chainID string) json.RawMessage {
+	secret := make([]byte, 32)
+	r.Read(secret)
+
+	msg := staking.NewMsgCreateValidator(
+		sdk.ValAddress(acc.Address),
+		ed25519.GenPrivKeyFromSecret(secret).PubKey(),
+		sdk.NewCoin(assets.MicroLunaDenom, luna.QuoRaw(1+r.Int63n(4))),
+		staking.NewDescription(simulation.RandStringOfLength(r, 10), "", "", ""),
+		staking.NewCommissionMsg(sdk.NewDecWithPrec(r.Int63n(50), 2), sdk.NewDecWithPrec(50, 2), sdk.NewDecWithPrec(1, 2)),
+		sdk.OneInt(),
+	)
+
+	// the account numbers of gentxs are zero
+	tx := simulation.GenTx([]sdk.Msg{msg}, []uint64{0}, []uint64{0}, chainID, acc.PrivKey)
+	return cdc.MustMarshalJSON(tx)
+}
+
+// simulationOperations returns the random operations of the simulation, by weight
+func simulationOperations(app *TerraApp) []simulation.WeightedOperation {
+	swapDenoms := append([]string{assets.MicroLunaDenom}, simDenoms...)
+
+	return []simulation.WeightedOperation{
+		{Weight: 100, Op: oraclesim.SimulateMsgPricePrevoteVote(app.accountKeeper, app.stakingKeeper, app.oracleKeeper, simDenoms)},
+		{Weight: 50, Op: marketsim.SimulateMsgSwap(app.accountKeeper, swapDenoms)},
+		{Weight: 5, Op: budgetsim.SimulateMsgSubmitProgram(app.accountKeeper, simDenoms)},
+		{Weight: 20, Op: budgetsim.SimulateMsgVoteProgram(app.accountKeeper, app.budgetKeeper)},
+		{Weight: 50, Op: paysim.SimulateMsgSend(app.accountKeeper)},
+	}
+}
+
+// TestFullTerraSimulation runs the simulation with the invariants asserted at the end of every block
+func TestFullTerraSimulation(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping Terra simulation")
+	}
+
+	logger := log.NewNopLogger()
+	if verbose {
+		logger = log.TestingLogger()
+	}
+
+	app := NewTerraApp(logger, dbm.NewMemDB(), nil, true, true)
+
+	_, err := simulation.SimulateFromSeed(t, app.BaseApp, appStateFn, seed,
+		simulationOperations(app), numBlocks, blockSize, verbose)
+	require.NoError(t, err)
+}
+
+// TestAppStateDeterminism runs the simulation twice from every seed and compares the app hashes
+func TestAppStateDeterminism(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping Terra simulation")
+	}
+
+	numSeeds := 3
+	numTimesToRun := 2
+
+	for i := 0; i < numSeeds; i++ {
+		runSeed := seed + int64(i)
+
+		var appHashes [][]byte
+		for j := 0; j < numTimesToRun; j++ {
+			app := NewTerraApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, false)
+
+			appHash, err := simulation.SimulateFromSeed(t, app.BaseApp, appStateFn, runSeed,
+				simulationOperations(app), 50, 100, false)
+			require.NoError(t, err)
+
+			appHashes = append(appHashes, appHash)
+		}
+
+		for j := 1; j < numTimesToRun; j++ {
+			require.Equal(t, appHashes[0], appHashes[j], "non-determinism in seed %d", runSeed)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package init
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/terra-project/core/app"
+	"github.com/terra-project/core/app/migrate"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagFromVersion = "from-version"
+	flagGenesisTime = "genesis-time"
+)
+
+// MigrateGenesisCmd returns a command that converts a genesis exported by a previous version of terrad
+// to the format of the target version, and prints it out
+func MigrateGenesisCmd(_ *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [target-version] [genesis-file]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Migrate a genesis file to the format of a newer version",
+		Long: fmt.Sprintf(`Migrate the app state of the genesis exported by a previous version of terrad to the format
+of [target-version], and print the migrated genesis out. Migrations are applied one version after
+another, starting after --from-version if given. Supported versions: %s
+
+$ terrad migrate v0.2.2 /path/to/genesis.json --chain-id=columbus-2 --genesis-time=2019-06-01T00:00:00Z
+`, strings.Join(migrate.Versions(), ", ")),
+		RunE: func(_ *cobra.Command, args []string) error {
+			target, genesis := args[0], args[1]
+
+			genDoc, err := LoadGenesisDoc(cdc, genesis)
+			if err != nil {
+				return fmt.Errorf("failed to load genesis doc from %s: %s", genesis, err.Error())
+			}
+
+			appState, err := migrate.Migrate(cdc, genDoc.AppState, viper.GetString(flagFromVersion), target)
+			if err != nil {
+				return err
+			}
+
+			var genesisState app.GenesisState
+			if err = cdc.UnmarshalJSON(appState, &genesisState); err != nil {
+				return fmt.Errorf("failed to unmarshal migrated app state: %s", err.Error())
+			}
+
+			if err = app.TerraValidateGenesisState(genesisState); err != nil {
+				return fmt.Errorf("migrated genesis is invalid: %s", err.Error())
+			}
+
+			genDoc.AppState = appState
+
+			if genesisTime := viper.GetString(flagGenesisTime); len(genesisTime) != 0 {
+				if genDoc.GenesisTime, err = time.Parse(time.RFC3339, genesisTime); err != nil {
+					return fmt.Errorf("genesis time %s is not in RFC3339 format: %s", genesisTime, err.Error())
+				}
+			}
+
+			if chainID := viper.GetString(client.FlagChainID); len(chainID) != 0 {
+				genDoc.ChainID = chainID
+			}
+
+			bz, err := cdc.MarshalJSONIndent(genDoc, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(sdk.MustSortJSON(bz)))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagFromVersion, "", "version that exported the genesis; default applies every migration up to [target-version]")
+	cmd.Flags().String(flagGenesisTime, "", "override the genesis time, in RFC3339 format")
+	cmd.Flags().String(client.FlagChainID, "", "override the chain id")
+
+	return cmd
+}
@@ -34,13 +34,16 @@ import (
 
 	budget "github.com/terra-project/core/x/budget/client/rest"
 	market "github.com/terra-project/core/x/market/client/rest"
+	mint "github.com/terra-project/core/x/mint/client/rest"
 	oracle "github.com/terra-project/core/x/oracle/client/rest"
 	pay "github.com/terra-project/core/x/pay/client/rest"
 	treasury "github.com/terra-project/core/x/treasury/client/rest"
 
 	bud "github.com/terra-project/core/x/budget"
 	mkt "github.com/terra-project/core/x/market"
+	mnt "github.com/terra-project/core/x/mint"
 	ora "github.com/terra-project/core/x/oracle"
+	py "github.com/terra-project/core/x/pay"
 	tre "github.com/terra-project/core/x/treasury"
 
 	dt "github.com/cosmos/cosmos-sdk/x/distribution"
@@ -50,12 +53,15 @@ import (
 	auth "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authcustomcmd "github.com/terra-project/core/x/auth/client/cli"
 	paycmd "github.com/terra-project/core/x/pay/client/cli"
 
 	budgetClient "github.com/terra-project/core/x/budget/client"
 	distClient "github.com/terra-project/core/x/distribution/client"
 	marketClient "github.com/terra-project/core/x/market/client"
+	mintClient "github.com/terra-project/core/x/mint/client"
 	oracleClient "github.com/terra-project/core/x/oracle/client"
+	payClient "github.com/terra-project/core/x/pay/client"
 	slashingClient "github.com/terra-project/core/x/slashing/client"
 	stakingClient "github.com/terra-project/core/x/staking/client"
 	treasuryClient "github.com/terra-project/core/x/treasury/client"
@@ -95,6 +101,8 @@ func main() {
 		treasuryClient.NewModuleClient(tre.StoreKey, cdc),
 		budgetClient.NewModuleClient(bud.StoreKey, cdc),
 		marketClient.NewModuleClient(mkt.StoreKey, cdc),
+		mintClient.NewModuleClient(mnt.StoreKey, cdc),
+		payClient.NewModuleClient(py.StoreKey, cdc),
 		crisisClient.NewModuleClient(sl.StoreKey, cdc),
 	}
 
@@ -141,13 +149,16 @@ func queryCmd(cdc *amino.Codec, mc []sdk.ModuleClients) *cobra.Command {
 		Short:   "Querying subcommands",
 	}
 
+	accountCmd := authcmd.GetAccountCmd(at.StoreKey, cdc)
+	accountCmd.AddCommand(client.GetCommands(authcustomcmd.GetCmdQueryVesting(cdc))...)
+
 	queryCmd.AddCommand(
 		rpc.ValidatorCommand(cdc),
 		rpc.BlockCommand(),
 		tx.SearchTxCmd(cdc),
 		tx.QueryTxCmd(cdc),
 		client.LineBreak,
-		authcmd.GetAccountCmd(at.StoreKey, cdc),
+		accountCmd,
 	)
 
 	for _, m := range mc {
@@ -210,6 +221,7 @@ func registerRoutes(rs *lcd.RestServer) {
 	treasury.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 	market.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 	budget.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
+	mint.RegisterRoutes(rs.CliCtx, rs.Mux, rs.Cdc)
 }
 
 func registerSwaggerUI(rs *lcd.RestServer) {
@@ -0,0 +1,53 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Account is an account of the simulation, with the keys to sign its txs
+type Account struct {
+	PrivKey crypto.PrivKey
+	PubKey  crypto.PubKey
+	Address sdk.AccAddress
+}
+
+// Equals returns true if both accounts have the same address
+func (acc Account) Equals(acc2 Account) bool {
+	return acc.Address.Equals(acc2.Address)
+}
+
+// RandomAccounts generates {n} accounts with keys drawn from {r}
+func RandomAccounts(r *rand.Rand, n int) []Account {
+	accs := make([]Account, n)
+	for i := 0; i < n; i++ {
+		secret := make([]byte, 32)
+		r.Read(secret)
+
+		accs[i].PrivKey = secp256k1.GenPrivKeySecp256k1(secret)
+		accs[i].PubKey = accs[i].PrivKey.PubKey()
+		accs[i].Address = sdk.AccAddress(accs[i].PubKey.Address())
+	}
+
+	return accs
+}
+
+// RandomAcc picks a random account of {accs}
+func RandomAcc(r *rand.Rand, accs []Account) Account {
+	return accs[r.Intn(len(accs))]
+}
+
+// FindAccount returns the account of {accs} with the address {addr}
+func FindAccount(accs []Account, addr sdk.Address) (Account, bool) {
+	for _, acc := range accs {
+		if acc.Address.Equals(addr) {
+			return acc, true
+		}
+	}
+
+	return Account{}, false
+}
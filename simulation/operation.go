@@ -0,0 +1,78 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Operation runs a random operation on the app, typically by delivering a random msg, and reports
+// what it did. An error is returned only if the app broke, not if the msg was rightfully rejected.
+type Operation func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+	accs []Account, chainID string) (opMsg OperationMsg, err error)
+
+// WeightedOperation is an operation picked with a probability proportional to its weight
+type WeightedOperation struct {
+	Weight int
+	Op     Operation
+}
+
+// OperationMsg reports the msg an operation delivered
+type OperationMsg struct {
+	Route   string `json:"route"`
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+	OK      bool   `json:"ok"`
+}
+
+// NewOperationMsg reports {msg} delivered with the result {res}
+func NewOperationMsg(msg sdk.Msg, res sdk.Result) OperationMsg {
+	return OperationMsg{
+		Route:   msg.Route(),
+		Name:    msg.Type(),
+		Comment: res.Log,
+		OK:      res.IsOK(),
+	}
+}
+
+// NoOpMsg reports an operation of the route {route} that had nothing to deliver
+func NoOpMsg(route string) OperationMsg {
+	return OperationMsg{
+		Route: route,
+		Name:  "no-operation",
+	}
+}
+
+// key is the key the operation is counted under in the simulation stats
+func (om OperationMsg) key() string {
+	if om.OK {
+		return fmt.Sprintf("%s/%s/ok", om.Route, om.Name)
+	}
+
+	return fmt.Sprintf("%s/%s/failure", om.Route, om.Name)
+}
+
+// String implements fmt.Stringer
+func (om OperationMsg) String() string {
+	return fmt.Sprintf("%s: %s", om.key(), om.Comment)
+}
+
+// pickOperation picks a random operation of {ops} by weight
+func pickOperation(r *rand.Rand, ops []WeightedOperation) Operation {
+	totalWeight := 0
+	for _, op := range ops {
+		totalWeight += op.Weight
+	}
+
+	pick := r.Intn(totalWeight)
+	for _, op := range ops {
+		if pick < op.Weight {
+			return op.Op
+		}
+		pick -= op.Weight
+	}
+
+	panic("unreachable")
+}
@@ -0,0 +1,48 @@
+package simulation
+
+import (
+	"math/big"
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// RandStringOfLength generates a random string of {n} letters and digits
+func RandStringOfLength(r *rand.Rand, n int) string {
+	bz := make([]byte, n)
+	for i := range bz {
+		bz[i] = letters[r.Intn(len(letters))]
+	}
+
+	return string(bz)
+}
+
+// RandomAmount generates a random amount in [0, max], biased towards the bounds so that the edge
+// cases are hit
+func RandomAmount(r *rand.Rand, max sdk.Int) sdk.Int {
+	if !max.IsPositive() {
+		return sdk.ZeroInt()
+	}
+
+	switch r.Intn(10) {
+	case 0:
+		return sdk.ZeroInt()
+	case 1:
+		return max
+	default:
+		return sdk.NewIntFromBigInt(new(big.Int).Rand(r, max.BigInt()))
+	}
+}
+
+// RandomCoin picks a random coin of {coins} with a random part of its amount. Returns false if
+// {coins} is empty.
+func RandomCoin(r *rand.Rand, coins sdk.Coins) (sdk.Coin, bool) {
+	if coins.Empty() {
+		return sdk.Coin{}, false
+	}
+
+	coin := coins[r.Intn(len(coins))]
+	return sdk.NewCoin(coin.Denom, RandomAmount(r, coin.Amount)), true
+}
@@ -0,0 +1,160 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+// AppStateFn generates a random genesis app state of the chain {chainID} funding {accs}
+type AppStateFn func(r *rand.Rand, accs []Account, genesisTime time.Time, chainID string) json.RawMessage
+
+// chain ID of the simulated chain
+const chainID = "simulation"
+
+// SimulateFromSeed runs {numBlocks} blocks of {blockSize} random operations on {app}, from a random
+// genesis of {appStateFn}, all drawn from {seed}. Validators sign the blocks they are in the set for
+// and propose them at random. Returns the app hash of the last block, so that two runs from the same
+// seed can be compared for non-determinism.
+//
+// The app should assert its invariants at the end of every block; the simulation only catches the
+// panics of the handlers, which baseapp would otherwise hide in failed txs.
+func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp, appStateFn AppStateFn, seed int64,
+	ops []WeightedOperation, numBlocks int, blockSize int, verbose bool) (appHash []byte, err error) {
+
+	r := rand.New(rand.NewSource(seed))
+	accs := RandomAccounts(r, 20+r.Intn(30))
+	genesisTime := time.Unix(1560000000+r.Int63n(100000000), 0).UTC()
+	tb.Logf("Simulating %d blocks of %d operations with %d accounts from seed %d",
+		numBlocks, blockSize, len(accs), seed)
+
+	res := app.InitChain(abci.RequestInitChain{
+		ChainId:       chainID,
+		Time:          genesisTime,
+		AppStateBytes: appStateFn(r, accs, genesisTime, chainID),
+	})
+
+	validators := make(map[string]abci.Validator)
+	if err := updateValidators(validators, res.Validators); err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]int)
+	header := abci.Header{ChainID: chainID, Height: 1, Time: genesisTime}
+	var lastCommitInfo abci.LastCommitInfo
+
+	for height := 1; height <= numBlocks; height++ {
+		vals := sortedValidators(validators)
+		if len(vals) == 0 {
+			return nil, fmt.Errorf("no validator left at height %d", header.Height)
+		}
+		header.ProposerAddress = vals[r.Intn(len(vals))].Address
+
+		app.BeginBlock(abci.RequestBeginBlock{Header: header, LastCommitInfo: lastCommitInfo})
+
+		ctx := app.NewContext(false, header)
+		for i := 0; i < blockSize; i++ {
+			opMsg, err := pickOperation(r, ops)(r, app, ctx, accs, chainID)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d of height %d: %s", i, header.Height, err)
+			}
+
+			stats[opMsg.key()]++
+			if verbose {
+				tb.Log(opMsg.String())
+			}
+		}
+
+		endRes := app.EndBlock(abci.RequestEndBlock{Height: header.Height})
+
+		// the validators of this block sign it, mostly
+		lastCommitInfo = randomCommitInfo(r, vals)
+		if err := updateValidators(validators, endRes.ValidatorUpdates); err != nil {
+			return nil, err
+		}
+
+		appHash = app.Commit().Data
+
+		header = abci.Header{
+			ChainID: chainID,
+			Height:  header.Height + 1,
+			Time:    header.Time.Add(time.Duration(3+r.Intn(7)) * time.Second),
+		}
+	}
+
+	logStats(tb, stats)
+	return appHash, nil
+}
+
+// updateValidators applies {updates} to the validator set {validators}, by address
+func updateValidators(validators map[string]abci.Validator, updates []abci.ValidatorUpdate) error {
+	for _, update := range updates {
+		pubKey, err := tmtypes.PB2TM.PubKey(update.PubKey)
+		if err != nil {
+			return err
+		}
+
+		address := pubKey.Address()
+		if update.Power == 0 {
+			delete(validators, string(address))
+			continue
+		}
+
+		validators[string(address)] = abci.Validator{
+			Address: address,
+			Power:   update.Power,
+		}
+	}
+
+	return nil
+}
+
+// sortedValidators returns the validators of the set, by address, so that the simulation is
+// deterministic
+func sortedValidators(validators map[string]abci.Validator) []abci.Validator {
+	vals := make([]abci.Validator, 0, len(validators))
+	for _, val := range validators {
+		vals = append(vals, val)
+	}
+
+	sort.Slice(vals, func(i, j int) bool {
+		return bytes.Compare(vals[i].Address, vals[j].Address) < 0
+	})
+
+	return vals
+}
+
+// randomCommitInfo has each of {vals} sign the block but for a random one in a hundred
+func randomCommitInfo(r *rand.Rand, vals []abci.Validator) abci.LastCommitInfo {
+	votes := make([]abci.VoteInfo, len(vals))
+	for i, val := range vals {
+		votes[i] = abci.VoteInfo{
+			Validator:       val,
+			SignedLastBlock: r.Intn(100) != 0,
+		}
+	}
+
+	return abci.LastCommitInfo{Votes: votes}
+}
+
+// logStats logs how many times every operation was run, by outcome
+func logStats(tb testing.TB, stats map[string]int) {
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tb.Logf("%s: %d", key, stats[key])
+	}
+}
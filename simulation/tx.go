@@ -0,0 +1,58 @@
+package simulation
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// gas of the simulated txs; never the reason a msg fails
+const maxGas = 100000000
+
+// GenTx generates a tx of {msgs} signed by {privKeys} with no fee
+func GenTx(msgs []sdk.Msg, accNums []uint64, seqs []uint64, chainID string, privKeys ...crypto.PrivKey) auth.StdTx {
+	fee := auth.NewStdFee(maxGas, nil)
+
+	sigs := make([]auth.StdSignature, len(privKeys))
+	for i, priv := range privKeys {
+		sig, err := priv.Sign(auth.StdSignBytes(chainID, accNums[i], seqs[i], fee, msgs, ""))
+		if err != nil {
+			panic(err)
+		}
+
+		sigs[i] = auth.StdSignature{
+			PubKey:    priv.PubKey(),
+			Signature: sig,
+		}
+	}
+
+	return auth.NewStdTx(msgs, fee, sigs, "")
+}
+
+// DeliverMsg signs {msg} by {signer} with its current account number and sequence, and delivers it
+// to {app}. Returns an error only if the app failed internally, e.g. a handler panicked.
+func DeliverMsg(app *baseapp.BaseApp, ctx sdk.Context, ak auth.AccountKeeper, chainID string,
+	msg sdk.Msg, signer Account) (OperationMsg, error) {
+
+	acc := ak.GetAccount(ctx, signer.Address)
+	if acc == nil {
+		return NoOpMsg(msg.Route()), nil
+	}
+
+	tx := GenTx([]sdk.Msg{msg}, []uint64{acc.GetAccountNumber()}, []uint64{acc.GetSequence()},
+		chainID, signer.PrivKey)
+
+	res := app.Deliver(tx)
+	opMsg := NewOperationMsg(msg, res)
+
+	// baseapp recovers the panics of the handlers into internal errors
+	if res.Codespace == sdk.CodespaceRoot && res.Code == sdk.CodeInternal {
+		return opMsg, fmt.Errorf("%s/%s failed internally: %s", msg.Route(), msg.Type(), res.Log)
+	}
+
+	return opMsg, nil
+}
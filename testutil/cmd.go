@@ -65,6 +65,7 @@ func executeCommandC(rootCmd *cobra.Command, args ...string) (output string, err
 	rootCmd.SetArgs(args)
 
 	executor := cli.PrepareMainCmd(rootCmd, "TE", app.DefaultCLIHome)
+	executor.Exit = func(int) {} // return failed commands' errors instead of exiting the test
 	err = executor.Execute()
 
 	return buf.String(), err
@@ -0,0 +1,22 @@
+package assets
+
+// TerraDenoms are the denoms of the Terra stablecoins, i.e. every asset but Luna
+var TerraDenoms = []string{
+	MicroUSDDenom,
+	MicroKRWDenom,
+	MicroSDRDenom,
+	MicroCNYDenom,
+	MicroJPYDenom,
+	MicroEURDenom,
+	MicroGBPDenom,
+}
+
+// IsTerraDenom returns true if {denom} is the denom of a Terra stablecoin
+func IsTerraDenom(denom string) bool {
+	for _, terraDenom := range TerraDenoms {
+		if terraDenom == denom {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+//-----------------------------------------------------------------------------
+// Clawback Vesting Account
+
+// ClawbackVestingAccount defines a lazy graded vesting account whose unvested coins
+// can be clawed back by the funder of the grant.
+type ClawbackVestingAccount interface {
+	LazyGradedVestingAccount
+
+	GetFunder() sdk.AccAddress
+	GetClawbackTime() int64
+	IsClawedBack() bool
+	Clawback(blockTime time.Time) (clawedBack, delegatedVesting sdk.Coins)
+}
+
+// BaseClawbackVestingAccount implements the ClawbackVestingAccount interface. It vests all
+// coins according to a predefined schedule until the funder claws the grant back.
+var _ ClawbackVestingAccount = (*BaseClawbackVestingAccount)(nil)
+
+// BaseClawbackVestingAccount implements the VestingAccount interface. It vests tokens according to
+// a predefined set of vesting schedule, which stops once the grant is clawed back by Funder.
+type BaseClawbackVestingAccount struct {
+	*BaseLazyGradedVestingAccount
+
+	Funder       sdk.AccAddress `json:"funder"`        // address allowed to claw back the unvested coins
+	ClawbackTime int64          `json:"clawback_time"` // time the vesting stopped (UNIX Epoch time); 0 until clawed back
+}
+
+// NewBaseClawbackVestingAccount returns a BaseClawbackVestingAccount
+func NewBaseClawbackVestingAccount(baseAcc *auth.BaseAccount, lazyVestingSchedules []LazyVestingSchedule,
+	funder sdk.AccAddress) *BaseClawbackVestingAccount {
+	return &BaseClawbackVestingAccount{
+		BaseLazyGradedVestingAccount: NewBaseLazyGradedVestingAccount(baseAcc, lazyVestingSchedules),
+		Funder:                       funder,
+	}
+}
+
+// GetFunder returns the address allowed to claw back the unvested coins
+func (cva BaseClawbackVestingAccount) GetFunder() sdk.AccAddress {
+	return cva.Funder
+}
+
+// GetClawbackTime returns the time the vesting stopped, or zero if the grant was not clawed back
+func (cva BaseClawbackVestingAccount) GetClawbackTime() int64 {
+	return cva.ClawbackTime
+}
+
+// IsClawedBack returns true if the grant was clawed back
+func (cva BaseClawbackVestingAccount) IsClawedBack() bool {
+	return cva.ClawbackTime != 0
+}
+
+// GetVestedCoins returns the total amount of vested coins for a clawback vesting account.
+// Coins vest by the lazy schedules until the grant is clawed back.
+func (cva BaseClawbackVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if cva.IsClawedBack() && blockTime.Unix() > cva.ClawbackTime {
+		blockTime = time.Unix(cva.ClawbackTime, 0)
+	}
+
+	return cva.BaseLazyGradedVestingAccount.GetVestedCoins(blockTime)
+}
+
+// GetVestingCoins returns the total number of vesting coins for a clawback vesting account.
+// Nothing is vesting once the grant is clawed back; the unvested coins belong to the funder.
+func (cva BaseClawbackVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	if cva.IsClawedBack() {
+		return nil
+	}
+
+	return cva.OriginalVesting.Sub(cva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the total number of spendable coins for a clawback
+// vesting account.
+func (cva BaseClawbackVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation tracks a desired delegation amount by setting the appropriate
+// values for the amount of delegated vesting, delegated free, and reducing the
+// overall amount of base coins.
+func (cva *BaseClawbackVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amount)
+}
+
+// Clawback stops the vesting at blockTime. The unvested coins held by the account are removed
+// and returned as clawedBack. The unvested coins that are delegated are released from the
+// delegated vesting and returned as delegatedVesting, for the caller to undelegate them on
+// behalf of the funder.
+//
+// CONTRACT: The account's coins, delegated vesting coins and vesting coins must be sorted.
+func (cva *BaseClawbackVestingAccount) Clawback(blockTime time.Time) (clawedBack, delegatedVesting sdk.Coins) {
+	if cva.IsClawedBack() {
+		return nil, nil
+	}
+
+	vestingCoins := cva.GetVestingCoins(blockTime)
+	bc := cva.GetCoins()
+
+	for _, coin := range vestingCoins {
+		// zip/lineup all coins by their denomination to provide O(n) time
+		baseAmt := bc.AmountOf(coin.Denom)
+		delVestingAmt := cva.DelegatedVesting.AmountOf(coin.Denom)
+
+		// compute x and y, where:
+		// X := min(max(V - DV, 0), BC) is held by the account
+		// Y := min(V - X, DV) is delegated
+		x := sdk.MinInt(sdk.MaxInt(coin.Amount.Sub(delVestingAmt), sdk.ZeroInt()), baseAmt)
+		y := sdk.MinInt(coin.Amount.Sub(x), delVestingAmt)
+
+		if !x.IsZero() {
+			clawedBack = clawedBack.Add(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+
+		if !y.IsZero() {
+			delegatedVesting = delegatedVesting.Add(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+	}
+
+	cva.Coins = cva.Coins.Sub(clawedBack)
+	cva.DelegatedVesting = cva.DelegatedVesting.Sub(delegatedVesting)
+	cva.ClawbackTime = blockTime.Unix()
+
+	return
+}
+
+func (cva BaseClawbackVestingAccount) String() string {
+	var pubkey string
+
+	if cva.PubKey != nil {
+		pubkey = sdk.MustBech32ifyAccPub(cva.PubKey)
+	}
+
+	return fmt.Sprintf(`BaseClawbackVestingAccount:
+  Address:          %s
+  Pubkey:           %s
+  Coins:            %s
+  AccountNumber:    %d
+  Sequence:         %d
+  OriginalVesting:  %s
+  DelegatedFree:    %s
+  DelegatedVesting: %s
+  LazyVestingSchedules:        %v
+  Funder:           %s
+  ClawbackTime:     %d`,
+		cva.Address, pubkey, cva.Coins, cva.AccountNumber, cva.Sequence,
+		cva.OriginalVesting, cva.DelegatedFree, cva.DelegatedVesting,
+		cva.LazyVestingSchedules, cva.Funder, cva.ClawbackTime,
+	)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVestedCoinsClawbackVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	_, _, funder := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)}
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+
+	// vests like a lazy graded vesting account until clawed back
+	cva := NewBaseClawbackVestingAccount(&bacc, []LazyVestingSchedule{
+		angelLazySchedule,
+	}, funder)
+	require.Equal(t, funder, cva.GetFunder())
+	require.False(t, cva.IsClawedBack())
+
+	vestedCoins := cva.GetVestedCoins(timeGenesis.AddDate(0, 3, 0))
+	require.Equal(t, scaleCoins(0.2, assets.MicroLunaDenom, origCoins), vestedCoins)
+
+	// vesting stops at the clawback
+	cva.Clawback(timeGenesis.AddDate(0, 3, 0))
+	require.True(t, cva.IsClawedBack())
+	require.Equal(t, timeGenesis.AddDate(0, 3, 0).Unix(), cva.GetClawbackTime())
+
+	vestedCoins = cva.GetVestedCoins(timeGenesis.AddDate(1, 1, 0))
+	require.Equal(t, scaleCoins(0.2, assets.MicroLunaDenom, origCoins), vestedCoins)
+
+	vestingCoins := cva.GetVestingCoins(timeGenesis.AddDate(1, 1, 0))
+	require.True(t, vestingCoins.Empty())
+}
+
+func TestClawbackClawbackVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	_, _, funder := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000), sdk.NewInt64Coin(assets.MicroSDRDenom, 10000)}
+	sdrSchedule := NewLazyVestingSchedule(assets.MicroSDRDenom, angelLazySchedule.LazySchedules)
+
+	// require all unvested coins be clawed back from an undelegated account
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+	cva := NewBaseClawbackVestingAccount(&bacc, []LazyVestingSchedule{
+		angelLazySchedule, sdrSchedule,
+	}, funder)
+
+	clawedBack, delegatedVesting := cva.Clawback(timeGenesis.AddDate(0, 3, 0))
+	require.Equal(t, scaleCoins(0.8, assets.MicroLunaDenom, origCoins).Add(scaleCoins(0.8, assets.MicroSDRDenom, origCoins)), clawedBack)
+	require.True(t, delegatedVesting.Empty())
+	require.Equal(t, origCoins.Sub(clawedBack), cva.GetCoins())
+	require.Equal(t, cva.GetCoins(), cva.SpendableCoins(timeGenesis.AddDate(0, 3, 0)))
+
+	// require a second clawback to be a no-op
+	clawedBack, delegatedVesting = cva.Clawback(timeGenesis.AddDate(1, 1, 0))
+	require.Nil(t, clawedBack)
+	require.Nil(t, delegatedVesting)
+	require.Equal(t, timeGenesis.AddDate(0, 3, 0).Unix(), cva.GetClawbackTime())
+
+	// require delegated unvested coins be released from the delegated vesting
+	bacc.SetCoins(origCoins)
+	cva = NewBaseClawbackVestingAccount(&bacc, []LazyVestingSchedule{
+		angelLazySchedule, sdrSchedule,
+	}, funder)
+	cva.TrackDelegation(timeGenesis, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 6000)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 6000)}, cva.DelegatedVesting)
+
+	// 8000 luna are unvested: 2000 held by the account, 6000 delegated
+	clawedBack, delegatedVesting = cva.Clawback(timeGenesis.AddDate(0, 3, 0))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 2000), sdk.NewInt64Coin(assets.MicroSDRDenom, 8000)}, clawedBack)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 6000)}, delegatedVesting)
+	require.True(t, cva.DelegatedVesting.Empty())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 2000), sdk.NewInt64Coin(assets.MicroSDRDenom, 2000)}, cva.GetCoins())
+	require.Equal(t, cva.GetCoins(), cva.SpendableCoins(timeGenesis.AddDate(0, 3, 0)))
+}
+
+func TestStringClawbackVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	_, _, funder := keyPubAddr()
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)})
+
+	cva := NewBaseClawbackVestingAccount(&bacc, []LazyVestingSchedule{
+		angelLazySchedule,
+	}, funder)
+	require.NotNil(t, cva.String())
+}
@@ -8,4 +8,5 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&VestingSchedule{}, "core/VestingSchedule", nil)
 	cdc.RegisterConcrete(&BaseGradedVestingAccount{}, "core/GradedVestingAccount", nil)
 	cdc.RegisterConcrete(&BaseLazyGradedVestingAccount{}, "core/LazyGradedVestingAccount", nil)
+	cdc.RegisterConcrete(&BaseClawbackVestingAccount{}, "core/ClawbackVestingAccount", nil)
 }
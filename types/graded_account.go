@@ -84,6 +84,19 @@ func (vs VestingSchedule) GetVestedRatio(blockTime int64) sdk.Dec {
 	return sumRatio
 }
 
+// GetNextUnlockTime returns the first cliff after blockTime, or zero if every cliff has passed.
+func (vs VestingSchedule) GetNextUnlockTime(blockTime int64) int64 {
+	next := int64(0)
+	for _, schedule := range vs.Schedules {
+		cliff := schedule.GetCliff()
+
+		if cliff > blockTime && (next == 0 || cliff < next) {
+			next = cliff
+		}
+	}
+	return next
+}
+
 // GetDenom returns the denom of vesting schedule
 func (vs VestingSchedule) GetDenom() string {
 	return vs.Denom
@@ -101,6 +101,33 @@ func (vs LazyVestingSchedule) GetVestedRatio(blockTime int64) sdk.Dec {
 	return sumRatio
 }
 
+// GetNextUnlockTime returns the first time after blockTime at which more tokens vest, or zero if the
+// schedule has ended. Tokens vest every second between the start and end time of a lazy schedule.
+func (vs LazyVestingSchedule) GetNextUnlockTime(blockTime int64) int64 {
+	next := int64(0)
+	for _, lazySchedule := range vs.LazySchedules {
+		startTime := lazySchedule.GetStartTime()
+		endTime := lazySchedule.GetEndTime()
+
+		var unlockTime int64
+		switch {
+		case blockTime < startTime && startTime == endTime:
+			unlockTime = startTime
+		case blockTime < startTime:
+			unlockTime = startTime + 1
+		case blockTime < endTime:
+			unlockTime = blockTime + 1
+		default:
+			continue
+		}
+
+		if next == 0 || unlockTime < next {
+			next = unlockTime
+		}
+	}
+	return next
+}
+
 // GetDenom returns the denom of vesting layz schedule
 func (vs LazyVestingSchedule) GetDenom() string {
 	return vs.Denom
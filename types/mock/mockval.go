@@ -26,6 +26,10 @@ func (mv MockValidator) GetOperator() sdk.ValAddress {
 	return mv.Address
 }
 
+func (mv MockValidator) GetDelegatorShares() sdk.Dec {
+	return sdk.NewDecFromInt(mv.Power)
+}
+
 type MockValset struct {
 	sdk.ValidatorSet
 
@@ -63,3 +67,7 @@ func (mv MockValset) IterateBondedValidatorsByPower(ctx sdk.Context,
 		}
 	}
 }
+
+func (mv MockValset) Delegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) sdk.Delegation {
+	return nil
+}
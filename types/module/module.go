@@ -0,0 +1,238 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+//-----------------------------------------------------------------------------
+// App Module
+
+// AppModuleBasic is the part of a module the app needs without any keeper: its codec and genesis validation
+type AppModuleBasic interface {
+	Name() string // also the key of the module genesis in the app genesis
+	RegisterCodec(cdc *codec.Codec)
+	ValidateGenesis(bz json.RawMessage) error
+}
+
+// AppModule is a module wired into the app. Modules without messages, queries or blockers return
+// empty routes and do nothing in the blockers.
+type AppModule interface {
+	AppModuleBasic
+
+	RegisterInvariants(ck *crisis.Keeper)
+
+	Route() string
+	NewHandler() sdk.Handler
+	QuerierRoute() string
+	NewQuerierHandler() sdk.Querier
+
+	InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate
+	ExportGenesis(ctx sdk.Context) json.RawMessage
+
+	BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) sdk.Tags
+	EndBlock(ctx sdk.Context, req abci.RequestEndBlock) ([]abci.ValidatorUpdate, sdk.Tags)
+}
+
+//-----------------------------------------------------------------------------
+// Basic Manager
+
+// BasicManager is the set of the basics of every module of the app
+type BasicManager []AppModuleBasic
+
+// NewBasicManager creates a new BasicManager object
+func NewBasicManager(modules ...AppModuleBasic) BasicManager {
+	return modules
+}
+
+// RegisterCodec registers the types of every module on {cdc}
+func (bm BasicManager) RegisterCodec(cdc *codec.Codec) {
+	for _, b := range bm {
+		b.RegisterCodec(cdc)
+	}
+}
+
+// ValidateGenesis validates the genesis of every module in {genesis}
+func (bm BasicManager) ValidateGenesis(genesis map[string]json.RawMessage) error {
+	for _, b := range bm {
+		if err := b.ValidateGenesis(genesis[b.Name()]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// Manager
+
+// Manager wires the modules of the app into its routers and runs their genesis and blockers in the
+// explicit orders set on it. Every order defaults to the order the modules were given in.
+type Manager struct {
+	Modules map[string]AppModule
+	names   []string // names of the modules in the order they were given in
+
+	OrderInitGenesis   []string
+	OrderExportGenesis []string
+	OrderBeginBlockers []string
+	OrderEndBlockers   []string
+}
+
+// NewManager creates a new Manager object. Panics if two modules share a name.
+func NewManager(modules ...AppModule) *Manager {
+	moduleMap := make(map[string]AppModule)
+	var names []string
+	for _, module := range modules {
+		if _, exists := moduleMap[module.Name()]; exists {
+			panic(fmt.Sprintf("duplicate module %s", module.Name()))
+		}
+
+		moduleMap[module.Name()] = module
+		names = append(names, module.Name())
+	}
+
+	return &Manager{
+		Modules:            moduleMap,
+		names:              names,
+		OrderInitGenesis:   names,
+		OrderExportGenesis: names,
+		OrderBeginBlockers: names,
+		OrderEndBlockers:   names,
+	}
+}
+
+// checkOrder panics if {names} is not an order of distinct modules of the manager
+func (m *Manager) checkOrder(names []string) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if _, exists := m.Modules[name]; !exists {
+			panic(fmt.Sprintf("unknown module %s", name))
+		}
+
+		if seen[name] {
+			panic(fmt.Sprintf("module %s ordered twice", name))
+		}
+		seen[name] = true
+	}
+}
+
+// SetOrderInitGenesis sets the order the modules init their genesis in. Modules left out of the
+// order are not initialized.
+func (m *Manager) SetOrderInitGenesis(names ...string) {
+	m.checkOrder(names)
+	m.OrderInitGenesis = names
+}
+
+// SetOrderExportGenesis sets the order the modules export their genesis in. Modules left out of the
+// order are not exported.
+func (m *Manager) SetOrderExportGenesis(names ...string) {
+	m.checkOrder(names)
+	m.OrderExportGenesis = names
+}
+
+// SetOrderBeginBlockers sets the order the begin blockers of the modules run in. Modules left out
+// of the order have no begin blocker run.
+func (m *Manager) SetOrderBeginBlockers(names ...string) {
+	m.checkOrder(names)
+	m.OrderBeginBlockers = names
+}
+
+// SetOrderEndBlockers sets the order the end blockers of the modules run in. Modules left out of
+// the order have no end blocker run.
+func (m *Manager) SetOrderEndBlockers(names ...string) {
+	m.checkOrder(names)
+	m.OrderEndBlockers = names
+}
+
+// RegisterInvariants registers the invariants of every module on the crisis keeper
+func (m *Manager) RegisterInvariants(ck *crisis.Keeper) {
+	for _, name := range m.names {
+		m.Modules[name].RegisterInvariants(ck)
+	}
+}
+
+// RegisterRoutes registers the message and query routes of every module
+func (m *Manager) RegisterRoutes(router bam.Router, queryRouter bam.QueryRouter) {
+	for _, name := range m.names {
+		module := m.Modules[name]
+		if module.Route() != "" {
+			router.AddRoute(module.Route(), module.NewHandler())
+		}
+
+		if module.QuerierRoute() != "" {
+			queryRouter.AddRoute(module.QuerierRoute(), module.NewQuerierHandler())
+		}
+	}
+}
+
+// InitGenesis inits the genesis of every module from {genesis}, by module name, and returns the
+// validator updates. Panics if more than one module updates the validators.
+func (m *Manager) InitGenesis(ctx sdk.Context, genesis map[string]json.RawMessage) (validatorUpdates []abci.ValidatorUpdate) {
+	for _, name := range m.OrderInitGenesis {
+		updates := m.Modules[name].InitGenesis(ctx, genesis[name])
+		if len(updates) == 0 {
+			continue
+		}
+
+		if len(validatorUpdates) > 0 {
+			panic(fmt.Sprintf("validator updates from more than one module, including %s", name))
+		}
+		validatorUpdates = updates
+	}
+
+	return
+}
+
+// ExportGenesis exports the genesis of every module, by module name
+func (m *Manager) ExportGenesis(ctx sdk.Context) map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for _, name := range m.OrderExportGenesis {
+		genesis[name] = m.Modules[name].ExportGenesis(ctx)
+	}
+
+	return genesis
+}
+
+// BeginBlock runs the begin blockers of the modules in order
+func (m *Manager) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	tags := sdk.EmptyTags()
+	for _, name := range m.OrderBeginBlockers {
+		tags = tags.AppendTags(m.Modules[name].BeginBlock(ctx, req))
+	}
+
+	return abci.ResponseBeginBlock{
+		Tags: tags.ToKVPairs(),
+	}
+}
+
+// EndBlock runs the end blockers of the modules in order. Panics if more than one module updates
+// the validators.
+func (m *Manager) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	var validatorUpdates []abci.ValidatorUpdate
+	tags := sdk.EmptyTags()
+	for _, name := range m.OrderEndBlockers {
+		updates, moduleTags := m.Modules[name].EndBlock(ctx, req)
+		tags = tags.AppendTags(moduleTags)
+
+		if len(updates) == 0 {
+			continue
+		}
+
+		if len(validatorUpdates) > 0 {
+			panic(fmt.Sprintf("validator updates from more than one module, including %s", name))
+		}
+		validatorUpdates = updates
+	}
+
+	return abci.ResponseEndBlock{
+		ValidatorUpdates: validatorUpdates,
+		Tags:             tags,
+	}
+}
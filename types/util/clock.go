@@ -0,0 +1,116 @@
+package util
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	SecondsPerBlock = int64(6) // block time the block counts above assume
+	SecondsPerDay   = int64(24 * 60 * 60)
+
+	DaysPerEpoch = BlocksPerEpoch / BlocksPerDay
+)
+
+// Clock anchors days, and the epochs made of them, to block time instead of block counts. The first
+// block whose time reaches the end of the day is the last block of that day, so that the modules
+// ending a day or an epoch do so at the end of that block, as they do with block counts.
+type Clock struct {
+	Day    int64 `json:"day"`     // current day, starting from 0
+	DayEnd int64 `json:"day_end"` // time the current day ends at (UNIX Epoch time); 0 until the first block of a restarted chain
+}
+
+// NewClock starts counting days by block time at the block of {ctx}. The day counted by blocks so
+// far goes on, and ends when the blocks left in it would have at SecondsPerBlock.
+func NewClock(ctx sdk.Context) Clock {
+	blocksLeft := BlocksPerDay - ctx.BlockHeight()%BlocksPerDay
+	return Clock{
+		Day:    ctx.BlockHeight() / BlocksPerDay,
+		DayEnd: ctx.BlockHeader().Time.Unix() + blocksLeft*SecondsPerBlock,
+	}
+}
+
+// GetDay returns the current day, starting from 0
+func (c Clock) GetDay() sdk.Int {
+	return sdk.NewInt(c.Day)
+}
+
+// GetEpoch returns the current epoch, starting from 0
+func (c Clock) GetEpoch() sdk.Int {
+	return sdk.NewInt(c.Day / DaysPerEpoch)
+}
+
+// IsDayLastBlock returns true if the block of {ctx} ends the current day
+func (c Clock) IsDayLastBlock(ctx sdk.Context) bool {
+	return ctx.BlockHeader().Time.Unix() >= c.DayEnd
+}
+
+// PeriodDays returns the days a period of {blocks} blocks lasts once counted by block time; whole
+// days, and at least one
+func PeriodDays(blocks int64) int64 {
+	if days := blocks / BlocksPerDay; days > 1 {
+		return days
+	}
+	return 1
+}
+
+// IsPeriodLastBlock returns true if the block of {ctx} ends a period of {days} days, counted from day 0
+func (c Clock) IsPeriodLastBlock(ctx sdk.Context, days int64) bool {
+	return c.IsDayLastBlock(ctx) && (c.Day+1)%days == 0
+}
+
+// IsEpochLastBlock returns true if the block of {ctx} ends the current epoch
+func (c Clock) IsEpochLastBlock(ctx sdk.Context) bool {
+	return c.IsPeriodLastBlock(ctx, DaysPerEpoch)
+}
+
+// PeriodEnd returns the time the current period of {days} days ends at (UNIX Epoch time)
+func (c Clock) PeriodEnd(days int64) int64 {
+	return c.DayEnd + (days-1-c.Day%days)*SecondsPerDay
+}
+
+// EpochEnd returns the time the current epoch ends at (UNIX Epoch time)
+func (c Clock) EpochEnd() int64 {
+	return c.PeriodEnd(DaysPerEpoch)
+}
+
+// EstimatePeriodLastBlock returns the height of the block expected to end the current period of
+// {days} days, after the block of {ctx}, if blocks come every SecondsPerBlock from then on
+func (c Clock) EstimatePeriodLastBlock(ctx sdk.Context, days int64) int64 {
+	blocksLeft := (c.PeriodEnd(days) - ctx.BlockHeader().Time.Unix() + SecondsPerBlock - 1) / SecondsPerBlock
+	if blocksLeft < 1 {
+		blocksLeft = 1
+	}
+
+	return ctx.BlockHeight() + blocksLeft
+}
+
+// EstimateEpochLastBlock returns the height of the block expected to end the current epoch, after
+// the block of {ctx}, if blocks come every SecondsPerBlock from then on
+func (c Clock) EstimateEpochLastBlock(ctx sdk.Context) int64 {
+	return c.EstimatePeriodLastBlock(ctx, DaysPerEpoch)
+}
+
+// Tick returns the clock after the block of {ctx}. A day that ends moves the clock to the next one,
+// whose end stays anchored to whole days from the first; days the chain was halted for are not counted.
+func (c Clock) Tick(ctx sdk.Context) Clock {
+	if !c.IsDayLastBlock(ctx) {
+		return c
+	}
+
+	c.Day++
+	for c.DayEnd <= ctx.BlockHeader().Time.Unix() {
+		c.DayEnd += SecondsPerDay
+	}
+
+	return c
+}
+
+// String implements fmt.Stringer
+func (c Clock) String() string {
+	return fmt.Sprintf(`Clock:
+  Day:    %d
+  DayEnd: %d`, c.Day, c.DayEnd)
+}
@@ -0,0 +1,158 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+//-----------------------------------------------------------------------------
+// Vesting Status
+
+// DenomVestingStatus is the vesting state of one denom of an account at a given time
+type DenomVestingStatus struct {
+	Denom            string  `json:"denom"`
+	OriginalVesting  sdk.Int `json:"original_vesting"`
+	Vested           sdk.Int `json:"vested"`
+	Vesting          sdk.Int `json:"vesting"`
+	Spendable        sdk.Int `json:"spendable"`
+	DelegatedFree    sdk.Int `json:"delegated_free"`
+	DelegatedVesting sdk.Int `json:"delegated_vesting"`
+	NextUnlockTime   int64   `json:"next_unlock_time"` // next time more coins vest (UNIX Epoch time); 0 if none
+}
+
+// String implements the fmt.Stringer interface
+func (dvs DenomVestingStatus) String() string {
+	return fmt.Sprintf(`%s:
+    OriginalVesting:  %s
+    Vested:           %s
+    Vesting:          %s
+    Spendable:        %s
+    DelegatedFree:    %s
+    DelegatedVesting: %s
+    NextUnlockTime:   %d`,
+		dvs.Denom, dvs.OriginalVesting, dvs.Vested, dvs.Vesting, dvs.Spendable,
+		dvs.DelegatedFree, dvs.DelegatedVesting, dvs.NextUnlockTime)
+}
+
+// VestingStatus is the vesting state of every denom held, delegated or vesting in an account at Time
+type VestingStatus struct {
+	Address sdk.AccAddress       `json:"address"`
+	Time    int64                `json:"time"` // UNIX Epoch time of the status
+	Denoms  []DenomVestingStatus `json:"denoms"`
+}
+
+// String implements the fmt.Stringer interface
+func (vs VestingStatus) String() string {
+	var denoms []string
+	for _, dvs := range vs.Denoms {
+		denoms = append(denoms, "  "+dvs.String())
+	}
+
+	return fmt.Sprintf(`VestingStatus:
+  Address: %s
+  Time:    %d
+%s`, vs.Address, vs.Time, strings.Join(denoms, "\n"))
+}
+
+// NewVestingStatus computes the vesting status of {acc} at {blockTime}. Accounts that do not vest report
+// all of their coins as vested.
+func NewVestingStatus(acc auth.Account, blockTime time.Time) VestingStatus {
+	var originalVesting, vested, vesting, delegatedFree, delegatedVesting sdk.Coins
+
+	vacc, isVesting := acc.(auth.VestingAccount)
+	if isVesting {
+		originalVesting = vacc.GetOriginalVesting()
+		vested = vacc.GetVestedCoins(blockTime)
+		vesting = vacc.GetVestingCoins(blockTime)
+		delegatedFree = vacc.GetDelegatedFree()
+		delegatedVesting = vacc.GetDelegatedVesting()
+	} else {
+		vested = acc.GetCoins()
+	}
+
+	coins := acc.GetCoins()
+	spendable := acc.SpendableCoins(blockTime)
+
+	denomSet := make(map[string]bool)
+	for _, set := range []sdk.Coins{coins, originalVesting, delegatedFree, delegatedVesting} {
+		for _, coin := range set {
+			denomSet[coin.Denom] = true
+		}
+	}
+
+	var denoms []string
+	for denom := range denomSet {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	status := VestingStatus{
+		Address: acc.GetAddress(),
+		Time:    blockTime.Unix(),
+		Denoms:  []DenomVestingStatus{},
+	}
+
+	for _, denom := range denoms {
+		dvs := DenomVestingStatus{
+			Denom:            denom,
+			OriginalVesting:  originalVesting.AmountOf(denom),
+			Vested:           vested.AmountOf(denom),
+			Vesting:          vesting.AmountOf(denom),
+			Spendable:        spendable.AmountOf(denom),
+			DelegatedFree:    delegatedFree.AmountOf(denom),
+			DelegatedVesting: delegatedVesting.AmountOf(denom),
+		}
+
+		if isVesting && dvs.Vesting.IsPositive() {
+			dvs.NextUnlockTime = nextUnlockTime(vacc, denom, blockTime.Unix())
+		}
+
+		status.Denoms = append(status.Denoms, dvs)
+	}
+
+	return status
+}
+
+// nextUnlockTime returns the next time more coins of {denom} vest in {vacc}, or zero if none will
+func nextUnlockTime(vacc auth.VestingAccount, denom string, blockTime int64) int64 {
+	switch vacc := vacc.(type) {
+	case ClawbackVestingAccount:
+		if vacc.IsClawedBack() {
+			return 0
+		}
+
+		if vs, found := vacc.GetLazyVestingSchedule(denom); found {
+			return vs.GetNextUnlockTime(blockTime)
+		}
+
+	case LazyGradedVestingAccount:
+		if vs, found := vacc.GetLazyVestingSchedule(denom); found {
+			return vs.GetNextUnlockTime(blockTime)
+		}
+
+	case GradedVestingAccount:
+		if vs, found := vacc.GetVestingSchedule(denom); found {
+			return vs.GetNextUnlockTime(blockTime)
+		}
+
+	case *auth.ContinuousVestingAccount:
+		// coins vest every second between the start and end time
+		if blockTime < vacc.GetStartTime() {
+			return vacc.GetStartTime() + 1
+		} else if blockTime < vacc.GetEndTime() {
+			return blockTime + 1
+		}
+
+	case *auth.DelayedVestingAccount:
+		if blockTime < vacc.GetEndTime() {
+			return vacc.GetEndTime()
+		}
+	}
+
+	return 0
+}
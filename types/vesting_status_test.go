@@ -0,0 +1,142 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingStatusBaseAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)}
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+
+	// all coins of a regular account are vested and spendable
+	status := NewVestingStatus(&bacc, timeGenesis)
+	require.Equal(t, addr, status.Address)
+	require.Equal(t, timeGenesis.Unix(), status.Time)
+	require.Equal(t, 1, len(status.Denoms))
+
+	dvs := status.Denoms[0]
+	require.Equal(t, assets.MicroLunaDenom, dvs.Denom)
+	require.True(t, dvs.OriginalVesting.IsZero())
+	require.True(t, dvs.Vested.Equal(sdk.NewInt(10000)))
+	require.True(t, dvs.Vesting.IsZero())
+	require.True(t, dvs.Spendable.Equal(sdk.NewInt(10000)))
+	require.Equal(t, int64(0), dvs.NextUnlockTime)
+}
+
+func TestVestingStatusGradVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)}
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+
+	gva := NewBaseGradedVestingAccount(&bacc, []VestingSchedule{
+		angelSchedule,
+	})
+
+	// the first cliff unlocks 10% after a month
+	status := NewVestingStatus(gva, timeGenesis)
+	require.Equal(t, 1, len(status.Denoms))
+
+	dvs := status.Denoms[0]
+	require.True(t, dvs.OriginalVesting.Equal(sdk.NewInt(10000)))
+	require.True(t, dvs.Vested.IsZero())
+	require.True(t, dvs.Vesting.Equal(sdk.NewInt(10000)))
+	require.True(t, dvs.Spendable.IsZero())
+	require.Equal(t, monthlyTimes[1], dvs.NextUnlockTime)
+
+	// nothing unlocks after the last cliff
+	dvs = NewVestingStatus(gva, timeGenesis.AddDate(2, 0, 0)).Denoms[0]
+	require.True(t, dvs.Vested.Equal(sdk.NewInt(10000)))
+	require.True(t, dvs.Vesting.IsZero())
+	require.Equal(t, int64(0), dvs.NextUnlockTime)
+}
+
+func TestVestingStatusLazyGradVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000), sdk.NewInt64Coin(assets.MicroSDRDenom, 10000)}
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+
+	lgva := NewBaseLazyGradedVestingAccount(&bacc, []LazyVestingSchedule{
+		angelLazySchedule,
+	})
+	lgva.TrackDelegation(timeGenesis, sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 4000)})
+
+	// half way through the second month, 15% is vested and more unlocks every second
+	blockTime := timeGenesis.AddDate(0, 2, 15)
+	status := NewVestingStatus(lgva, blockTime)
+	require.Equal(t, 2, len(status.Denoms))
+
+	luna := status.Denoms[0]
+	require.Equal(t, assets.MicroLunaDenom, luna.Denom)
+	require.True(t, luna.OriginalVesting.Equal(sdk.NewInt(10000)))
+	require.True(t, luna.Vested.Equal(sdk.NewInt(1500)))
+	require.True(t, luna.Vesting.Equal(sdk.NewInt(8500)))
+	require.True(t, luna.Spendable.Equal(sdk.NewInt(1500)))
+	require.True(t, luna.DelegatedFree.IsZero())
+	require.True(t, luna.DelegatedVesting.Equal(sdk.NewInt(4000)))
+	require.Equal(t, blockTime.Unix()+1, luna.NextUnlockTime)
+
+	// coins without a schedule are vested right away
+	sdr := status.Denoms[1]
+	require.Equal(t, assets.MicroSDRDenom, sdr.Denom)
+	require.True(t, sdr.Vested.Equal(sdk.NewInt(10000)))
+	require.True(t, sdr.Vesting.IsZero())
+	require.True(t, sdr.Spendable.Equal(sdk.NewInt(10000)))
+	require.Equal(t, int64(0), sdr.NextUnlockTime)
+
+	// between tranches, the next one starts unlocking a second after its start time
+	luna = NewVestingStatus(lgva, timeGenesis.AddDate(0, 6, 0)).Denoms[0]
+	require.True(t, luna.Vested.Equal(sdk.NewInt(3000)))
+	require.Equal(t, monthlyTimes[12]+1, luna.NextUnlockTime)
+}
+
+func TestVestingStatusClawbackVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	_, _, funder := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)}
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+
+	cva := NewBaseClawbackVestingAccount(&bacc, []LazyVestingSchedule{
+		angelLazySchedule,
+	}, funder)
+
+	dvs := NewVestingStatus(cva, timeGenesis).Denoms[0]
+	require.True(t, dvs.Vesting.Equal(sdk.NewInt(10000)))
+	require.Equal(t, monthlyTimes[1]+1, dvs.NextUnlockTime)
+
+	// nothing unlocks once clawed back
+	cva.Clawback(timeGenesis.AddDate(0, 3, 0))
+	dvs = NewVestingStatus(cva, timeGenesis.AddDate(0, 6, 0)).Denoms[0]
+	require.True(t, dvs.Vested.Equal(sdk.NewInt(2000)))
+	require.True(t, dvs.Vesting.IsZero())
+	require.True(t, dvs.Spendable.Equal(sdk.NewInt(2000)))
+	require.Equal(t, int64(0), dvs.NextUnlockTime)
+}
+
+func TestVestingStatusDelayedVestingAcc(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)}
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+
+	endTime := timeGenesis.AddDate(1, 0, 0).Unix()
+	dva := auth.NewDelayedVestingAccount(&bacc, endTime)
+
+	dvs := NewVestingStatus(dva, timeGenesis).Denoms[0]
+	require.True(t, dvs.Vesting.Equal(sdk.NewInt(10000)))
+	require.Equal(t, endTime, dvs.NextUnlockTime)
+
+	dvs = NewVestingStatus(dva, timeGenesis.AddDate(1, 0, 0)).Denoms[0]
+	require.True(t, dvs.Vested.Equal(sdk.NewInt(10000)))
+	require.Equal(t, int64(0), dvs.NextUnlockTime)
+}
@@ -0,0 +1,32 @@
+package update
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker applies the scheduled upgrade once its height is reached. A binary that does not
+// register a plan under the name of the upgrade halts the node, so that it can be replaced by the
+// binary that does; the block is then replayed, and the upgrade applied, by the new binary.
+func BeginBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
+	up, found := k.GetUpgradePlan(ctx)
+	if !found || ctx.BlockHeight() < up.Height {
+		return
+	}
+
+	p, registered := k.GetRegistry().GetPlan(up.Name)
+	if !registered {
+		msg := fmt.Sprintf("UPGRADE \"%s\" NEEDED at height %d: %s", up.Name, up.Height, up.Info)
+		ctx.Logger().Error(msg)
+		panic(msg)
+	}
+
+	if !k.IsApplied(ctx, p.Name) {
+		k.ApplyPlan(ctx, p)
+	}
+	k.ClearUpgradePlan(ctx)
+
+	ctx.Logger().Info("Applied software upgrade", "plan", up.Name, "height", ctx.BlockHeight())
+	return sdk.NewTags(up.Name, "updated")
+}
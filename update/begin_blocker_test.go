@@ -0,0 +1,213 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/terra-project/core/update/plan"
+)
+
+func TestScheduleUpgrade(t *testing.T) {
+	input := createTestInput(t)
+	ctx := input.ctx.WithBlockHeight(10)
+
+	_, found := input.updateKeeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// Invalid plans
+	require.NotNil(t, input.updateKeeper.ScheduleUpgrade(ctx, NewUpgradePlan("", 20, "")))
+	require.NotNil(t, input.updateKeeper.ScheduleUpgrade(ctx, NewUpgradePlan("past", 10, "")))
+
+	input.updateKeeper.setAppliedHeight(ctx, "applied", 5)
+	require.NotNil(t, input.updateKeeper.ScheduleUpgrade(ctx, NewUpgradePlan("applied", 20, "")))
+
+	// A new schedule replaces the previous one
+	require.Nil(t, input.updateKeeper.ScheduleUpgrade(ctx, NewUpgradePlan("first", 20, "")))
+	require.Nil(t, input.updateKeeper.ScheduleUpgrade(ctx, NewUpgradePlan("second", 30, "v0.3.0")))
+
+	up, found := input.updateKeeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, NewUpgradePlan("second", 30, "v0.3.0"), up)
+
+	input.updateKeeper.ClearUpgradePlan(ctx)
+	_, found = input.updateKeeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestBeginBlockerUpgrade(t *testing.T) {
+	applied := 0
+	upgradePlan := plan.NewPlan("v0.3.0", 0, func(ctx sdk.Context, keepers plan.Keepers) {
+		applied++
+	})
+
+	// Both binaries share the chain state; only the new one knows the upgrade
+	input := createTestInput(t)
+	oldBinary := input.updateKeeper
+	newBinary := input.updateKeeper
+	newBinary.registry = plan.NewRegistry(upgradePlan)
+
+	require.Nil(t, oldBinary.ScheduleUpgrade(input.ctx.WithBlockHeight(1), NewUpgradePlan("v0.3.0", 10, "https://github.com/terra-project/core/releases")))
+
+	// Both binaries run the blocks before the upgrade height
+	for _, k := range []Keeper{oldBinary, newBinary} {
+		tags := BeginBlocker(input.ctx.WithBlockHeight(9), k)
+		require.Equal(t, 0, len(tags))
+		require.Equal(t, 0, applied)
+
+		// the plan has no height, so it is never applied by the end blocker
+		EndBlocker(input.ctx.WithBlockHeight(9), k)
+		require.Equal(t, 0, applied)
+	}
+
+	// The old binary halts at the upgrade height
+	ctx := input.ctx.WithBlockHeight(10)
+	require.Panics(t, func() { BeginBlocker(ctx, oldBinary) })
+	require.Equal(t, 0, applied)
+
+	_, found := oldBinary.GetUpgradePlan(ctx)
+	require.True(t, found)
+
+	// The new binary replays the block, applies the upgrade and continues
+	tags := BeginBlocker(ctx, newBinary)
+	require.Equal(t, sdk.NewTags("v0.3.0", "updated"), tags)
+	require.Equal(t, 1, applied)
+	require.True(t, newBinary.IsApplied(ctx, "v0.3.0"))
+
+	_, found = newBinary.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	tags = BeginBlocker(input.ctx.WithBlockHeight(11), newBinary)
+	require.Equal(t, 0, len(tags))
+	require.Equal(t, 1, applied)
+
+	// The upgrade cannot be scheduled again
+	require.NotNil(t, newBinary.ScheduleUpgrade(input.ctx.WithBlockHeight(11), NewUpgradePlan("v0.3.0", 20, "")))
+}
+
+func TestGenesis(t *testing.T) {
+	input := createTestInput(t)
+
+	genesis := NewGenesisState(
+		NewUpgradePlan("v0.3.0", 100, "info"),
+		[]AppliedPlan{{Name: "v0.2.0", AppliedHeight: 50}},
+		[]UpgradeVote{NewUpgradeVote(valAddrs[0], NewUpgradePlan("v0.4.0", 200, ""))},
+	)
+	require.Nil(t, ValidateGenesis(genesis))
+
+	InitGenesis(input.ctx, input.updateKeeper, genesis)
+	require.Equal(t, genesis, ExportGenesis(input.ctx, input.updateKeeper))
+
+	emptyInput := createTestInput(t)
+	require.Equal(t, DefaultGenesisState(), ExportGenesis(emptyInput.ctx, emptyInput.updateKeeper))
+
+	// Invalid genesis
+	require.NotNil(t, ValidateGenesis(NewGenesisState(NewUpgradePlan("v0.3.0", 0, ""), nil, nil)))
+	require.NotNil(t, ValidateGenesis(NewGenesisState(NewUpgradePlan("v0.2.0", 100, ""), genesis.AppliedPlans, nil)))
+	require.NotNil(t, ValidateGenesis(NewGenesisState(UpgradePlan{}, []AppliedPlan{{Name: "v0.2.0"}, {Name: "v0.2.0"}}, nil)))
+	require.NotNil(t, ValidateGenesis(NewGenesisState(UpgradePlan{}, nil, []UpgradeVote{NewUpgradeVote(valAddrs[0], UpgradePlan{})})))
+	require.NotNil(t, ValidateGenesis(NewGenesisState(UpgradePlan{}, nil, append(genesis.UpgradeVotes, genesis.UpgradeVotes...))))
+}
+
+func TestVoteUpgrade(t *testing.T) {
+	applied := 0
+	upgradePlan := plan.NewPlan("v0.3.0", 0, func(ctx sdk.Context, keepers plan.Keepers) {
+		applied++
+	})
+
+	input := createTestInput(t)
+	oldBinary := input.updateKeeper
+	newBinary := input.updateKeeper
+	newBinary.registry = plan.NewRegistry(upgradePlan)
+
+	handler := NewHandler(oldBinary)
+	querier := NewQuerier(oldBinary)
+	ctx := input.ctx.WithBlockHeight(1)
+	up := NewUpgradePlan("v0.3.0", 10, "https://github.com/terra-project/core/releases")
+
+	// Only validators vote, for plans that can be scheduled
+	res := handler(ctx, NewMsgVoteUpgrade(up, sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())))
+	require.Equal(t, CodeValidatorNotFound, res.Code)
+
+	res = handler(ctx, NewMsgVoteUpgrade(NewUpgradePlan("v0.3.0", 1, ""), valAddrs[0]))
+	require.Equal(t, CodeInvalidUpgradePlan, res.Code)
+
+	// Votes for different plans don't add up
+	res = handler(ctx, NewMsgVoteUpgrade(up, valAddrs[0]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	res = handler(ctx, NewMsgVoteUpgrade(NewUpgradePlan("v0.3.0", 12, ""), valAddrs[2]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	bz, err := querier(ctx, []string{QueryVotes}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	var votes []UpgradeVote
+	require.Nil(t, input.cdc.UnmarshalJSON(bz, &votes))
+	require.Equal(t, 2, len(votes))
+
+	tags := EndBlocker(ctx, oldBinary)
+	require.Equal(t, 0, len(tags))
+	_, found := oldBinary.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// Two thirds of the bonded stake is not enough
+	res = handler(ctx, NewMsgVoteUpgrade(up, valAddrs[2]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	tags = EndBlocker(ctx, oldBinary)
+	require.Equal(t, 0, len(tags))
+	_, found = oldBinary.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// A withdrawn vote does not count
+	res = handler(ctx, NewMsgVoteUpgrade(up, valAddrs[1]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	res = handler(ctx, NewMsgVoteUpgrade(UpgradePlan{}, valAddrs[1]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	tags = EndBlocker(ctx, oldBinary)
+	require.Equal(t, 0, len(tags))
+
+	// The plan is scheduled once more than two thirds of the bonded stake voted for it
+	res = handler(ctx, NewMsgVoteUpgrade(up, valAddrs[1]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	tags = EndBlocker(ctx, oldBinary)
+	require.Equal(t, 3, len(tags))
+
+	scheduled, found := oldBinary.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, up, scheduled)
+	require.Equal(t, 0, len(oldBinary.GetUpgradeVotes(ctx)))
+
+	// The old binary halts at the upgrade height, and the new binary applies the upgrade
+	require.Equal(t, 0, len(BeginBlocker(input.ctx.WithBlockHeight(9), oldBinary)))
+
+	ctx = input.ctx.WithBlockHeight(10)
+	require.Panics(t, func() { BeginBlocker(ctx, oldBinary) })
+	require.Equal(t, 0, applied)
+
+	require.Equal(t, sdk.NewTags("v0.3.0", "updated"), BeginBlocker(ctx, newBinary))
+	require.Equal(t, 1, applied)
+	require.True(t, newBinary.IsApplied(ctx, "v0.3.0"))
+
+	// Votes of validators that left the validator set are dropped
+	ctx = input.ctx.WithBlockHeight(11)
+	outsider := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	input.valset.setPower(outsider, 10)
+	res = NewHandler(newBinary)(ctx, NewMsgVoteUpgrade(NewUpgradePlan("v0.4.0", 20, ""), outsider))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	require.Equal(t, 1, len(newBinary.GetUpgradeVotes(ctx)))
+
+	delete(input.valset.validators, outsider.String())
+	require.Equal(t, 0, len(EndBlocker(ctx, newBinary)))
+	require.Equal(t, 0, len(newBinary.GetUpgradeVotes(ctx)))
+
+	// An applied plan cannot be voted for again
+	res = NewHandler(newBinary)(input.ctx.WithBlockHeight(11), NewMsgVoteUpgrade(NewUpgradePlan("v0.3.0", 20, ""), valAddrs[0]))
+	require.Equal(t, CodePlanApplied, res.Code)
+}
@@ -0,0 +1,16 @@
+package update
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+var msgCdc = codec.New()
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgVoteUpgrade{}, "update/MsgVoteUpgrade", nil)
+}
+
+func init() {
+	RegisterCodec(msgCdc)
+}
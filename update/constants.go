@@ -0,0 +1,22 @@
+package update
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the update module
+	ModuleName = "update"
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the update module
+	RouterKey = ModuleName
+
+	// QuerierRoute is the query router key for the update module
+	QuerierRoute = ModuleName
+)
+
+// VoteThreshold is the share of the bonded stake that must vote for an upgrade plan to schedule it
+var VoteThreshold = sdk.NewDec(2).Quo(sdk.NewDec(3))
@@ -1,25 +1,84 @@
 package update
 
 import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
+	"strconv"
+
+	"github.com/terra-project/core/update/tags"
 
-	"github.com/terra-project/core/update/plan"
-	"github.com/terra-project/core/x/market"
-	"github.com/terra-project/core/x/oracle"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// EndBlocker
-func EndBlocker(
-	ctx sdk.Context,
-	accountKeeper auth.AccountKeeper,
-	oracleKeeper oracle.Keeper,
-	marketKeeper market.Keeper) (resTags sdk.Tags) {
+// EndBlocker applies the upgrade plans scheduled at the current height that were not applied yet,
+// and schedules the upgrade plan the validators voted for, if any
+func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
+	for _, p := range k.GetRegistry().GetPlansAt(ctx.BlockHeight()) {
+		if k.IsApplied(ctx, p.Name) {
+			continue
+		}
+
+		k.ApplyPlan(ctx, p)
+
+		resTags = resTags.AppendTag(p.Name, "updated")
+		ctx.Logger().Info("Applied upgrade plan", "plan", p.Name, "height", ctx.BlockHeight())
+	}
+
+	resTags = resTags.AppendTags(tallyUpgradeVotes(ctx, k))
+	return
+}
+
+// tallyUpgradeVotes schedules the upgrade plan that the validators holding more than VoteThreshold
+// of the bonded stake voted for, and clears the votes. Votes for plans that can no longer be
+// scheduled, or of validators that left the validator set, are dropped.
+func tallyUpgradeVotes(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
+	var plans []UpgradePlan
+	powers := make(map[UpgradePlan]sdk.Int)
+
+	votes := k.GetUpgradeVotes(ctx)
+	for _, vote := range votes {
+		if k.validateUpgradePlan(ctx, vote.Plan) != nil {
+			k.DeleteUpgradeVote(ctx, vote.Validator)
+			continue
+		}
+
+		validator := k.valset.Validator(ctx, vote.Validator)
+		if validator == nil {
+			k.DeleteUpgradeVote(ctx, vote.Validator)
+			continue
+		}
+
+		power, voted := powers[vote.Plan]
+		if !voted {
+			plans = append(plans, vote.Plan)
+			power = sdk.ZeroInt()
+		}
+		powers[vote.Plan] = power.Add(validator.GetBondedTokens())
+	}
+
+	totalPower := k.valset.TotalBondedTokens(ctx)
+	if !totalPower.IsPositive() {
+		return
+	}
+
+	for _, up := range plans {
+		if !sdk.NewDecFromInt(powers[up]).QuoInt(totalPower).GT(VoteThreshold) {
+			continue
+		}
+
+		if err := k.ScheduleUpgrade(ctx, up); err != nil {
+			ctx.Logger().Error("Failed to schedule software upgrade", "plan", up.Name, "err", err.Error())
+			continue
+		}
 
-	updated := plan.Update230000(ctx, accountKeeper, oracleKeeper, marketKeeper)
+		for _, vote := range votes {
+			k.DeleteUpgradeVote(ctx, vote.Validator)
+		}
 
-	if updated {
-		resTags.AppendTag(plan.TagUpdate230000, "updated")
+		ctx.Logger().Info("Scheduled software upgrade", "plan", up.Name, "height", up.Height)
+		return sdk.NewTags(
+			tags.Action, tags.ActionUpgradeScheduled,
+			tags.Upgrade, up.Name,
+			tags.Height, strconv.FormatInt(up.Height, 10),
+		)
 	}
 
 	return
@@ -0,0 +1,78 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/terra-project/core/update/plan"
+)
+
+func TestEndBlockerAppliesPlanOnce(t *testing.T) {
+	applied := 0
+	input := createTestInput(t,
+		plan.NewPlan("test_plan", 10, func(ctx sdk.Context, keepers plan.Keepers) {
+			applied++
+		}),
+	)
+
+	// Nothing happens before the plan height
+	tags := EndBlocker(input.ctx.WithBlockHeight(9), input.updateKeeper)
+	require.Equal(t, 0, applied)
+	require.Equal(t, 0, len(tags))
+	require.False(t, input.updateKeeper.IsApplied(input.ctx, "test_plan"))
+
+	// The plan is applied at its height
+	ctx := input.ctx.WithBlockHeight(10)
+	tags = EndBlocker(ctx, input.updateKeeper)
+	require.Equal(t, 1, applied)
+	require.Equal(t, sdk.NewTags("test_plan", "updated"), tags)
+	require.True(t, input.updateKeeper.IsApplied(ctx, "test_plan"))
+
+	// and never again, even if the height is replayed
+	tags = EndBlocker(ctx, input.updateKeeper)
+	require.Equal(t, 1, applied)
+	require.Equal(t, 0, len(tags))
+
+	EndBlocker(input.ctx.WithBlockHeight(11), input.updateKeeper)
+	require.Equal(t, 1, applied)
+}
+
+func TestRegistry(t *testing.T) {
+	noop := func(ctx sdk.Context, keepers plan.Keepers) {}
+
+	registry := plan.NewRegistry(
+		plan.NewPlan("second", 20, noop),
+		plan.NewPlan("first", 10, noop),
+		plan.NewPlan("also_first", 10, noop),
+	)
+
+	plans := registry.GetPlans()
+	require.Equal(t, 3, len(plans))
+	require.Equal(t, "first", plans[0].Name)
+	require.Equal(t, "also_first", plans[1].Name)
+	require.Equal(t, "second", plans[2].Name)
+
+	require.Equal(t, 2, len(registry.GetPlansAt(10)))
+	require.Equal(t, 0, len(registry.GetPlansAt(15)))
+
+	p, found := registry.GetPlan("second")
+	require.True(t, found)
+	require.Equal(t, int64(20), p.Height)
+
+	_, found = registry.GetPlan("third")
+	require.False(t, found)
+
+	// Invalid registries
+	require.Panics(t, func() { plan.NewRegistry(plan.NewPlan("dup", 10, noop), plan.NewPlan("dup", 20, noop)) })
+	require.Panics(t, func() { plan.NewRegistry(plan.NewPlan("", 10, noop)) })
+	require.Panics(t, func() { plan.NewRegistry(plan.NewPlan("negative", -1, noop)) })
+	require.Panics(t, func() { plan.NewRegistry(plan.NewPlan("nil", 10, nil)) })
+
+	// The default registry holds the 230000 update
+	p, found = plan.DefaultRegistry().GetPlan(plan.TagUpdate230000)
+	require.True(t, found)
+	require.Equal(t, int64(230000), p.Height)
+}
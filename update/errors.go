@@ -0,0 +1,32 @@
+package update
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultCodespace nolint
+	DefaultCodespace sdk.CodespaceType = "update"
+
+	// Update errors
+	CodeInvalidUpgradePlan sdk.CodeType = 1
+	CodePlanApplied        sdk.CodeType = 2
+	CodeValidatorNotFound  sdk.CodeType = 3
+)
+
+// nolint
+func ErrInvalidUpgradePlan(reason string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidUpgradePlan, fmt.Sprintf("invalid upgrade plan: %s", reason))
+}
+
+// nolint
+func ErrPlanApplied(name string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodePlanApplied, fmt.Sprintf("plan %s was already applied", name))
+}
+
+// nolint
+func ErrValidatorNotFound(valAddr sdk.ValAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeValidatorNotFound, fmt.Sprintf("validator %s not found", valAddr))
+}
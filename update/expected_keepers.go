@@ -0,0 +1,11 @@
+package update
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidatorSet is the expected validator set, which weighs the upgrade votes
+type ValidatorSet interface {
+	Validator(ctx sdk.Context, address sdk.ValAddress) sdk.Validator
+	TotalBondedTokens(ctx sdk.Context) sdk.Int
+}
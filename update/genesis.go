@@ -0,0 +1,126 @@
+package update
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all update state that must be provided at genesis
+type GenesisState struct {
+	UpgradePlan  UpgradePlan   `json:"upgrade_plan"` // empty if no upgrade is scheduled
+	AppliedPlans []AppliedPlan `json:"applied_plans"`
+	UpgradeVotes []UpgradeVote `json:"upgrade_votes"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(upgradePlan UpgradePlan, appliedPlans []AppliedPlan, upgradeVotes []UpgradeVote) GenesisState {
+	return GenesisState{
+		UpgradePlan:  upgradePlan,
+		AppliedPlans: appliedPlans,
+		UpgradeVotes: upgradeVotes,
+	}
+}
+
+// DefaultGenesisState get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		UpgradePlan:  UpgradePlan{},
+		AppliedPlans: []AppliedPlan{},
+		UpgradeVotes: []UpgradeVote{},
+	}
+}
+
+// InitGenesis new update genesis
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, p := range data.AppliedPlans {
+		keeper.setAppliedHeight(ctx, p.Name, p.AppliedHeight)
+	}
+
+	if !data.UpgradePlan.Empty() {
+		if err := keeper.ScheduleUpgrade(ctx, data.UpgradePlan); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, vote := range data.UpgradeVotes {
+		keeper.SetUpgradeVote(ctx, vote.Validator, vote.Plan)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	upgradePlan, _ := keeper.GetUpgradePlan(ctx)
+	return NewGenesisState(upgradePlan, append([]AppliedPlan{}, keeper.GetAppliedPlans(ctx)...),
+		append([]UpgradeVote{}, keeper.GetUpgradeVotes(ctx)...))
+}
+
+// PrepForZeroHeightGenesis makes the heights of the scheduled upgrade and of the plans voted for
+// relative to the export, for a chain restarted from it at height zero. The plans applied keep the
+// heights of the exporting chain.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	// the plans are due after the last block of the exporting chain
+	for _, vote := range keeper.GetUpgradeVotes(ctx) {
+		if vote.Plan.Height <= ctx.BlockHeight() {
+			keeper.DeleteUpgradeVote(ctx, vote.Validator)
+			continue
+		}
+
+		vote.Plan.Height -= ctx.BlockHeight()
+		keeper.SetUpgradeVote(ctx, vote.Validator, vote.Plan)
+	}
+
+	upgradePlan, found := keeper.GetUpgradePlan(ctx)
+	if !found {
+		return
+	}
+
+	upgradePlan.Height -= ctx.BlockHeight()
+	if err := keeper.ScheduleUpgrade(ctx.WithBlockHeight(0), upgradePlan); err != nil {
+		panic(err)
+	}
+}
+
+// ValidateGenesis validates the provided update genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	applied := make(map[string]bool)
+	for _, p := range data.AppliedPlans {
+		if len(p.Name) == 0 {
+			return fmt.Errorf("applied plan without a name")
+		}
+
+		if applied[p.Name] {
+			return fmt.Errorf("duplicate applied plan: %s", p.Name)
+		}
+		applied[p.Name] = true
+	}
+
+	voted := make(map[string]bool)
+	for _, vote := range data.UpgradeVotes {
+		if len(vote.Validator) == 0 {
+			return fmt.Errorf("upgrade vote without a validator")
+		}
+
+		if voted[vote.Validator.String()] {
+			return fmt.Errorf("duplicate upgrade vote of %s", vote.Validator)
+		}
+		voted[vote.Validator.String()] = true
+
+		if vote.Plan.Empty() || vote.Plan.Height <= 0 {
+			return fmt.Errorf("upgrade vote of %s must be for a named plan with a positive height", vote.Validator)
+		}
+	}
+
+	if !data.UpgradePlan.Empty() {
+		if data.UpgradePlan.Height <= 0 {
+			return fmt.Errorf("upgrade plan %s must have a positive height, is %d", data.UpgradePlan.Name, data.UpgradePlan.Height)
+		}
+
+		if applied[data.UpgradePlan.Name] {
+			return fmt.Errorf("upgrade plan %s was already applied", data.UpgradePlan.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package update
+
+import (
+	"reflect"
+
+	"github.com/terra-project/core/update/tags"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler creates a new handler for all update type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgVoteUpgrade:
+			return handleMsgVoteUpgrade(ctx, k, msg)
+		default:
+			errMsg := "Unrecognized update Msg type: " + reflect.TypeOf(msg).Name()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// handleMsgVoteUpgrade records the vote of a validator for an upgrade plan, replacing its previous
+// vote. The plan is scheduled by the end blocker once enough of the bonded stake votes for it.
+func handleMsgVoteUpgrade(ctx sdk.Context, k Keeper, msg MsgVoteUpgrade) sdk.Result {
+	if k.valset.Validator(ctx, msg.Validator) == nil {
+		return ErrValidatorNotFound(msg.Validator).Result()
+	}
+
+	if msg.Plan.Empty() {
+		k.DeleteUpgradeVote(ctx, msg.Validator)
+	} else {
+		if err := k.validateUpgradePlan(ctx, msg.Plan); err != nil {
+			return err.Result()
+		}
+
+		k.SetUpgradeVote(ctx, msg.Validator, msg.Plan)
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionUpgradeVote,
+			tags.Voter, msg.Validator.String(),
+			tags.Upgrade, msg.Plan.Name,
+		),
+	}
+}
@@ -0,0 +1,211 @@
+package update
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/terra-project/core/update/plan"
+)
+
+// Keeper of the update store
+type Keeper struct {
+	cdc *codec.Codec
+	key sdk.StoreKey
+
+	valset   ValidatorSet
+	keepers  plan.Keepers
+	registry plan.Registry
+}
+
+// NewKeeper constructs a new keeper, which applies the plans of the registry with the given keepers.
+// Upgrades are scheduled by the votes of the validators of valset.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, valset ValidatorSet, keepers plan.Keepers, registry plan.Registry) Keeper {
+	return Keeper{
+		cdc:      cdc,
+		key:      key,
+		valset:   valset,
+		keepers:  keepers,
+		registry: registry,
+	}
+}
+
+// GetRegistry returns the registry of upgrade plans
+func (k Keeper) GetRegistry() plan.Registry {
+	return k.registry
+}
+
+// IsApplied returns true if the plan named name was applied
+func (k Keeper) IsApplied(ctx sdk.Context, name string) bool {
+	store := ctx.KVStore(k.key)
+	return store.Has(keyAppliedPlan(name))
+}
+
+// SetApplied records the plan as applied at the current height
+func (k Keeper) SetApplied(ctx sdk.Context, p plan.Plan) {
+	k.setAppliedHeight(ctx, p.Name, ctx.BlockHeight())
+}
+
+func (k Keeper) setAppliedHeight(ctx sdk.Context, name string, height int64) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(height)
+	store.Set(keyAppliedPlan(name), bz)
+}
+
+// IterateAppliedPlans iterates over the applied plans, with the heights they were applied at.
+// Stops when handler returns true.
+func (k Keeper) IterateAppliedPlans(ctx sdk.Context, handler func(name string, height int64) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	prefix := keyAppliedPlan("")
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var height int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &height)
+
+		if handler(string(iter.Key()[len(prefix):]), height) {
+			break
+		}
+	}
+}
+
+// AppliedPlan is a plan that was applied, with the height it was applied at
+type AppliedPlan struct {
+	Name          string `json:"name"`
+	Height        int64  `json:"height"`
+	AppliedHeight int64  `json:"applied_height"`
+}
+
+// GetAppliedPlans returns every applied plan. Plans removed from the registry since, or that only
+// run when scheduled as an upgrade, have no Height.
+func (k Keeper) GetAppliedPlans(ctx sdk.Context) (applied []AppliedPlan) {
+	k.IterateAppliedPlans(ctx, func(name string, height int64) (stop bool) {
+		p := AppliedPlan{Name: name, AppliedHeight: height}
+		if registered, found := k.registry.GetPlan(name); found {
+			p.Height = registered.Height
+		}
+
+		applied = append(applied, p)
+		return false
+	})
+
+	return
+}
+
+// ApplyPlan runs the handler of the plan and records it as applied
+func (k Keeper) ApplyPlan(ctx sdk.Context, p plan.Plan) {
+	p.Handler(ctx, k.keepers)
+	k.SetApplied(ctx, p)
+}
+
+//-----------------------------------
+// Upgrade plan logic
+
+// ScheduleUpgrade schedules a coordinated upgrade, replacing the one scheduled before if any.
+// The validators schedule upgrades on chain by voting for them; see MsgVoteUpgrade.
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, up UpgradePlan) sdk.Error {
+	if err := k.validateUpgradePlan(ctx, up); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(up)
+	store.Set(keyUpgradePlan, bz)
+	return nil
+}
+
+// GetUpgradePlan returns the scheduled upgrade, if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (up UpgradePlan, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(keyUpgradePlan)
+	if bz == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &up)
+	return up, true
+}
+
+// ClearUpgradePlan cancels the scheduled upgrade
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyUpgradePlan)
+}
+
+func (k Keeper) validateUpgradePlan(ctx sdk.Context, up UpgradePlan) sdk.Error {
+	if up.Empty() {
+		return ErrInvalidUpgradePlan("name cannot be empty")
+	}
+
+	if up.Height <= ctx.BlockHeight() {
+		return ErrInvalidUpgradePlan(fmt.Sprintf("height %d has already passed", up.Height))
+	}
+
+	if k.IsApplied(ctx, up.Name) {
+		return ErrPlanApplied(up.Name)
+	}
+
+	return nil
+}
+
+//-----------------------------------
+// Upgrade vote logic
+
+// SetUpgradeVote records the vote of a validator for an upgrade plan
+func (k Keeper) SetUpgradeVote(ctx sdk.Context, valAddr sdk.ValAddress, up UpgradePlan) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(up)
+	store.Set(keyUpgradeVote(valAddr), bz)
+}
+
+// GetUpgradeVote returns the upgrade plan a validator voted for, if any
+func (k Keeper) GetUpgradeVote(ctx sdk.Context, valAddr sdk.ValAddress) (up UpgradePlan, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(keyUpgradeVote(valAddr))
+	if bz == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &up)
+	return up, true
+}
+
+// DeleteUpgradeVote withdraws the vote of a validator
+func (k Keeper) DeleteUpgradeVote(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyUpgradeVote(valAddr))
+}
+
+// IterateUpgradeVotes iterates over the upgrade votes. Stops when handler returns true.
+func (k Keeper) IterateUpgradeVotes(ctx sdk.Context, handler func(vote UpgradeVote) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	prefix := keyUpgradeVote(sdk.ValAddress{})
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		valAddr, err := sdk.ValAddressFromBech32(string(iter.Key()[len(prefix):]))
+		if err != nil {
+			panic(err)
+		}
+
+		var up UpgradePlan
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &up)
+
+		if handler(NewUpgradeVote(valAddr, up)) {
+			break
+		}
+	}
+}
+
+// GetUpgradeVotes returns every upgrade vote
+func (k Keeper) GetUpgradeVotes(ctx sdk.Context) (votes []UpgradeVote) {
+	k.IterateUpgradeVotes(ctx, func(vote UpgradeVote) (stop bool) {
+		votes = append(votes, vote)
+		return false
+	})
+
+	return
+}
@@ -0,0 +1,22 @@
+package update
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+var (
+	prefixAppliedPlan = []byte("applied_plan")
+	keyUpgradePlan    = []byte("upgrade_plan")
+	prefixUpgradeVote = []byte("upgrade_vote")
+)
+
+func keyAppliedPlan(name string) []byte {
+	return []byte(fmt.Sprintf("%s:%s", prefixAppliedPlan, name))
+}
+
+func keyUpgradeVote(valAddr sdk.ValAddress) []byte {
+	return []byte(fmt.Sprintf("%s:%s", prefixUpgradeVote, valAddr))
+}
@@ -0,0 +1,58 @@
+package update
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgVoteUpgrade defines the msg of a validator voting for an upgrade plan. An empty plan
+// withdraws the vote of the validator.
+type MsgVoteUpgrade struct {
+	Plan      UpgradePlan    `json:"plan"`      // Plan voted for
+	Validator sdk.ValAddress `json:"validator"` // Operator address of the voting validator
+}
+
+// NewMsgVoteUpgrade creates a MsgVoteUpgrade instance
+func NewMsgVoteUpgrade(plan UpgradePlan, validator sdk.ValAddress) MsgVoteUpgrade {
+	return MsgVoteUpgrade{
+		Plan:      plan,
+		Validator: validator,
+	}
+}
+
+// Route returns msg route
+func (msg MsgVoteUpgrade) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgVoteUpgrade) Type() string { return "voteupgrade" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgVoteUpgrade) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgVoteUpgrade) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.Validator)}
+}
+
+// ValidateBasic validate msg
+func (msg MsgVoteUpgrade) ValidateBasic() sdk.Error {
+	if len(msg.Validator) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Validator.String())
+	}
+
+	if !msg.Plan.Empty() && msg.Plan.Height <= 0 {
+		return ErrInvalidUpgradePlan(fmt.Sprintf("height must be positive, is %d", msg.Plan.Height))
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgVoteUpgrade) String() string {
+	return fmt.Sprintf(`MsgVoteUpgrade
+	Validator: %v
+	Plan: %v`, msg.Validator, msg.Plan)
+}
@@ -0,0 +1,109 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
+	"github.com/terra-project/core/x/oracle"
+)
+
+// Keepers is the bundle of keepers an upgrade plan may migrate state with
+type Keepers struct {
+	AccountKeeper auth.AccountKeeper
+	OracleKeeper  oracle.Keeper
+	MarketKeeper  market.Keeper
+	MintKeeper    mint.ModuleKeeper // e.g. to set the clock transition height of a live chain
+}
+
+// Handler migrates the chain state when its plan is applied
+type Handler func(ctx sdk.Context, keepers Keepers)
+
+// Plan is a state migration applied once, at the end of the block at Height. Plans without a
+// Height are only applied when a coordinated upgrade is scheduled on chain under their Name.
+type Plan struct {
+	Name    string  `json:"name"`
+	Height  int64   `json:"height"`
+	Handler Handler `json:"-"`
+}
+
+// NewPlan returns a new upgrade plan
+func NewPlan(name string, height int64, handler Handler) Plan {
+	return Plan{
+		Name:    name,
+		Height:  height,
+		Handler: handler,
+	}
+}
+
+// String implements fmt.Stringer
+func (p Plan) String() string {
+	return fmt.Sprintf("Plan %s at height %d", p.Name, p.Height)
+}
+
+// Registry holds the upgrade plans of the chain, ordered by height
+type Registry struct {
+	plans []Plan
+}
+
+// NewRegistry returns a registry of the given plans. Panics if two plans share a name,
+// or if a plan has no name, a negative height or no handler.
+func NewRegistry(plans ...Plan) Registry {
+	names := make(map[string]bool)
+	for _, p := range plans {
+		if len(p.Name) == 0 || p.Height < 0 || p.Handler == nil {
+			panic(fmt.Sprintf("invalid upgrade plan: %s", p))
+		}
+
+		if names[p.Name] {
+			panic(fmt.Sprintf("duplicate upgrade plan: %s", p.Name))
+		}
+		names[p.Name] = true
+	}
+
+	sorted := make([]Plan, len(plans))
+	copy(sorted, plans)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+
+	return Registry{plans: sorted}
+}
+
+// DefaultRegistry returns the registry of every upgrade plan of the chain
+func DefaultRegistry() Registry {
+	return NewRegistry(
+		NewPlan(TagUpdate230000, 230000, update230000),
+	)
+}
+
+// GetPlans returns every registered plan, ordered by height
+func (r Registry) GetPlans() []Plan {
+	return r.plans
+}
+
+// GetPlan returns the plan registered under name
+func (r Registry) GetPlan(name string) (Plan, bool) {
+	for _, p := range r.plans {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Plan{}, false
+}
+
+// GetPlansAt returns the plans scheduled at height
+func (r Registry) GetPlansAt(height int64) (plans []Plan) {
+	for _, p := range r.plans {
+		if p.Height != 0 && p.Height == height {
+			plans = append(plans, p)
+		}
+	}
+
+	return
+}
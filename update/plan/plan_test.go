@@ -0,0 +1,45 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
+)
+
+func TestPlanStartsClock(t *testing.T) {
+	input := createTestInput(t)
+	ctx := input.ctx.WithBlockHeight(100)
+
+	keepers := Keepers{
+		AccountKeeper: input.accKeeper,
+		OracleKeeper:  input.oracleKeeper,
+		MarketKeeper:  input.marketKeeper,
+		MintKeeper:    input.mintKeeper.ForModule(updateModule),
+	}
+
+	// The clock stays off on a chain started without a transition height
+	mint.BeginBlocker(ctx, input.mintKeeper)
+	_, found := input.mintKeeper.GetClock(ctx)
+	require.False(t, found)
+
+	p := NewPlan("clock", 0, func(ctx sdk.Context, keepers Keepers) {
+		require.Nil(t, keepers.MintKeeper.SetParams(ctx, mint.NewParams(ctx.BlockHeight()+1)))
+	})
+	p.Handler(ctx, keepers)
+
+	// Modules without the params permission cannot change them
+	err := input.mintKeeper.ForModule(market.ModuleName).SetParams(ctx, mint.DefaultParams())
+	require.NotNil(t, err)
+
+	// The plan applies at the end of the block; the clock starts with the next one
+	ctx = ctx.WithBlockHeight(101)
+	mint.BeginBlocker(ctx, input.mintKeeper)
+	clock, found := input.mintKeeper.GetClock(ctx)
+	require.True(t, found)
+	require.Equal(t, int64(0), clock.Day)
+}
@@ -45,12 +45,16 @@ func setup(t *testing.T) testInput {
 	return input
 }
 
+// updateModule is the name the update module registers its mint permission under
+const updateModule = "update"
+
 type testInput struct {
 	ctx          sdk.Context
 	cdc          *codec.Codec
 	accKeeper    auth.AccountKeeper
 	oracleKeeper oracle.Keeper
 	marketKeeper market.Keeper
+	mintKeeper   mint.Keeper
 }
 
 func newTestCodec() *codec.Codec {
@@ -134,7 +138,12 @@ func createTestInput(t *testing.T) testInput {
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
+	mintKeeper.SetParams(ctx, mint.DefaultParams())
+	mintKeeper.RegisterPermission(market.ModuleName, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+	mintKeeper.RegisterPermission(updateModule, mint.NewPermission([]string{mint.OperationParams}, nil))
 
 	stakingKeeper.SetPool(ctx, staking.InitialPool())
 	stakingParams := staking.DefaultParams()
@@ -155,9 +164,9 @@ func createTestInput(t *testing.T) testInput {
 		cdc,
 		keyMarket,
 		oracleKeeper,
-		mintKeeper,
+		mintKeeper.ForModule(market.ModuleName),
 		paramsKeeper.Subspace(market.DefaultParamspace),
 	)
 
-	return testInput{ctx, cdc, accKeeper, oracleKeeper, marketKeeper}
+	return testInput{ctx, cdc, accKeeper, oracleKeeper, marketKeeper, mintKeeper}
 }
@@ -49,6 +49,21 @@ func Update230000(ctx sdk.Context, accKeeper auth.AccountKeeper, oracleKeeper or
 		return false
 	}
 
+	update230000(ctx, Keepers{
+		AccountKeeper: accKeeper,
+		OracleKeeper:  oracleKeeper,
+		MarketKeeper:  marketKeeper,
+	})
+
+	return true
+}
+
+// update230000 is the handler of the update 230000 plan
+func update230000(ctx sdk.Context, keepers Keepers) {
+	accKeeper := keepers.AccountKeeper
+	oracleKeeper := keepers.OracleKeeper
+	marketKeeper := keepers.MarketKeeper
+
 	// update vesting schedule
 	accKeeper.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
 		stop = false
@@ -61,47 +76,7 @@ func Update230000(ctx sdk.Context, accKeeper auth.AccountKeeper, oracleKeeper or
 
 		gvacc, ok := vacc.(types.GradedVestingAccount)
 		if ok {
-
-			var lazyVestingSchedules []types.LazyVestingSchedule
-
-			isPreseedAccount := false
-			for _, addr := range preseedAddresses {
-				if addr == gvacc.GetAddress().String() {
-					lazyVestingSchedules = updatePreseedSchedules(gvacc)
-					isPreseedAccount = true
-					break
-				}
-			}
-
-			isSeedAccount := false
-			for _, addr := range seedAddresses {
-				if addr == gvacc.GetAddress().String() {
-					lazyVestingSchedules = updateSeedSchedules(gvacc)
-					isSeedAccount = true
-					break
-				}
-			}
-
-			if !isPreseedAccount && !isSeedAccount {
-				// update to LazyGradedVestingAccount
-				vestingSchedules := gvacc.GetVestingSchedules()
-
-				for _, vs := range vestingSchedules {
-					var lazySchedules []types.LazySchedule
-					for _, s := range vs.Schedules {
-						lazySchedules = append(lazySchedules, types.NewLazySchedule(s.GetCliff(), s.GetCliff()+secondsPerMonth, s.GetRatio()))
-					}
-
-					lazyVestingSchedule := types.NewLazyVestingSchedule(vs.GetDenom(), lazySchedules)
-					lazyVestingSchedules = append(lazyVestingSchedules, lazyVestingSchedule)
-				}
-			}
-
-			for _, lvs := range lazyVestingSchedules {
-				if !lvs.IsValid() {
-					panic(fmt.Sprintf("not valid schdule: %v\n %v", gvacc, lvs))
-				}
-			}
+			lazyVestingSchedules := LazyVestingSchedules(gvacc)
 
 			baseAccount := &auth.BaseAccount{
 				Address:       gvacc.GetAddress(),
@@ -139,8 +114,52 @@ func Update230000(ctx sdk.Context, accKeeper auth.AccountKeeper, oracleKeeper or
 	marketParams := marketKeeper.GetParams(ctx)
 	marketParams.DailyLunaDeltaCap = sdk.NewDecWithPrec(1, 3) // 0.1%
 	marketKeeper.SetParams(ctx, marketParams)
+}
 
-	return true
+// LazyVestingSchedules converts the vesting schedules of a graded vesting account to the lazy vesting
+// schedules of update 230000. Preseed and seed accounts get their own schedules; the cliffs of the
+// others are spread over the month that follows them. Panics if a schedule turns out invalid.
+func LazyVestingSchedules(gvacc types.GradedVestingAccount) (lazyVestingSchedules []types.LazyVestingSchedule) {
+	isPreseedAccount := false
+	for _, addr := range preseedAddresses {
+		if addr == gvacc.GetAddress().String() {
+			lazyVestingSchedules = updatePreseedSchedules(gvacc)
+			isPreseedAccount = true
+			break
+		}
+	}
+
+	isSeedAccount := false
+	for _, addr := range seedAddresses {
+		if addr == gvacc.GetAddress().String() {
+			lazyVestingSchedules = updateSeedSchedules(gvacc)
+			isSeedAccount = true
+			break
+		}
+	}
+
+	if !isPreseedAccount && !isSeedAccount {
+		// update to LazyGradedVestingAccount
+		vestingSchedules := gvacc.GetVestingSchedules()
+
+		for _, vs := range vestingSchedules {
+			var lazySchedules []types.LazySchedule
+			for _, s := range vs.Schedules {
+				lazySchedules = append(lazySchedules, types.NewLazySchedule(s.GetCliff(), s.GetCliff()+secondsPerMonth, s.GetRatio()))
+			}
+
+			lazyVestingSchedule := types.NewLazyVestingSchedule(vs.GetDenom(), lazySchedules)
+			lazyVestingSchedules = append(lazyVestingSchedules, lazyVestingSchedule)
+		}
+	}
+
+	for _, lvs := range lazyVestingSchedules {
+		if !lvs.IsValid() {
+			panic(fmt.Sprintf("not valid schdule: %v\n %v", gvacc, lvs))
+		}
+	}
+
+	return
 }
 
 // preseed account does not have any other schedules
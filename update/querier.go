@@ -0,0 +1,118 @@
+package update
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/terra-project/core/update/plan"
+)
+
+// query endpoints supported by the update Querier
+const (
+	QueryApplied = "applied"
+	QueryPending = "pending"
+	QueryUpgrade = "upgrade"
+	QueryVotes   = "votes"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryApplied:
+			return queryApplied(ctx, req, keeper)
+		case QueryPending:
+			return queryPending(ctx, req, keeper)
+		case QueryUpgrade:
+			return queryUpgrade(ctx, req, keeper)
+		case QueryVotes:
+			return queryVotes(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown update query endpoint")
+		}
+	}
+}
+
+// JSON response format
+type QueryAppliedResponse []AppliedPlan
+
+func (r QueryAppliedResponse) String() (out string) {
+	for _, p := range r {
+		out += fmt.Sprintf("%s: scheduled at %d, applied at %d\n", p.Name, p.Height, p.AppliedHeight)
+	}
+	return strings.TrimSpace(out)
+}
+
+// JSON response format
+type QueryPendingResponse []plan.Plan
+
+func (r QueryPendingResponse) String() (out string) {
+	for _, p := range r {
+		out += fmt.Sprintf("%s: scheduled at %d\n", p.Name, p.Height)
+	}
+	return strings.TrimSpace(out)
+}
+
+// nolint: unparam
+func queryApplied(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	response := append(QueryAppliedResponse{}, keeper.GetAppliedPlans(ctx)...)
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, response)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryPending(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	response := QueryPendingResponse{}
+	for _, p := range keeper.GetRegistry().GetPlans() {
+		// plans whose height has passed without applying them will never run; plans without
+		// a height only run when an upgrade is scheduled under their name
+		if p.Height <= ctx.BlockHeight() || keeper.IsApplied(ctx, p.Name) {
+			continue
+		}
+
+		response = append(response, p)
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, response)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryUpgrade(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	up, found := keeper.GetUpgradePlan(ctx)
+	if !found {
+		return nil, sdk.ErrUnknownRequest("no upgrade is scheduled")
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, up)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryVotes(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	votes := append([]UpgradeVote{}, keeper.GetUpgradeVotes(ctx)...)
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, votes)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+
+	return bz, nil
+}
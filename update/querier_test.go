@@ -0,0 +1,47 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/terra-project/core/update/plan"
+)
+
+func TestQuerier(t *testing.T) {
+	noop := func(ctx sdk.Context, keepers plan.Keepers) {}
+	input := createTestInput(t,
+		plan.NewPlan("past", 5, noop),
+		plan.NewPlan("current", 10, noop),
+		plan.NewPlan("future", 20, noop),
+	)
+	querier := NewQuerier(input.updateKeeper)
+
+	ctx := input.ctx.WithBlockHeight(10)
+	EndBlocker(ctx, input.updateKeeper)
+
+	// Applied plans
+	bz, err := querier(ctx, []string{QueryApplied}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	var applied QueryAppliedResponse
+	require.Nil(t, input.cdc.UnmarshalJSON(bz, &applied))
+	require.Equal(t, QueryAppliedResponse{{Name: "current", Height: 10, AppliedHeight: 10}}, applied)
+
+	// Pending plans; the past plan was never applied and will never run
+	bz, err = querier(ctx, []string{QueryPending}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	var pending QueryPendingResponse
+	require.Nil(t, input.cdc.UnmarshalJSON(bz, &pending))
+	require.Equal(t, 1, len(pending))
+	require.Equal(t, "future", pending[0].Name)
+	require.Equal(t, int64(20), pending[0].Height)
+
+	// Unknown endpoint
+	_, err = querier(ctx, []string{"unknown"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
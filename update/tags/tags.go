@@ -0,0 +1,16 @@
+package tags
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Update tags
+var (
+	ActionUpgradeVote      = "upgrade-vote"      // emitted when a validator votes for an upgrade plan
+	ActionUpgradeScheduled = "upgrade-scheduled" // emitted when an upgrade plan wins the vote
+
+	Action  = sdk.TagAction
+	Voter   = "voter"
+	Upgrade = "upgrade"
+	Height  = "height"
+)
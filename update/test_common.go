@@ -0,0 +1,88 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/terra-project/core/update/plan"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+)
+
+var valAddrs = []sdk.ValAddress{
+	sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address()),
+}
+
+// mockValidatorSet is a validator set of bonded validators
+type mockValidatorSet struct {
+	validators map[string]staking.Validator
+}
+
+var _ ValidatorSet = mockValidatorSet{}
+
+func (vs mockValidatorSet) Validator(ctx sdk.Context, address sdk.ValAddress) sdk.Validator {
+	validator, found := vs.validators[address.String()]
+	if !found {
+		return nil
+	}
+	return validator
+}
+
+func (vs mockValidatorSet) TotalBondedTokens(ctx sdk.Context) sdk.Int {
+	total := sdk.ZeroInt()
+	for _, validator := range vs.validators {
+		total = total.Add(validator.GetBondedTokens())
+	}
+	return total
+}
+
+// setPower bonds the validator with the given tokens
+func (vs mockValidatorSet) setPower(valAddr sdk.ValAddress, tokens int64) {
+	validator := staking.NewValidator(valAddr, ed25519.GenPrivKey().PubKey(), staking.Description{})
+	validator.Status = sdk.Bonded
+	validator.Tokens = sdk.NewInt(tokens)
+	vs.validators[valAddr.String()] = validator
+}
+
+type testInput struct {
+	ctx          sdk.Context
+	cdc          *codec.Codec
+	valset       mockValidatorSet
+	updateKeeper Keeper
+}
+
+// createTestInput returns an update keeper with the given plans; the plans are handed empty keepers.
+// The validators of valAddrs are bonded with 10, 20 and 30 tokens.
+func createTestInput(t *testing.T, plans ...plan.Plan) testInput {
+	keyUpdate := sdk.NewKVStoreKey(StoreKey)
+
+	cdc := codec.New()
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+
+	ms.MountStoreWithDB(keyUpdate, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	valset := mockValidatorSet{validators: make(map[string]staking.Validator)}
+	for i, valAddr := range valAddrs {
+		valset.setPower(valAddr, int64(10*(i+1)))
+	}
+
+	updateKeeper := NewKeeper(cdc, keyUpdate, valset, plan.Keepers{}, plan.NewRegistry(plans...))
+
+	return testInput{ctx, cdc, valset, updateKeeper}
+}
@@ -0,0 +1,58 @@
+package update
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UpgradePlan schedules a coordinated software upgrade. At Height, nodes whose binary registers
+// a plan named Name run its migration and continue; the others halt until they are upgraded.
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+	Info   string `json:"info"` // where to find the new binary, release notes, etc.
+}
+
+// NewUpgradePlan returns a new upgrade plan
+func NewUpgradePlan(name string, height int64, info string) UpgradePlan {
+	return UpgradePlan{
+		Name:   name,
+		Height: height,
+		Info:   info,
+	}
+}
+
+// String implements fmt.Stringer
+func (up UpgradePlan) String() string {
+	return fmt.Sprintf(`UpgradePlan:
+  Name:   %s
+  Height: %d
+  Info:   %s`, up.Name, up.Height, up.Info)
+}
+
+// Empty returns true if no upgrade is scheduled by the plan
+func (up UpgradePlan) Empty() bool {
+	return len(up.Name) == 0
+}
+
+// UpgradeVote is the vote of a validator for an upgrade plan
+type UpgradeVote struct {
+	Validator sdk.ValAddress `json:"validator"`
+	Plan      UpgradePlan    `json:"plan"`
+}
+
+// NewUpgradeVote returns a new upgrade vote
+func NewUpgradeVote(validator sdk.ValAddress, plan UpgradePlan) UpgradeVote {
+	return UpgradeVote{
+		Validator: validator,
+		Plan:      plan,
+	}
+}
+
+// String implements fmt.Stringer
+func (vote UpgradeVote) String() string {
+	return fmt.Sprintf(`UpgradeVote:
+  Validator: %s
+  Plan:      %s`, vote.Validator, vote.Plan.Name)
+}
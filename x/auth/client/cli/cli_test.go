@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terra-project/core/testutil"
+)
+
+func TestQueryVesting(t *testing.T) {
+	cdc, _, _, _ := testutil.PrepareCmdTest()
+
+	queryVesting := GetCmdQueryVesting(cdc)
+
+	// Name check
+	require.Equal(t, "vesting", queryVesting.Name())
+
+	// Arg check
+	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.ExactArgs(1))), testutil.FS(queryVesting.Args))
+
+	// Check Flags
+	timeFlag := queryVesting.Flag(flagTime)
+	require.NotNil(t, timeFlag)
+}
+
+func TestParseTime(t *testing.T) {
+	timeStr, err := parseTime("")
+	require.Nil(t, err)
+	require.Equal(t, "", timeStr)
+
+	timeStr, err = parseTime("1577836800")
+	require.Nil(t, err)
+	require.Equal(t, "1577836800", timeStr)
+
+	timeStr, err = parseTime("2020-01-01T00:00:00Z")
+	require.Nil(t, err)
+	require.Equal(t, "1577836800", timeStr)
+
+	_, err = parseTime("tomorrow")
+	require.NotNil(t, err)
+}
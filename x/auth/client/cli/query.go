@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/x/auth"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagTime = "time"
+)
+
+// GetCmdQueryVesting implements the query vesting status command.
+func GetCmdQueryVesting(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vesting [address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the vesting status of an account",
+		Long: strings.TrimSpace(`
+Query, per denom, the original vesting, vested, vesting, spendable, delegated free and delegated vesting
+coins of an account, along with the next time more coins unlock. The status is computed at --time, given
+as a UNIX Epoch time or in RFC3339 format; it defaults to the time of the latest block.
+
+$ terracli query account vesting terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv --time 2020-01-01T00:00:00Z
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			timeStr, err := parseTime(viper.GetString(flagTime))
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", auth.QuerierRoute, auth.QueryVesting, addr, timeStr), nil)
+			if err != nil {
+				return err
+			}
+
+			var status types.VestingStatus
+			cdc.MustUnmarshalJSON(res, &status)
+			return cliCtx.PrintOutput(status)
+		},
+	}
+
+	cmd.Flags().String(flagTime, "", "(optional) time of the status, as a UNIX Epoch time or in RFC3339 format; default is the latest block time")
+
+	return cmd
+}
+
+// parseTime converts a UNIX Epoch or RFC3339 time to a UNIX Epoch time string; empty stays empty
+func parseTime(timeStr string) (string, error) {
+	if len(timeStr) == 0 {
+		return "", nil
+	}
+
+	if _, err := strconv.ParseInt(timeStr, 10, 64); err == nil {
+		return timeStr, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, timeStr)
+	if err != nil {
+		return "", fmt.Errorf("given time %s is neither a UNIX Epoch time nor in RFC3339 format", timeStr)
+	}
+
+	return strconv.FormatInt(t.Unix(), 10), nil
+}
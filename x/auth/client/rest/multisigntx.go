@@ -20,6 +20,7 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
 	r.HandleFunc("/auth/accounts/{address}/multisign", MultiSignRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+	r.HandleFunc("/auth/accounts/{address}/vesting", QueryVestingRequestHandlerFn(cdc, cliCtx)).Methods("GET")
 }
 
 // MultiSignReq defines the properties of a multisign request's body.
@@ -0,0 +1,69 @@
+// Package auth contains the account queries of Terra that are not served by the auth module of the sdk.
+package auth
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/terra-project/core/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// QuerierRoute is the querier route for account queries
+const QuerierRoute = "account"
+
+// query endpoints supported by the account Querier
+const (
+	QueryVesting = "vesting"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(ak auth.AccountKeeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryVesting:
+			return queryVesting(ctx, path[1:], req, ak, cdc)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown account query endpoint")
+		}
+	}
+}
+
+// queryVesting returns the vesting status of the account at path[0], as of the UNIX Epoch time at path[1].
+// The time defaults to the time of the current block.
+// nolint: unparam
+func queryVesting(ctx sdk.Context, path []string, req abci.RequestQuery, ak auth.AccountKeeper, cdc *codec.Codec) ([]byte, sdk.Error) {
+	if len(path) == 0 || len(path[0]) == 0 {
+		return nil, sdk.ErrUnknownRequest("address parameter is missing")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+
+	blockTime := ctx.BlockHeader().Time
+	if len(path) > 1 && len(path[1]) != 0 {
+		unixTime, err := strconv.ParseInt(path[1], 10, 64)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest("time parameter is not correctly formatted")
+		}
+		blockTime = time.Unix(unixTime, 0)
+	}
+
+	acc := ak.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(addr.String())
+	}
+
+	bz, err := codec.MarshalJSONIndent(cdc, types.NewVestingStatus(acc, blockTime))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/types/assets"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, *codec.Codec, auth.AccountKeeper) {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tKeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	types.RegisterCodec(cdc)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tKeyParams)
+	accKeeper := auth.NewAccountKeeper(
+		cdc,
+		keyAcc,
+		paramsKeeper.Subspace(auth.DefaultParamspace),
+		auth.ProtoBaseAccount,
+	)
+
+	return ctx, cdc, accKeeper
+}
+
+func TestQueryVesting(t *testing.T) {
+	ctx, cdc, accKeeper := createTestInput(t)
+	querier := NewQuerier(accKeeper, cdc)
+
+	// Half of the grant vests over the next 1000 seconds
+	now := ctx.BlockHeader().Time.Unix()
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(sdk.Coins{sdk.NewInt64Coin(assets.MicroLunaDenom, 10000)})
+	accKeeper.SetAccount(ctx, types.NewBaseLazyGradedVestingAccount(&bacc, []types.LazyVestingSchedule{
+		types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+			types.NewLazySchedule(now, now+1000, sdk.NewDecWithPrec(5, 1)),
+			types.NewLazySchedule(now+2000, now+2000, sdk.NewDecWithPrec(5, 1)),
+		}),
+	}))
+
+	query := func(path ...string) (types.VestingStatus, sdk.Error) {
+		var status types.VestingStatus
+		bz, err := querier(ctx, append([]string{QueryVesting}, path...), abci.RequestQuery{})
+		if err == nil {
+			require.Nil(t, cdc.UnmarshalJSON(bz, &status))
+		}
+		return status, err
+	}
+
+	// Defaults to the current block time
+	status, err := query(addr.String())
+	require.Nil(t, err)
+	require.Equal(t, addr, status.Address)
+	require.Equal(t, now, status.Time)
+	require.Equal(t, 1, len(status.Denoms))
+	require.True(t, status.Denoms[0].Vesting.Equal(sdk.NewInt(10000)))
+	require.Equal(t, now+1, status.Denoms[0].NextUnlockTime)
+
+	// At a given time
+	status, err = query(addr.String(), strconv.FormatInt(now+1500, 10))
+	require.Nil(t, err)
+	require.Equal(t, now+1500, status.Time)
+	require.True(t, status.Denoms[0].Vested.Equal(sdk.NewInt(5000)))
+	require.True(t, status.Denoms[0].Spendable.Equal(sdk.NewInt(5000)))
+	require.Equal(t, now+2000, status.Denoms[0].NextUnlockTime)
+
+	// Invalid parameters
+	_, err = query()
+	require.NotNil(t, err)
+
+	_, err = query("terra1invalid")
+	require.NotNil(t, err)
+
+	_, err = query(addr.String(), "tomorrow")
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+
+	_, err = query(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String())
+	require.NotNil(t, err)
+}
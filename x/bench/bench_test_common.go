@@ -41,6 +41,9 @@ var (
 	uSDRAmt  = sdk.NewInt(10000000000).MulRaw(assets.MicroUnit)
 )
 
+// faucet is the module the tests fund accounts as; it may mint and burn every denom
+const faucet = "faucet"
+
 type testInput struct {
 	ctx            sdk.Context
 	cdc            *codec.Codec
@@ -144,8 +147,14 @@ func createTestInput() testInput {
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(faucet, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+	mintKeeper.RegisterPermission(market.ModuleName, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+
 	sh := staking.NewHandler(stakingKeeper)
 	for i := 0; i < 100; i++ {
 		pubKeys[i] = secp256k1.GenPrivKey().PubKey()
@@ -154,7 +163,7 @@ func createTestInput() testInput {
 		valConsPubKeys[i] = ed25519.GenPrivKey().PubKey()
 		valConsAddrs[i] = sdk.ConsAddress(valConsPubKeys[i].Address())
 
-		err2 := mintKeeper.Mint(ctx, addrs[i], sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(3)))
+		err2 := mintKeeper.ForModule(faucet).Mint(ctx, addrs[i], sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(3)))
 		if err2 != nil {
 			panic(err2)
 		}
@@ -186,20 +195,22 @@ func createTestInput() testInput {
 		cdc,
 		keyMarket,
 		oracleKeeper,
-		mintKeeper,
+		mintKeeper.ForModule(market.ModuleName),
 		paramsKeeper.Subspace(market.DefaultParamspace))
 
 	treasuryKeeper := treasury.NewKeeper(
 		cdc,
 		keyTreasury,
 		stakingKeeper.GetValidatorSet(),
-		mintKeeper,
+		mintKeeper.ForModule(treasury.ModuleName),
 		marketKeeper,
 		paramsKeeper.Subspace(treasury.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(budget.ModuleName, mint.NewPermission(
+		[]string{mint.OperationMint, mint.OperationBurn, mint.OperationPool}, assets.TerraDenoms))
 	budgetKeeper := budget.NewKeeper(
-		cdc, keyBudget, marketKeeper, mintKeeper, treasuryKeeper, stakingKeeper.GetValidatorSet(),
+		cdc, keyBudget, marketKeeper, mintKeeper.ForModule(budget.ModuleName), treasuryKeeper, distrKeeper, stakingKeeper,
 		paramsKeeper.Subspace(budget.DefaultParamspace),
 	)
 
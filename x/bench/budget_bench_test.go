@@ -6,9 +6,15 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/x/budget"
 )
 
+var (
+	requestedAmount = sdk.NewInt64Coin(assets.MicroSDRDenom, 1000000)
+	totalCap        = sdk.NewInt64Coin(assets.MicroSDRDenom, 0)
+)
+
 func BenchmarkSubmitAndVoteProgramsPerBlock(b *testing.B) {
 	const numOfPrograms = 5
 	input := createTestInput()
@@ -25,7 +31,7 @@ func BenchmarkSubmitAndVoteProgramsPerBlock(b *testing.B) {
 
 		for p := 0; p < numOfPrograms; p++ {
 			// registers programs
-			msg := budget.NewMsgSubmitProgram(fmt.Sprintf("test-%d-%d", i, p), "description", addrs[p], addrs[p+1])
+			msg := budget.NewMsgSubmitProgram(fmt.Sprintf("test-%d-%d", i, p), "description", addrs[p], addrs[p+1], requestedAmount, totalCap, "")
 			res := h(ctx, msg)
 
 			if !res.IsOK() {
@@ -61,7 +67,7 @@ func BenchmarkSubmitAndWithdrawProgramPerBlock(b *testing.B) {
 			var msg sdk.Msg
 
 			if i%2 == 0 {
-				msg = budget.NewMsgSubmitProgram(fmt.Sprintf("test-%d-%d", i, v), "description", addrs[v], addrs[v])
+				msg = budget.NewMsgSubmitProgram(fmt.Sprintf("test-%d-%d", i, v), "description", addrs[v], addrs[v], requestedAmount, totalCap, "")
 			} else {
 				msg = budget.NewMsgWithdrawProgram(uint64((i/2)*numOfValidators+v+1), addrs[v])
 			}
@@ -37,6 +37,8 @@ func TestSubmitProgramTx(t *testing.T) {
 		`--title=testprogram`,
 		`--description=testprogramtestprogram`,
 		`--executor=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--requested-amount=1000000usdr`,
+		`--total-cap=12000000usdr`,
 		`--generate-only`,
 		`--offline`,
 		`--chain-id=columbus`,
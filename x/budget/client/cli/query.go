@@ -197,3 +197,51 @@ func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryTally implements the query tally command.
+func GetCmdQueryTally(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   budget.QueryTally,
+		Args:  cobra.NoArgs,
+		Short: "Query the live tally of a program",
+		Long: strings.TrimSpace(`
+Query the current tally of a program; its yes, no and net vote power, the total bonded power, 
+the threshold it is held to, and its projected share of the next epoch's budget pool.
+
+$ terracli query budget tally --program-id 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// validate that the program id is a uint
+			programIDStr := viper.GetString(flagProgramID)
+			if len(programIDStr) == 0 {
+				return fmt.Errorf("--program-id flag is required")
+			}
+
+			programID, err := strconv.ParseUint(programIDStr, 10, 64)
+			if err != nil {
+				return fmt.Errorf("given program-id %s not a valid format\n, program-id should be formatted as integer", programIDStr)
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%d", budget.QuerierRoute, budget.QueryTally, programID), nil)
+			if err != nil {
+				return err
+			}
+
+			var preview budget.TallyPreview
+			err = cdc.UnmarshalJSON(res, &preview)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(preview)
+		},
+	}
+
+	cmd.Flags().String(flagProgramID, "", "the program ID to tally")
+
+	cmd.MarkFlagRequired(flagProgramID)
+
+	return cmd
+}
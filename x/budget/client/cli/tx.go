@@ -29,18 +29,27 @@ const (
 	flagProgramID   = "program-id"
 	flagOption      = "option"
 	flagOffline     = "offline"
+	flagRequested   = "requested-amount"
+	flagTotalCap    = "total-cap"
+	flagPayoutDenom = "payout-denom"
 )
 
 type program struct {
-	Title       string
-	Description string
-	Executor    string
+	Title           string
+	Description     string
+	Executor        string
+	RequestedAmount string `json:"requested_amount"`
+	TotalCap        string `json:"total_cap"`
+	PayoutDenom     string `json:"payout_denom"`
 }
 
 var programFlags = []string{
 	flagTitle,
 	flagDescription,
 	flagExecutor,
+	flagRequested,
+	flagTotalCap,
+	flagPayoutDenom,
 }
 
 // GetCmdSubmitProgram implements submitting a program transaction command.
@@ -59,6 +68,9 @@ where program.json contains:
   "title": "Test program",
   "description": "My awesome program (include a website link for impact)",
   "executor": terra1nk5lsuvy0rcfjcdr8au8za0wq25rat0qa07p6t,
+  "requested_amount": "1000000000usdr",
+  "total_cap": "12000000000usdr",
+  "payout_denom": "ukrw"
 }
 
 is equivalent to
@@ -85,6 +97,20 @@ $ terracli budget submit-program --title="Test program" --description="My awesom
 				return err
 			}
 
+			requestedAmount, err := sdk.ParseCoin(program.RequestedAmount)
+			if err != nil {
+				return err
+			}
+
+			// The total cap is optional; an empty cap leaves the program uncapped
+			var totalCap sdk.Coin
+			if len(program.TotalCap) != 0 {
+				totalCap, err = sdk.ParseCoin(program.TotalCap)
+				if err != nil {
+					return err
+				}
+			}
+
 			offline := viper.GetBool(flagOffline)
 			if !offline {
 
@@ -120,7 +146,7 @@ $ terracli budget submit-program --title="Test program" --description="My awesom
 
 			}
 
-			msg := budget.NewMsgSubmitProgram(program.Title, program.Description, from, executorAddr)
+			msg := budget.NewMsgSubmitProgram(program.Title, program.Description, from, executorAddr, requestedAmount, totalCap, program.PayoutDenom)
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -133,6 +159,9 @@ $ terracli budget submit-program --title="Test program" --description="My awesom
 	cmd.Flags().String(flagTitle, "", "title of program")
 	cmd.Flags().String(flagDescription, "", "(optional) description of program")
 	cmd.Flags().String(flagExecutor, "", "executor of program")
+	cmd.Flags().String(flagRequested, "", "grant requested per distribution period, in usdr")
+	cmd.Flags().String(flagTotalCap, "", "(optional) cap on the cumulative grant, in usdr")
+	cmd.Flags().String(flagPayoutDenom, "", "(optional) stablecoin denom the grants are minted in, at oracle rates; usdr if empty")
 	cmd.Flags().String(flagProgram, "", "program file path (if this path is given, other program flags are ignored)")
 	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection it can build and sign tx")
 
@@ -158,6 +187,15 @@ func parseSubmitProgramFlags() (*program, error) {
 			return nil, fmt.Errorf("--%s flag is required", flagExecutor)
 		}
 
+		program.RequestedAmount = viper.GetString(flagRequested)
+		program.TotalCap = viper.GetString(flagTotalCap)
+		program.PayoutDenom = viper.GetString(flagPayoutDenom)
+
+		// Check requested amount existence
+		if len(program.RequestedAmount) == 0 {
+			return nil, fmt.Errorf("--%s flag is required", flagRequested)
+		}
+
 		return program, nil
 	}
 
@@ -305,3 +343,73 @@ $ terracli tx budget withdraw --program-id 1
 
 	return cmd
 }
+
+// GetCmdUpdateProgram implements amending a program transaction command.
+func GetCmdUpdateProgram(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-program",
+		Short: "Amend the title, description or executor of a program",
+		Long: strings.TrimSpace(`
+Amend a program you submitted. Only the given fields are changed, and the replaced values are kept in the 
+amendment history of the program. Changing the executor either clears the votes of the program or sends it 
+back to the candidate queue for a new voting period, depending on the executor change policy parameter.
+
+$ terracli tx budget update-program --program-id 1 --executor terra1... --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			// Get submitter address
+			from := cliCtx.GetFromAddress()
+
+			// validate that the program id is a uint
+			programStrID := viper.GetString(flagProgramID)
+			if programStrID == "" {
+				return fmt.Errorf("--program-id flag is required")
+			}
+
+			programID, err := strconv.ParseUint(programStrID, 10, 64)
+			if err != nil {
+				return fmt.Errorf("given program-id %s not a valid int, please input a valid program-id", programStrID)
+			}
+
+			var executor sdk.AccAddress
+			if executorStr := viper.GetString(flagExecutor); len(executorStr) != 0 {
+				executor, err = sdk.AccAddressFromBech32(executorStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			offline := viper.GetBool(flagOffline)
+
+			if !offline {
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+			}
+
+			// Build update message and run basic validation
+			msg := budget.NewMsgUpdateProgram(programID, from, viper.GetString(flagTitle), viper.GetString(flagDescription), executor)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.MarkFlagRequired(flagProgramID)
+
+	cmd.Flags().String(flagProgramID, "", "the program ID to update")
+	cmd.Flags().String(flagTitle, "", "new title of the program")
+	cmd.Flags().String(flagDescription, "", "new description of the program")
+	cmd.Flags().String(flagExecutor, "", "new executor of the program")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection it can build and sign tx")
+
+	return cmd
+}
@@ -30,6 +30,7 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 		cli.GetCmdQueryCandidates(mc.cdc),
 		cli.GetCmdQueryVotes(mc.cdc),
 		cli.GetCmdQueryParams(mc.cdc),
+		cli.GetCmdQueryTally(mc.cdc),
 	)...)
 
 	return budgetQueryCmd
@@ -46,6 +47,7 @@ func (mc ModuleClient) GetTxCmd() *cobra.Command {
 		cli.GetCmdSubmitProgram(mc.cdc),
 		cli.GetCmdWithdrawProgram(mc.cdc),
 		cli.GetCmdVote(mc.cdc),
+		cli.GetCmdUpdateProgram(mc.cdc),
 	)...)
 
 	return budgetTxCmd
@@ -19,12 +19,14 @@ var (
 		"params":         true,
 		"program":        true,
 		"votes":          true,
+		"tally":          true,
 	}
 
 	txCmdList = map[string]bool{
 		"withdraw":       true,
 		"vote":           true,
 		"submit-program": true,
+		"update-program": true,
 	}
 )
 
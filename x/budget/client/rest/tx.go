@@ -20,6 +20,7 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec
 	r.HandleFunc("/budget/programs/submit", submitProgramHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/budget/programs/{%s}/withdraw", RestProgramID), withdrawProgramHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/budget/programs/{%s}/votes", RestProgramID), voteHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/budget/programs/{%s}/update", RestProgramID), updateProgramHandlerFn(cdc, cliCtx)).Methods("POST")
 }
 
 type submitProgramReq struct {
@@ -27,6 +28,10 @@ type submitProgramReq struct {
 	Title       string         `json:"title"`       //  Title of the Program
 	Description string         `json:"description"` //  Description of the Program
 	Executor    sdk.AccAddress `json:"executor"`    //  Address of the executor
+
+	RequestedAmount sdk.Coin `json:"requested_amount"` //  Grant requested per distribution period, in TerraSDR
+	TotalCap        sdk.Coin `json:"total_cap"`        //  Optional cap on the cumulative grant, in TerraSDR
+	PayoutDenom     string   `json:"payout_denom"`     //  Optional denom to mint the grants in; TerraSDR if empty
 }
 
 type voteReq struct {
@@ -38,6 +43,13 @@ type withdrawReq struct {
 	BaseReq rest.BaseReq `json:"base_req"`
 }
 
+type updateProgramReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Title       string         `json:"title"`       //  New title of the Program; empty to keep
+	Description string         `json:"description"` //  New description of the Program; empty to keep
+	Executor    sdk.AccAddress `json:"executor"`    //  Address of the new executor; empty to keep
+}
+
 func submitProgramHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req submitProgramReq
@@ -82,7 +94,7 @@ func submitProgramHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 		}
 
 		// create the message
-		msg := budget.NewMsgSubmitProgram(req.Title, req.Description, fromAddress, req.Executor)
+		msg := budget.NewMsgSubmitProgram(req.Title, req.Description, fromAddress, req.Executor, req.RequestedAmount, req.TotalCap, req.PayoutDenom)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -180,3 +192,47 @@ func voteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc
 		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
+
+func updateProgramHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProgramID := vars[RestProgramID]
+
+		if len(strProgramID) == 0 {
+			err := errors.New("programID required but not specified")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		programID, ok := rest.ParseUint64OrReturnBadRequest(w, strProgramID)
+		if !ok {
+			return
+		}
+
+		var req updateProgramReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddress, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := budget.NewMsgUpdateProgram(programID, fromAddress, req.Title, req.Description, req.Executor)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -11,6 +11,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProgram{}, "budget/MsgSubmitProgram", nil)
 	cdc.RegisterConcrete(MsgWithdrawProgram{}, "budget/MsgWithdrawProgram", nil)
 	cdc.RegisterConcrete(MsgVoteProgram{}, "budget/MsgVoteProgram", nil)
+	cdc.RegisterConcrete(MsgUpdateProgram{}, "budget/MsgUpdateProgram", nil)
 
 	cdc.RegisterConcrete(&Program{}, "budget/Program", nil)
 }
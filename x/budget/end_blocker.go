@@ -3,7 +3,6 @@ package budget
 import (
 	"strconv"
 
-	"github.com/terra-project/core/types"
 	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/types/util"
 	"github.com/terra-project/core/x/budget/tags"
@@ -14,33 +13,135 @@ import (
 // Tally returns votePower = yesVotes minus NoVotes for program, as well as the total votes.
 // Power is denominated in validator bonded tokens (Luna stake size)
 func tally(ctx sdk.Context, k Keeper, targetProgramID uint64) (votePower sdk.Int, totalPower sdk.Int) {
-	votePower = sdk.ZeroInt()
-	totalPower = k.valset.TotalBondedTokens(ctx)
+	result := tallyProgram(ctx, k, targetProgramID)
+	return result.VotePower(), result.TotalPower
+}
 
-	targetProgramIDPrefix := keyVote(targetProgramID, sdk.AccAddress{})
-	k.IterateVotesWithPrefix(ctx, targetProgramIDPrefix, func(programID uint64, voter sdk.AccAddress, option bool) (stop bool) {
-		valAddr := sdk.ValAddress(voter)
+// clearsThreshold returns true if totalPower * threshold < votePower
+func clearsThreshold(votePower, totalPower sdk.Int, threshold sdk.Dec) bool {
+	return votePower.GTE(threshold.MulInt(totalPower).RoundInt())
+}
 
-		if validator := k.valset.Validator(ctx, valAddr); validator != nil {
-			bondSize := validator.GetBondedTokens()
-			if option {
-				votePower = votePower.Add(bondSize)
-			} else {
-				votePower = votePower.Sub(bondSize)
-			}
-		} else {
-			k.DeleteVote(ctx, targetProgramID, voter)
+// evaluateProgram tallies a program against the threshold of its state; candidates have to clear
+// the active threshold to become active, and active programs the legacy threshold to stay active.
+func evaluateProgram(ctx sdk.Context, k Keeper, params Params, program Program) (result TallyResult, threshold sdk.Dec, candidate bool) {
+	result = tallyProgram(ctx, k, program.ProgramID)
+	candidate = k.CandQueueHas(ctx, program.getVotingEndBlock(ctx, k), program.ProgramID)
+
+	threshold = params.LegacyThreshold
+	if candidate {
+		threshold = params.ActiveThreshold
+	}
+	return
+}
+
+// isVotePeriodLastBlock returns true if the current block ends a vote period, at which active programs
+// are re-weighted. Once the clock has started, vote periods follow block time, in whole days.
+func isVotePeriodLastBlock(ctx sdk.Context, k Keeper, params Params) bool {
+	if clock, found := k.mk.GetClock(ctx); found {
+		return clock.IsPeriodLastBlock(ctx, util.PeriodDays(params.VotePeriod))
+	}
+
+	return util.IsPeriodLastBlock(ctx, params.VotePeriod)
+}
+
+// isSnapshotOffsetBlock returns true if active programs are re-weighted SnapshotOffset blocks from now.
+// Once the clock has started, the offset is counted in whole days before the end of the vote period,
+// and is less than the period.
+func isSnapshotOffsetBlock(ctx sdk.Context, k Keeper, params Params) bool {
+	if clock, found := k.mk.GetClock(ctx); found {
+		periodDays := util.PeriodDays(params.VotePeriod)
+		offsetDays := params.SnapshotOffset / util.BlocksPerDay
+		if offsetDays > periodDays-1 {
+			offsetDays = periodDays - 1
 		}
 
+		return clock.IsDayLastBlock(ctx) && (clock.Day+1+offsetDays)%periodDays == 0
+	}
+
+	return (ctx.BlockHeight()+params.SnapshotOffset+1)%params.VotePeriod == 0
+}
+
+// snapshotBeforeDeadline refreshes the vote snapshots of the programs that are tallied SnapshotOffset
+// blocks from now; candidates whose voting period ends then, and active programs if they are re-weighted then.
+func snapshotBeforeDeadline(ctx sdk.Context, k Keeper, params Params) {
+	tallyBlock := ctx.BlockHeight() + params.SnapshotOffset
+
+	k.CandQueueIterateEndBlock(ctx, tallyBlock, func(programID uint64) (stop bool) {
+		snapshotProgramVotes(ctx, k, programID)
 		return false
 	})
 
-	return
+	if isSnapshotOffsetBlock(ctx, k, params) {
+		k.IteratePrograms(ctx, true, func(program Program) (stop bool) {
+			snapshotProgramVotes(ctx, k, program.ProgramID)
+			return false
+		})
+	}
 }
 
-// clearsThreshold returns true if totalPower * threshold < votePower
-func clearsThreshold(votePower, totalPower sdk.Int, threshold sdk.Dec) bool {
-	return votePower.GTE(threshold.MulInt(totalPower).RoundInt())
+// refreshActiveSnapshots snapshots the votes on a program that is active for the next vote period,
+// once it has been tallied. With snapshots taken when votes are cast, active programs are re-weighted
+// with the stake at the start of each vote period rather than the stake behind the original votes;
+// votes cast during the period keep their own snapshot.
+func refreshActiveSnapshots(ctx sdk.Context, k Keeper, params Params, programID uint64) {
+	if params.SnapshotPolicy == SnapshotAtVote {
+		snapshotProgramVotes(ctx, k, programID)
+	}
+}
+
+// nextPeriodLastBlock returns the first block from {height} on that ends a period of {period} blocks
+func nextPeriodLastBlock(height, period int64) int64 {
+	return height + (period - 1 - height%period)
+}
+
+// projectClaims returns the claim pool as it will stand at the next grant distribution, assuming
+// the votes do not change until then. Programs are only re-weighted if a vote period ends before
+// the epoch does, and candidates only claim if they become active by then.
+func projectClaims(ctx sdk.Context, k Keeper, params Params) map[uint64]sdk.Int {
+	claims := make(map[uint64]sdk.Int)
+	k.iterateClaimPool(ctx, func(programID uint64, weight sdk.Int) (stop bool) {
+		claims[programID] = weight
+		return false
+	})
+
+	// The end blocker of the current block has already run
+	height := ctx.BlockHeight() + 1
+	epochLastBlock := nextPeriodLastBlock(height, util.BlocksPerEpoch)
+	reweightBlock := nextPeriodLastBlock(height, params.VotePeriod)
+	if clock, found := k.mk.GetClock(ctx); found {
+		epochLastBlock = clock.EstimateEpochLastBlock(ctx)
+		reweightBlock = clock.EstimatePeriodLastBlock(ctx, util.PeriodDays(params.VotePeriod))
+	}
+
+	if reweightBlock > epochLastBlock {
+		return claims
+	}
+
+	k.IteratePrograms(ctx, false, func(program Program) (stop bool) {
+		result, threshold, candidate := evaluateProgram(ctx, k, params, program)
+		votePower := result.VotePower()
+
+		if candidate {
+			if program.getVotingEndBlock(ctx, k) > reweightBlock || !clearsThreshold(votePower, result.TotalPower, threshold) {
+				return false
+			}
+
+			threshold = params.LegacyThreshold
+		}
+
+		if clearsThreshold(votePower, result.TotalPower, threshold) {
+			if weight, ok := claims[program.ProgramID]; ok {
+				votePower = votePower.Add(weight)
+			}
+
+			claims[program.ProgramID] = votePower
+		}
+
+		return false
+	})
+
+	return claims
 }
 
 // EndBlocker is called at the end of every block
@@ -48,6 +149,10 @@ func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
 	params := k.GetParams(ctx)
 	resTags = sdk.EmptyTags()
 
+	if params.SnapshotPolicy == SnapshotBeforeDeadline {
+		snapshotBeforeDeadline(ctx, k, params)
+	}
+
 	k.CandQueueIterateExpired(ctx, ctx.BlockHeight(), func(programID uint64) (stop bool) {
 		program, err := k.GetProgram(ctx, programID)
 		if err != nil {
@@ -55,17 +160,47 @@ func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
 		}
 
 		// Did not pass the tally, delete program
-		votePower, totalPower := tally(ctx, k, programID)
+		result, threshold, _ := evaluateProgram(ctx, k, params, program)
+		votePower := result.VotePower()
 
-		if !clearsThreshold(votePower, totalPower, params.ActiveThreshold) {
+		if !clearsThreshold(votePower, result.TotalPower, threshold) {
 			k.DeleteVotesForProgram(ctx, programID)
 			k.DeleteProgram(ctx, programID)
-			resTags.AppendTag(tags.Action, tags.ActionProgramRejected)
+			resTags = resTags.AppendTag(tags.Action, tags.ActionProgramRejected)
+
+			if program.Deposit.IsPositive() {
+				// never return err, but handle err for lint
+				if err := k.forfeitDeposit(ctx, program.Deposit); err != nil {
+					panic(err)
+				}
+
+				resTags = resTags.AppendTags(sdk.NewTags(
+					tags.Action, tags.ActionDepositForfeited,
+					tags.Deposit, program.Deposit.String(),
+				))
+			}
 		} else {
-			resTags.AppendTag(tags.Action, tags.ActionProgramPassed)
+			resTags = resTags.AppendTag(tags.Action, tags.ActionProgramPassed)
+			refreshActiveSnapshots(ctx, k, params, programID)
+
+			if program.Deposit.IsPositive() {
+				// never return err, but handle err for lint
+				if err := k.RefundDeposit(ctx, program.Submitter, program.Deposit); err != nil {
+					panic(err)
+				}
+
+				resTags = resTags.AppendTags(sdk.NewTags(
+					tags.Action, tags.ActionDepositRefunded,
+					tags.Submitter, program.Submitter.String(),
+					tags.Deposit, program.Deposit.String(),
+				))
+
+				program.Deposit = sdk.NewCoin(program.Deposit.Denom, sdk.ZeroInt())
+				k.StoreProgram(ctx, program)
+			}
 		}
 
-		resTags.AppendTags(
+		resTags = resTags.AppendTags(
 			sdk.NewTags(
 				tags.ProgramID, strconv.FormatUint(programID, 10),
 				tags.Weight, votePower.String(),
@@ -77,25 +212,25 @@ func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
 	})
 
 	// Time to re-weight programs
-	if util.IsPeriodLastBlock(ctx, params.VotePeriod) {
-		claims := types.ClaimPool{}
-
+	if isVotePeriodLastBlock(ctx, k, params) {
 		// iterate programs and weight them
 		k.IteratePrograms(ctx, true, func(program Program) (stop bool) {
-			votePower, totalPower := tally(ctx, k, program.ProgramID)
+			result, threshold, _ := evaluateProgram(ctx, k, params, program)
+			votePower := result.VotePower()
 
 			// Need to check if the program should be legacied
-			if !clearsThreshold(votePower, totalPower, params.LegacyThreshold) {
+			if !clearsThreshold(votePower, result.TotalPower, threshold) {
 				// Delete all votes on target program
 				k.DeleteVotesForProgram(ctx, program.ProgramID)
 				k.DeleteProgram(ctx, program.ProgramID)
-				resTags.AppendTag(tags.Action, tags.ActionProgramLegacied)
+				resTags = resTags.AppendTag(tags.Action, tags.ActionProgramLegacied)
 			} else {
-				claims = append(claims, types.NewClaim(votePower, program.Executor))
-				resTags.AppendTag(tags.Action, tags.ActionProgramGranted)
+				k.addClaim(ctx, program.ProgramID, votePower)
+				resTags = resTags.AppendTag(tags.Action, tags.ActionProgramGranted)
+				refreshActiveSnapshots(ctx, k, params, program.ProgramID)
 			}
 
-			resTags.AppendTags(
+			resTags = resTags.AppendTags(
 				sdk.NewTags(
 					tags.ProgramID, strconv.FormatUint(program.ProgramID, 10),
 					tags.Weight, votePower.String(),
@@ -104,45 +239,180 @@ func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
 
 			return false
 		})
-
-		k.addClaimPool(ctx, claims)
 	}
 
 	// Time to distribute rewards to claims
-	if util.IsPeriodLastBlock(ctx, util.BlocksPerEpoch) {
-		epoch := util.GetEpoch(ctx)
-		rewardWeight := k.tk.GetRewardWeight(ctx, epoch)
-		seigniorage := k.mk.PeekEpochSeigniorage(ctx, epoch)
-		rewardPool := sdk.OneDec().Sub(rewardWeight).MulInt(seigniorage)
-
-		if rewardPool.GT(sdk.ZeroDec()) {
-			rewardPoolCoin, err := k.mrk.GetSwapDecCoin(ctx, sdk.NewDecCoinFromDec(assets.MicroLunaDenom, rewardPool), assets.MicroSDRDenom)
+	if k.mk.IsEpochLastBlock(ctx) {
+		grantTags, deferred := distributeGrants(ctx, k, params)
+		resTags = resTags.AppendTags(grantTags)
+
+		// Clear all claims, unless they are deferred to the next epoch
+		if !deferred {
+			k.clearClaimPool(ctx)
+		}
+	}
+	return
+}
+
+// distributeGrants pays each claiming program its share of the epoch's budget pool, weighted by
+// vote power and capped at the program's requested amount and remaining total cap. The rest of
+// the pool is handled according to the leftover policy. Without a TerraSDR rate no grant can be
+// priced, and the whole pool and the claims are deferred to the next epoch.
+func distributeGrants(ctx sdk.Context, k Keeper, params Params) (resTags sdk.Tags, deferred bool) {
+	resTags = sdk.EmptyTags()
+
+	epoch := k.mk.GetEpoch(ctx)
+	rewardWeight := k.tk.GetRewardWeight(ctx, epoch)
+	seigniorage := k.mk.PeekEpochSeigniorage(ctx, epoch)
+	rewardPool := sdk.OneDec().Sub(rewardWeight).MulInt(seigniorage).Add(k.GetCarryOver(ctx))
+	k.SetCarryOver(ctx, sdk.ZeroDec())
+
+	if !rewardPool.IsPositive() {
+		return payDeferredGrants(ctx, k, nil), false
+	}
+
+	rewardPoolCoin, err := k.mrk.GetSwapDecCoin(ctx, sdk.NewDecCoinFromDec(assets.MicroLunaDenom, rewardPool), assets.MicroSDRDenom)
+	if err != nil {
+		k.SetCarryOver(ctx, rewardPool)
+
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionGrantsDeferred,
+			tags.Amount, sdk.NewDecCoinFromDec(assets.MicroLunaDenom, rewardPool).String(),
+		))
+		return resTags, true
+	}
+
+	leftover := rewardPoolCoin
+
+	weightSum := sdk.ZeroInt()
+	k.iterateClaimPool(ctx, func(_ uint64, weight sdk.Int) (stop bool) {
+		weightSum = weightSum.Add(weight)
+		return false
+	})
+
+	claimed := make(map[uint64]bool)
+	if weightSum.IsPositive() {
+		k.iterateClaimPool(ctx, func(programID uint64, weight sdk.Int) (stop bool) {
+			program, err := k.GetProgram(ctx, programID)
 			if err != nil {
-				// No SDR swap rate exists
-				rewardPoolCoin = sdk.NewDecCoinFromDec(assets.MicroLunaDenom, rewardPool)
+				return false
 			}
 
-			weightSum := sdk.ZeroInt()
-			k.iterateClaimPool(ctx, func(_ sdk.AccAddress, weight sdk.Int) (stop bool) {
-				weightSum = weightSum.Add(weight)
-				return false
-			})
+			claimed[programID] = true
 
-			k.iterateClaimPool(ctx, func(recipient sdk.AccAddress, weight sdk.Int) (stop bool) {
-				rewardAmt := rewardPoolCoin.Amount.MulInt(weight).QuoInt(weightSum).TruncateInt()
+			grantAmt := rewardPoolCoin.Amount.MulInt(weight).QuoInt(weightSum).TruncateInt()
+			if remaining := program.remainingGrant(); grantAmt.GT(remaining) {
+				grantAmt = remaining
+			}
 
-				// never return err, but handle err for lint
-				err := k.mk.Mint(ctx, recipient, sdk.NewCoin(rewardPoolCoin.Denom, rewardAmt))
-				if err != nil {
-					panic(err)
-				}
+			if grantAmt.IsPositive() {
+				leftover.Amount = leftover.Amount.Sub(sdk.NewDecFromInt(grantAmt))
+			}
 
-				return false
-			})
+			resTags = resTags.AppendTags(payGrant(ctx, k, program, grantAmt))
+			return false
+		})
+	}
+
+	resTags = resTags.AppendTags(payDeferredGrants(ctx, k, claimed))
+
+	if !leftover.Amount.IsPositive() {
+		return
+	}
+
+	switch params.LeftoverPolicy {
+	case LeftoverCarryOver:
+		// The swap rate exists, as the pool was converted with it
+		lunaCoin, err := k.mrk.GetSwapDecCoin(ctx, leftover, assets.MicroLunaDenom)
+		if err != nil {
+			panic(err)
+		}
+
+		k.SetCarryOver(ctx, lunaCoin.Amount)
+	case LeftoverCommunityPool:
+		coin, _ := leftover.TruncateDecimal()
+		if err := k.addCommunityPool(ctx, coin); err != nil {
+			panic(err)
+		}
+	}
+
+	resTags = resTags.AppendTags(sdk.NewTags(
+		tags.Action, tags.ActionLeftover,
+		tags.LeftoverPolicy, params.LeftoverPolicy,
+		tags.Amount, leftover.String(),
+	))
+
+	return
+}
+
+// payDeferredGrants retries the grants deferred in previous epochs for the programs that were not
+// {claimed} in this one; they are owed even without a new claim.
+func payDeferredGrants(ctx sdk.Context, k Keeper, claimed map[uint64]bool) (resTags sdk.Tags) {
+	resTags = sdk.EmptyTags()
+	k.IteratePrograms(ctx, false, func(program Program) (stop bool) {
+		if !claimed[program.ProgramID] && program.Deferred.IsPositive() {
+			resTags = resTags.AppendTags(payGrant(ctx, k, program, sdk.ZeroInt()))
+		}
+		return false
+	})
+	return
+}
+
+// payGrant mints the grant of {grantAmt} TerraSDR, along with what was deferred before, to the
+// program's executor in its payout denom. If the oracle has no rate for the payout denom, the
+// whole amount is deferred to the next distribution. A program paid up to its total cap is completed,
+// and deleted along with its votes.
+func payGrant(ctx sdk.Context, k Keeper, program Program, grantAmt sdk.Int) (resTags sdk.Tags) {
+	resTags = sdk.EmptyTags()
+
+	owed := program.Deferred.Add(sdk.NewCoin(assets.MicroSDRDenom, grantAmt))
+	if !owed.IsPositive() {
+		return
+	}
+
+	payout := owed
+	if program.PayoutDenom != assets.MicroSDRDenom {
+		payoutDecCoin, err := k.mrk.GetSwapDecCoin(ctx, sdk.NewDecCoin(owed.Denom, owed.Amount), program.PayoutDenom)
+		if err != nil {
+			program.Deferred = owed
+			k.StoreProgram(ctx, program)
+
+			return sdk.NewTags(
+				tags.Action, tags.ActionGrantDeferred,
+				tags.ProgramID, strconv.FormatUint(program.ProgramID, 10),
+				tags.Amount, owed.String(),
+			)
 		}
 
-		// Clear all claims
-		k.clearClaimPool(ctx)
+		payout, _ = payoutDecCoin.TruncateDecimal()
 	}
+
+	if payout.IsPositive() {
+		// never return err, but handle err for lint
+		if err := k.mk.Mint(ctx, program.Executor, payout); err != nil {
+			panic(err)
+		}
+	}
+
+	program.Paid = program.Paid.Add(owed)
+	program.Deferred = sdk.NewCoin(assets.MicroSDRDenom, sdk.ZeroInt())
+	k.StoreProgram(ctx, program)
+
+	resTags = sdk.NewTags(
+		tags.Action, tags.ActionProgramPaid,
+		tags.ProgramID, strconv.FormatUint(program.ProgramID, 10),
+		tags.Amount, payout.String(),
+	)
+
+	if program.TotalCap.IsPositive() && !program.Paid.IsLT(program.TotalCap) {
+		k.DeleteVotesForProgram(ctx, program.ProgramID)
+		k.DeleteProgram(ctx, program.ProgramID)
+
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionProgramCompleted,
+			tags.ProgramID, strconv.FormatUint(program.ProgramID, 10),
+		))
+	}
+
 	return
 }
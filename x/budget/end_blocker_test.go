@@ -8,10 +8,13 @@ import (
 	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/types/mock"
 	"github.com/terra-project/core/types/util"
+	"github.com/terra-project/core/x/budget/tags"
+	"github.com/terra-project/core/x/mint"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
@@ -83,6 +86,190 @@ func TestEndBlockerTallyRandom(t *testing.T) {
 	require.Equal(t, actualVotePower, sdk.NewInt(int64(votePower)))
 }
 
+func TestEndBlockerTallyDelegatorOverride(t *testing.T) {
+	input := createTestInput(t)
+
+	testProgram := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, testProgram)
+
+	// Two delegators bond to validator 0; one follows it, the other votes against
+	follower := delegate(t, input, addrs[0], uLunaAmt)
+	dissenter := delegate(t, input, addrs[0], uLunaAmt)
+
+	h := NewHandler(input.budgetKeeper)
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, addrs[0])).IsOK())
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, addrs[1])).IsOK())
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, false, dissenter)).IsOK())
+
+	// Accounts that neither validate nor delegate cannot vote
+	outsider := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	require.False(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, outsider)).IsOK())
+
+	result := tallyProgram(input.ctx, input.budgetKeeper, testProgram.ProgramID)
+
+	// Validator 0 keeps its own and the follower's stake; the dissenter takes its share away
+	uLunaDec := sdk.NewDecFromInt(uLunaAmt)
+	require.Equal(t, uLunaDec.MulInt64(3), result.YesPower)
+	require.Equal(t, uLunaDec, result.NoPower)
+	require.Equal(t, uLunaAmt.MulRaw(2), result.VotePower())
+	require.Equal(t, uLunaAmt.MulRaw(5), result.TotalPower)
+
+	require.Equal(t, 2, len(result.Validators))
+	for _, vt := range result.Validators {
+		if vt.Validator.Equals(sdk.ValAddress(addrs[0])) {
+			require.Equal(t, uLunaDec, vt.DelegatorDeductions)
+			require.Equal(t, uLunaDec.MulInt64(2), vt.Power)
+		} else {
+			require.True(t, vt.DelegatorDeductions.IsZero())
+			require.Equal(t, uLunaDec, vt.Power)
+		}
+	}
+
+	require.Equal(t, 1, len(result.Delegators))
+	require.Equal(t, dissenter, result.Delegators[0].Delegator)
+	require.False(t, result.Delegators[0].Option)
+	require.Equal(t, uLunaDec, result.Delegators[0].Power)
+
+	// The follower votes too; the total is unchanged
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, follower)).IsOK())
+	votePower, _ := tally(input.ctx, input.budgetKeeper, testProgram.ProgramID)
+	require.Equal(t, uLunaAmt.MulRaw(2), votePower)
+}
+
+func TestEndBlockerSnapshotAtVote(t *testing.T) {
+	input := createTestInput(t)
+	h := NewHandler(input.budgetKeeper)
+	sh := staking.NewHandler(input.stakingKeeper)
+
+	testProgram := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, testProgram)
+
+	mover := delegate(t, input, addrs[2], uLunaAmt.MulRaw(2))
+
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, addrs[0])).IsOK())
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, false, addrs[1])).IsOK())
+
+	// Stake redelegated to the yes voter after it voted does not count
+	redelegate := staking.NewMsgBeginRedelegate(mover, sdk.ValAddress(addrs[2]), sdk.ValAddress(addrs[0]), sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(2)))
+	require.True(t, sh(input.ctx, redelegate).IsOK())
+
+	votePower, totalPower := tally(input.ctx, input.budgetKeeper, testProgram.ProgramID)
+	require.True(t, votePower.IsZero())
+	require.Equal(t, uLunaAmt.MulRaw(5), totalPower)
+
+	// Voting again takes a new snapshot
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, addrs[0])).IsOK())
+
+	votePower, _ = tally(input.ctx, input.budgetKeeper, testProgram.ProgramID)
+	require.Equal(t, uLunaAmt.MulRaw(2), votePower)
+
+	// Snapshots go away with the votes
+	input.budgetKeeper.DeleteVotesForProgram(input.ctx, testProgram.ProgramID)
+	_, found := input.budgetKeeper.GetVoteSnapshot(input.ctx, testProgram.ProgramID, addrs[0])
+	require.False(t, found)
+}
+
+func TestEndBlockerSnapshotRedelegateThenVote(t *testing.T) {
+	input := createTestInput(t)
+	h := NewHandler(input.budgetKeeper)
+	sh := staking.NewHandler(input.stakingKeeper)
+
+	testProgram := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, testProgram)
+
+	// A delegator votes, then redelegates to a validator that votes against
+	mover := delegate(t, input, addrs[2], uLunaAmt.MulRaw(2))
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, true, mover)).IsOK())
+
+	redelegate := staking.NewMsgBeginRedelegate(mover, sdk.ValAddress(addrs[2]), sdk.ValAddress(addrs[0]), sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(2)))
+	require.True(t, sh(input.ctx, redelegate).IsOK())
+
+	require.True(t, h(input.ctx, NewMsgVoteProgram(testProgram.ProgramID, false, addrs[0])).IsOK())
+
+	snapshot, found := input.budgetKeeper.GetVoteSnapshot(input.ctx, testProgram.ProgramID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, uLunaAmt.MulRaw(3), snapshot.Validator.BondedTokens)
+	require.Equal(t, 1, len(snapshot.VoterShares))
+	require.Equal(t, mover, snapshot.VoterShares[0].Voter)
+
+	// The delegator's stake is counted once, with its own vote
+	result := tallyProgram(input.ctx, input.budgetKeeper, testProgram.ProgramID)
+	uLunaDec := sdk.NewDecFromInt(uLunaAmt)
+	require.Equal(t, uLunaDec.MulInt64(2), result.YesPower)
+	require.Equal(t, uLunaDec, result.NoPower)
+	require.Equal(t, uLunaAmt, result.VotePower())
+	require.Equal(t, uLunaAmt.MulRaw(5), result.TotalPower)
+
+	// Stake bonded after the votes counts neither in the votes nor in the total
+	delegate(t, input, addrs[0], uLunaAmt.MulRaw(4))
+
+	result = tallyProgram(input.ctx, input.budgetKeeper, testProgram.ProgramID)
+	require.Equal(t, uLunaAmt, result.VotePower())
+	require.Equal(t, uLunaAmt.MulRaw(5), result.TotalPower)
+
+	// The votes on the active program are snapshotted again once it is re-weighted
+	params := input.budgetKeeper.GetParams(input.ctx)
+	ctx := input.ctx.WithBlockHeight(params.VotePeriod - 1)
+	EndBlocker(ctx, input.budgetKeeper)
+
+	snapshot, found = input.budgetKeeper.GetVoteSnapshot(ctx, testProgram.ProgramID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeight(), snapshot.Height)
+	require.Equal(t, uLunaAmt.MulRaw(7), snapshot.Validator.BondedTokens)
+
+	result = tallyProgram(ctx, input.budgetKeeper, testProgram.ProgramID)
+	require.Equal(t, uLunaAmt.MulRaw(-3), result.VotePower())
+	require.Equal(t, uLunaAmt.MulRaw(9), result.TotalPower)
+}
+
+func TestEndBlockerSnapshotBeforeDeadline(t *testing.T) {
+	input := createTestInput(t)
+	h := NewHandler(input.budgetKeeper)
+	sh := staking.NewHandler(input.stakingKeeper)
+
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.SnapshotPolicy = SnapshotBeforeDeadline
+	params.SnapshotOffset = 10
+	input.budgetKeeper.SetParams(input.ctx, params)
+
+	ctx := input.ctx.WithBlockHeight(1)
+	require.True(t, h(ctx, NewMsgSubmitProgram("test", "description", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, "")).IsOK())
+
+	program, err := input.budgetKeeper.GetProgram(ctx, 1)
+	require.Nil(t, err)
+	endBlock := program.getVotingEndBlock(ctx, input.budgetKeeper)
+
+	mover := delegate(t, input, addrs[2], uLunaAmt.MulRaw(2))
+
+	require.True(t, h(ctx, NewMsgVoteProgram(program.ProgramID, true, addrs[0])).IsOK())
+	require.True(t, h(ctx, NewMsgVoteProgram(program.ProgramID, false, addrs[1])).IsOK())
+
+	// Stake redelegated to the yes voter before the snapshot block counts
+	redelegate := staking.NewMsgBeginRedelegate(mover, sdk.ValAddress(addrs[2]), sdk.ValAddress(addrs[0]), sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(2)))
+	require.True(t, sh(ctx, redelegate).IsOK())
+
+	ctx = ctx.WithBlockHeight(endBlock - params.SnapshotOffset)
+	EndBlocker(ctx, input.budgetKeeper)
+
+	snapshot, found := input.budgetKeeper.GetVoteSnapshot(ctx, program.ProgramID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeight(), snapshot.Height)
+	require.Equal(t, uLunaAmt.MulRaw(3), snapshot.Validator.BondedTokens)
+
+	// Stake bonded to the no voter after the snapshot block counts neither in the votes nor in the total
+	delegate(t, input, addrs[1], uLunaAmt.MulRaw(4))
+
+	result := tallyProgram(ctx, input.budgetKeeper, program.ProgramID)
+	require.Equal(t, uLunaAmt.MulRaw(2), result.VotePower())
+	require.Equal(t, uLunaAmt.MulRaw(5), result.TotalPower)
+
+	// The program passes at the deadline
+	ctx = ctx.WithBlockHeight(endBlock)
+	resTags := EndBlocker(ctx, input.budgetKeeper)
+	require.Contains(t, actionTags(resTags), tags.ActionProgramPassed)
+	require.False(t, input.budgetKeeper.CandQueueHas(ctx, endBlock, program.ProgramID))
+}
+
 func TestEndBlockerTiming(t *testing.T) {
 	input := createTestInput(t)
 
@@ -110,13 +297,46 @@ func TestEndBlockerTiming(t *testing.T) {
 	claimCount = countClaimPool(input.ctx, input.budgetKeeper)
 	require.Equal(t, 1, claimCount)
 
-	input.budgetKeeper.iterateClaimPool(input.ctx, func(recipient sdk.AccAddress, weight sdk.Int) (stop bool) {
+	input.budgetKeeper.iterateClaimPool(input.ctx, func(programID uint64, weight sdk.Int) (stop bool) {
 		require.Equal(t, input.budgetKeeper.valset.TotalBondedTokens(input.ctx), weight)
 		return true
 	})
 
 }
 
+func TestEndBlockerTimingWithClock(t *testing.T) {
+	input := createTestInput(t)
+
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.VotePeriod = 2 * util.BlocksPerDay
+	input.budgetKeeper.SetParams(input.ctx, params)
+
+	// Start the clock; day 0 ends when the blocks left in it would have
+	start := time.Unix(util.SecondsPerDay, 0)
+	ctx := input.ctx.WithBlockHeight(1).WithBlockTime(start)
+	input.mintKeeper.SetParams(ctx, mint.NewParams(1))
+	mint.BeginBlocker(ctx, input.mintKeeper)
+	clock, found := input.mintKeeper.GetClock(ctx)
+	require.True(t, found)
+
+	testProgram := generateTestProgram(ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(ctx, testProgram)
+	for _, addr := range addrs {
+		input.budgetKeeper.AddVote(ctx, testProgram.ProgramID, addr, true)
+	}
+
+	// Day 0 ends a few blocks later; the vote period lasts two days
+	ctx = input.ctx.WithBlockHeight(2).WithBlockTime(time.Unix(clock.DayEnd, 0))
+	EndBlocker(ctx, input.budgetKeeper)
+	mint.EndBlocker(ctx, input.mintKeeper)
+	require.Equal(t, 0, countClaimPool(ctx, input.budgetKeeper))
+
+	// Programs are re-weighted at the end of day 1, well before VotePeriod blocks have passed
+	ctx = input.ctx.WithBlockHeight(3).WithBlockTime(time.Unix(clock.DayEnd+util.SecondsPerDay, 0))
+	EndBlocker(ctx, input.budgetKeeper)
+	require.Equal(t, 1, countClaimPool(ctx, input.budgetKeeper))
+}
+
 func TestEndBlockerClaimDistribution(t *testing.T) {
 	input := createTestInput(t)
 
@@ -144,11 +364,12 @@ func TestEndBlockerClaimDistribution(t *testing.T) {
 	claimCount = countClaimPool(input.ctx, input.budgetKeeper)
 	require.Equal(t, 1, claimCount)
 
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(1000)))
+	input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(1000)))
 
 	// after 5 week, distribution date reach
 	input.ctx = input.ctx.WithBlockHeight(util.BlocksPerEpoch*5 - 1)
 	input.treasuryKeeper.SetRewardWeight(input.ctx, sdk.NewDecWithPrec(1, 1))
+	input.oracleKeeper.SetLunaSwapRate(input.ctx, assets.MicroSDRDenom, sdk.OneDec())
 	EndBlocker(input.ctx, input.budgetKeeper)
 
 	claimCount = countClaimPool(input.ctx, input.budgetKeeper)
@@ -242,11 +463,199 @@ func TestEndBlockerPassOrReject(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestEndBlockerCappedGrants(t *testing.T) {
+	input := createTestInput(t)
+
+	// 1 Luna is worth 1 TerraSDR, and the pool only comes from carry over
+	input.oracleKeeper.SetLunaSwapRate(input.ctx, assets.MicroSDRDenom, sdk.OneDec())
+	input.treasuryKeeper.SetRewardWeight(input.ctx, sdk.OneDec())
+	pool := sdk.NewDec(1000 * assets.MicroUnit)
+
+	uncapped := generateTestProgram(input.ctx, input.budgetKeeper, addrs[0], addrs[1])
+	input.budgetKeeper.StoreProgram(input.ctx, uncapped)
+
+	capped := generateTestProgram(input.ctx, input.budgetKeeper, addrs[0], addrs[2])
+	capped.TotalCap = sdk.NewInt64Coin(assets.MicroSDRDenom, 150*assets.MicroUnit)
+	input.budgetKeeper.StoreProgram(input.ctx, capped)
+
+	balance1 := input.bankKeeper.GetCoins(input.ctx, addrs[1]).AmountOf(assets.MicroSDRDenom)
+	balance2 := input.bankKeeper.GetCoins(input.ctx, addrs[2]).AmountOf(assets.MicroSDRDenom)
+
+	// Equal weights would split the pool in half; grants are capped at the requested amount
+	// and the leftover is burned
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.LeftoverPolicy = LeftoverBurn
+	input.budgetKeeper.SetCarryOver(input.ctx, pool)
+	input.budgetKeeper.addClaim(input.ctx, uncapped.ProgramID, sdk.OneInt())
+	input.budgetKeeper.addClaim(input.ctx, capped.ProgramID, sdk.OneInt())
+	_, deferred := distributeGrants(input.ctx, input.budgetKeeper, params)
+	require.False(t, deferred)
+	input.budgetKeeper.clearClaimPool(input.ctx)
+
+	require.Equal(t, balance1.Add(testRequestedAmount.Amount), input.bankKeeper.GetCoins(input.ctx, addrs[1]).AmountOf(assets.MicroSDRDenom))
+	require.Equal(t, balance2.Add(testRequestedAmount.Amount), input.bankKeeper.GetCoins(input.ctx, addrs[2]).AmountOf(assets.MicroSDRDenom))
+	require.True(t, input.budgetKeeper.GetCarryOver(input.ctx).IsZero())
+
+	capped, err := input.budgetKeeper.GetProgram(input.ctx, capped.ProgramID)
+	require.Nil(t, err)
+	require.Equal(t, testRequestedAmount, capped.Paid)
+
+	// The capped program only receives what is left of its total cap; the leftover is carried over
+	input.budgetKeeper.AddVote(input.ctx, capped.ProgramID, addrs[0], true)
+	params.LeftoverPolicy = LeftoverCarryOver
+	input.budgetKeeper.SetCarryOver(input.ctx, pool)
+	input.budgetKeeper.addClaim(input.ctx, uncapped.ProgramID, sdk.OneInt())
+	input.budgetKeeper.addClaim(input.ctx, capped.ProgramID, sdk.OneInt())
+	resTags, deferred := distributeGrants(input.ctx, input.budgetKeeper, params)
+	require.False(t, deferred)
+	input.budgetKeeper.clearClaimPool(input.ctx)
+
+	require.Equal(t, balance2.Add(capped.TotalCap.Amount), input.bankKeeper.GetCoins(input.ctx, addrs[2]).AmountOf(assets.MicroSDRDenom))
+
+	// Paid up to its total cap, the program is completed along with its votes
+	require.Contains(t, actionTags(resTags), tags.ActionProgramCompleted)
+	_, err = input.budgetKeeper.GetProgram(input.ctx, capped.ProgramID)
+	require.NotNil(t, err)
+	_, err = input.budgetKeeper.GetVote(input.ctx, capped.ProgramID, addrs[0])
+	require.NotNil(t, err)
+
+	uncapped, err = input.budgetKeeper.GetProgram(input.ctx, uncapped.ProgramID)
+	require.Nil(t, err)
+	require.Equal(t, testRequestedAmount.Amount.MulRaw(2), uncapped.Paid.Amount)
+
+	require.Equal(t, sdk.NewDec(850*assets.MicroUnit), input.budgetKeeper.GetCarryOver(input.ctx))
+
+	// The completed program claims nothing more; the leftover goes to the community pool
+	params.LeftoverPolicy = LeftoverCommunityPool
+	input.budgetKeeper.addClaim(input.ctx, uncapped.ProgramID, sdk.OneInt())
+	_, deferred = distributeGrants(input.ctx, input.budgetKeeper, params)
+	require.False(t, deferred)
+	input.budgetKeeper.clearClaimPool(input.ctx)
+
+	require.Equal(t, balance2.Add(capped.TotalCap.Amount), input.bankKeeper.GetCoins(input.ctx, addrs[2]).AmountOf(assets.MicroSDRDenom))
+
+	communityPool := input.distrKeeper.GetFeePool(input.ctx).CommunityPool
+	require.Equal(t, sdk.NewDec(750*assets.MicroUnit), communityPool.AmountOf(assets.MicroSDRDenom))
+	require.True(t, input.budgetKeeper.GetCarryOver(input.ctx).IsZero())
+}
+
+func TestEndBlockerPayoutDenom(t *testing.T) {
+	input := createTestInput(t)
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.LeftoverPolicy = LeftoverBurn
+
+	// Without a TerraSDR rate, the pool and the claims are deferred
+	input.treasuryKeeper.SetRewardWeight(input.ctx, sdk.OneDec())
+	pool := sdk.NewDec(1000 * assets.MicroUnit)
+
+	program := generateTestProgram(input.ctx, input.budgetKeeper, addrs[0], addrs[1])
+	program.PayoutDenom = assets.MicroKRWDenom
+	input.budgetKeeper.StoreProgram(input.ctx, program)
+
+	input.budgetKeeper.SetCarryOver(input.ctx, pool)
+	input.budgetKeeper.addClaim(input.ctx, program.ProgramID, sdk.OneInt())
+	resTags, deferred := distributeGrants(input.ctx, input.budgetKeeper, params)
+	require.True(t, deferred)
+	require.True(t, pool.Equal(input.budgetKeeper.GetCarryOver(input.ctx)))
+	require.Contains(t, actionTags(resTags), tags.ActionGrantsDeferred)
+
+	// Without a rate for the payout denom, the program's grant is deferred
+	input.oracleKeeper.SetLunaSwapRate(input.ctx, assets.MicroSDRDenom, sdk.OneDec())
+	krwBalance := input.bankKeeper.GetCoins(input.ctx, addrs[1]).AmountOf(assets.MicroKRWDenom)
+
+	resTags, deferred = distributeGrants(input.ctx, input.budgetKeeper, params)
+	require.False(t, deferred)
+	require.Contains(t, actionTags(resTags), tags.ActionGrantDeferred)
+	input.budgetKeeper.clearClaimPool(input.ctx)
+
+	program, err := input.budgetKeeper.GetProgram(input.ctx, program.ProgramID)
+	require.Nil(t, err)
+	require.Equal(t, testRequestedAmount, program.Deferred)
+	require.True(t, program.Paid.IsZero())
+	require.Equal(t, krwBalance, input.bankKeeper.GetCoins(input.ctx, addrs[1]).AmountOf(assets.MicroKRWDenom))
+
+	// Once the rate is available, the deferred grant is minted in the payout denom, without a new claim
+	input.oracleKeeper.SetLunaSwapRate(input.ctx, assets.MicroKRWDenom, sdk.NewDec(1000))
+
+	resTags, deferred = distributeGrants(input.ctx, input.budgetKeeper, params)
+	require.False(t, deferred)
+	require.Contains(t, actionTags(resTags), tags.ActionProgramPaid)
+
+	program, err = input.budgetKeeper.GetProgram(input.ctx, program.ProgramID)
+	require.Nil(t, err)
+	require.True(t, program.Deferred.IsZero())
+	require.Equal(t, testRequestedAmount, program.Paid)
+	require.Equal(t, krwBalance.Add(testRequestedAmount.Amount.MulRaw(1000)), input.bankKeeper.GetCoins(input.ctx, addrs[1]).AmountOf(assets.MicroKRWDenom))
+}
+
+func TestEndBlockerDepositLifecycle(t *testing.T) {
+	input := createTestInput(t)
+	h := NewHandler(input.budgetKeeper)
+
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.RejectedDepositPolicy = DepositPolicyCommunityPool
+	input.budgetKeeper.SetParams(input.ctx, params)
+
+	ctx := input.ctx.WithBlockHeight(1)
+	balance := input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(params.Deposit.Denom)
+
+	// The deposit is taken on submission and held on the program
+	res := h(ctx, NewMsgSubmitProgram("passing", "description", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, ""))
+	require.True(t, res.IsOK())
+	res = h(ctx, NewMsgSubmitProgram("failing", "description", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, ""))
+	require.True(t, res.IsOK())
+
+	require.Equal(t, balance.Sub(params.Deposit.Amount.MulRaw(2)), input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(params.Deposit.Denom))
+
+	passing, err := input.budgetKeeper.GetProgram(ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, params.Deposit, passing.Deposit)
+
+	for _, addr := range addrs {
+		input.budgetKeeper.AddVote(ctx, passing.ProgramID, addr, true)
+	}
+
+	// The passing program's deposit is refunded, the failing one's goes to the community pool
+	ctx = ctx.WithBlockHeight(1 + params.VotePeriod)
+	resTags := EndBlocker(ctx, input.budgetKeeper)
+
+	require.Equal(t, balance.Sub(params.Deposit.Amount), input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(params.Deposit.Denom))
+
+	passing, err = input.budgetKeeper.GetProgram(ctx, passing.ProgramID)
+	require.Nil(t, err)
+	require.True(t, passing.Deposit.IsZero())
+
+	_, err = input.budgetKeeper.GetProgram(ctx, 2)
+	require.NotNil(t, err)
+
+	communityPool := input.distrKeeper.GetFeePool(ctx).CommunityPool
+	require.Equal(t, sdk.NewDecFromInt(params.Deposit.Amount), communityPool.AmountOf(params.Deposit.Denom))
+
+	actions := actionTags(resTags)
+	require.Contains(t, actions, tags.ActionDepositRefunded)
+	require.Contains(t, actions, tags.ActionDepositForfeited)
+
+	// Withdrawing the active program does not refund the deposit twice
+	res = h(ctx, NewMsgWithdrawProgram(passing.ProgramID, addrs[0]))
+	require.True(t, res.IsOK())
+	require.Equal(t, balance.Sub(params.Deposit.Amount), input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(params.Deposit.Denom))
+}
+
 func countClaimPool(ctx sdk.Context, keeper Keeper) (claimCount int) {
-	keeper.iterateClaimPool(ctx, func(recipient sdk.AccAddress, weight sdk.Int) (stop bool) {
+	keeper.iterateClaimPool(ctx, func(programID uint64, weight sdk.Int) (stop bool) {
 		claimCount++
 		return false
 	})
 
 	return claimCount
 }
+
+func actionTags(resTags sdk.Tags) (actions []string) {
+	for _, tag := range resTags {
+		if string(tag.Key) == tags.Action {
+			actions = append(actions, string(tag.Value))
+		}
+	}
+
+	return actions
+}
@@ -21,6 +21,11 @@ const (
 	CodeRefundFailed             sdk.CodeType = 7
 	CodeInvalidSubmitBlockHeight sdk.CodeType = 8
 	CodeDuplicateProgramID       sdk.CodeType = 9
+	CodeInvalidRequestedAmount   sdk.CodeType = 10
+	CodeInvalidTotalCap          sdk.CodeType = 11
+	CodeEmptyAmendment           sdk.CodeType = 12
+	CodeInvalidPayoutDenom       sdk.CodeType = 13
+	CodeInsufficientVoterStake   sdk.CodeType = 14
 )
 
 // nolint
@@ -62,10 +67,35 @@ func ErrRefundFailed(submitter sdk.AccAddress, programID uint64) sdk.Error {
 
 // nolint
 func ErrInvalidSubmitBlockHeight(submitBlock int64) sdk.Error {
-	return sdk.NewError(DefaultCodespace, CodeInvalidSubmitBlockHeight, fmt.Sprintf("Submit Block should be 0 at genesis not %d", submitBlock))
+	return sdk.NewError(DefaultCodespace, CodeInvalidSubmitBlockHeight, fmt.Sprintf("Submit Block should not be positive at genesis, is %d", submitBlock))
 }
 
 // nolint
 func ErrDuplicateProgramID(programID uint64) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeDuplicateProgramID, fmt.Sprintf("program ID is duplicated %d", programID))
 }
+
+// nolint
+func ErrInvalidRequestedAmount(amount sdk.Coin) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidRequestedAmount, fmt.Sprintf("Requested amount should be a positive amount of TerraSDR, is %s", amount))
+}
+
+// nolint
+func ErrInvalidTotalCap(totalCap sdk.Coin) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidTotalCap, fmt.Sprintf("Total cap should be zero or an amount of TerraSDR no less than the requested amount, is %s", totalCap))
+}
+
+// nolint
+func ErrEmptyAmendment(programID uint64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeEmptyAmendment, fmt.Sprintf("Update does not change program %d", programID))
+}
+
+// nolint
+func ErrInvalidPayoutDenom(denom string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidPayoutDenom, fmt.Sprintf("Payout denom should be a Terra stablecoin, is %s", denom))
+}
+
+// nolint
+func ErrInsufficientVoterStake(voter sdk.AccAddress, stake, minStake sdk.Int) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInsufficientVoterStake, fmt.Sprintf("%s has %s bonded, needs at least %s to vote", voter, stake, minStake))
+}
@@ -2,6 +2,9 @@ package budget
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+
+	"github.com/terra-project/core/types/util"
 )
 
 // expected mint keeper
@@ -9,6 +12,10 @@ type MintKeeper interface {
 	Mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) (err sdk.Error)
 	Burn(ctx sdk.Context, payer sdk.AccAddress, coin sdk.Coin) (err sdk.Error)
 	PeekEpochSeigniorage(ctx sdk.Context, epoch sdk.Int) (epochSeigniorage sdk.Int)
+	ChangeIssuance(ctx sdk.Context, denom string, delta sdk.Int) (err sdk.Error)
+	GetEpoch(ctx sdk.Context) sdk.Int
+	IsEpochLastBlock(ctx sdk.Context) bool
+	GetClock(ctx sdk.Context) (clock util.Clock, found bool)
 }
 
 // expected treasury keeper
@@ -21,3 +28,9 @@ type TreasuryKeeper interface {
 type MarketKeeper interface {
 	GetSwapDecCoin(ctx sdk.Context, offerCoin sdk.DecCoin, askDenom string) (sdk.DecCoin, sdk.Error)
 }
+
+// expected distribution keeper
+type DistributionKeeper interface {
+	GetFeePool(ctx sdk.Context) (feePool distrtypes.FeePool)
+	SetFeePool(ctx sdk.Context, feePool distrtypes.FeePool)
+}
@@ -1,6 +1,10 @@
 package budget
 
 import (
+	"fmt"
+
+	"github.com/terra-project/core/types/assets"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -11,17 +15,38 @@ type GenesisState struct {
 	ActivePrograms    Programs `json:"active_programs"`
 	CandidatePrograms Programs `json:"candidate_programs"`
 
-	Votes Votes `json:"votes"`
+	Votes     Votes         `json:"votes"`
+	Snapshots VoteSnapshots `json:"snapshots"` // stake behind the votes, for the votes that have a snapshot
+
+	CarryOver sdk.Dec `json:"carry_over"` // budget pool carried over to the next distribution, in Luna
+	Claims    []Claim `json:"claims"`     // claims on the next distribution
+}
+
+// Claim is the weight a program claims the next distribution with
+type Claim struct {
+	ProgramID uint64  `json:"program_id"`
+	Weight    sdk.Int `json:"weight"`
+}
+
+// NewClaim creates a new Claim
+func NewClaim(programID uint64, weight sdk.Int) Claim {
+	return Claim{
+		ProgramID: programID,
+		Weight:    weight,
+	}
 }
 
 func NewGenesisState(params Params, activePrograms,
-	candidatePrograms Programs, votes Votes) GenesisState {
+	candidatePrograms Programs, votes Votes, snapshots VoteSnapshots, carryOver sdk.Dec, claims []Claim) GenesisState {
 	return GenesisState{
 		Params: params,
 
 		ActivePrograms:    activePrograms,
 		CandidatePrograms: candidatePrograms,
 		Votes:             votes,
+		Snapshots:         snapshots,
+		CarryOver:         carryOver,
+		Claims:            claims,
 	}
 }
 
@@ -33,6 +58,9 @@ func DefaultGenesisState() GenesisState {
 		ActivePrograms:    Programs{},
 		CandidatePrograms: Programs{},
 		Votes:             Votes{},
+		Snapshots:         VoteSnapshots{},
+		CarryOver:         sdk.ZeroDec(),
+		Claims:            []Claim{},
 	}
 }
 
@@ -46,13 +74,22 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 
 	for _, program := range data.CandidatePrograms {
 		keeper.StoreProgram(ctx, program)
-		keeper.CandQueueInsert(ctx, data.Params.VotePeriod, program.ProgramID)
+		keeper.CandQueueInsert(ctx, program.getVotingEndBlock(ctx, keeper), program.ProgramID)
 	}
 
 	for _, vote := range data.Votes {
 		keeper.AddVote(ctx, vote.ProgramID, vote.Voter, vote.Option)
 	}
 
+	for _, snapshot := range data.Snapshots {
+		keeper.SetVoteSnapshot(ctx, snapshot)
+	}
+
+	keeper.SetCarryOver(ctx, data.CarryOver)
+
+	for _, claim := range data.Claims {
+		keeper.addClaim(ctx, claim.ProgramID, claim.Weight)
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper. The
@@ -83,7 +120,53 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
 		return false
 	})
 
-	return NewGenesisState(params, activePrograms, candidatePrograms, votes)
+	var snapshots VoteSnapshots
+	keeper.IterateVoteSnapshots(ctx, func(snapshot VoteSnapshot) (stop bool) {
+		snapshots = append(snapshots, snapshot)
+		return false
+	})
+
+	carryOver := keeper.GetCarryOver(ctx)
+
+	claims := []Claim{}
+	keeper.iterateClaimPool(ctx, func(programID uint64, weight sdk.Int) (stop bool) {
+		claims = append(claims, NewClaim(programID, weight))
+		return false
+	})
+
+	return NewGenesisState(params, activePrograms, candidatePrograms, votes, snapshots, carryOver, claims)
+}
+
+// PrepForZeroHeightGenesis settles the budget for a chain restarted from an export at height zero,
+// which starts on the next epoch. The claims of the current epoch are paid from its seigniorage so
+// far, and what cannot be paid is left in the carry-over. Without a TerraSDR rate the distribution is
+// deferred, and the claims are kept along with the pool. Submit heights are made relative to the
+// export, so that candidates keep the blocks left in their vote.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	params := keeper.GetParams(ctx)
+	if _, deferred := distributeGrants(ctx, keeper, params); !deferred {
+		keeper.clearClaimPool(ctx)
+	}
+
+	var programs Programs
+	keeper.IteratePrograms(ctx, false, func(program Program) (stop bool) {
+		programs = append(programs, program)
+		return false
+	})
+
+	height := ctx.BlockHeight()
+	for _, program := range programs {
+		endBlock := program.getVotingEndBlock(ctx, keeper)
+		candidate := keeper.CandQueueHas(ctx, endBlock, program.ProgramID)
+
+		program.SubmitBlock -= height
+		keeper.StoreProgram(ctx, program)
+
+		if candidate {
+			keeper.CandQueueRemove(ctx, endBlock, program.ProgramID)
+			keeper.CandQueueInsert(ctx, program.getVotingEndBlock(ctx, keeper), program.ProgramID)
+		}
+	}
 }
 
 // ValidateGenesis validates the provided oracle genesis state to ensure the
@@ -95,12 +178,16 @@ func ValidateGenesis(data GenesisState) error {
 		return err
 	}
 
-	var programMap map[uint64]bool
+	programMap := make(map[uint64]bool)
 	for _, program := range data.ActivePrograms {
-		if program.SubmitBlock != 0 {
+		if program.SubmitBlock > 0 {
 			return ErrInvalidSubmitBlockHeight(program.SubmitBlock)
 		}
 
+		if err := validateProgramFunding(program); err != nil {
+			return err
+		}
+
 		// duplicate program ID check
 		if _, ok := programMap[program.ProgramID]; ok {
 			return ErrDuplicateProgramID(program.ProgramID)
@@ -110,10 +197,14 @@ func ValidateGenesis(data GenesisState) error {
 	}
 
 	for _, program := range data.CandidatePrograms {
-		if program.SubmitBlock != 0 {
+		if program.SubmitBlock > 0 {
 			return ErrInvalidSubmitBlockHeight(program.SubmitBlock)
 		}
 
+		if err := validateProgramFunding(program); err != nil {
+			return err
+		}
+
 		// duplicate program ID check
 		if _, ok := programMap[program.ProgramID]; ok {
 			return ErrDuplicateProgramID(program.ProgramID)
@@ -122,11 +213,66 @@ func ValidateGenesis(data GenesisState) error {
 		programMap[program.ProgramID] = true
 	}
 
+	voteMap := make(map[string]bool)
 	for _, vote := range data.Votes {
 		if _, ok := programMap[vote.ProgramID]; !ok {
 			return ErrProgramNotFound(vote.ProgramID)
 		}
+
+		voteMap[string(keyVote(vote.ProgramID, vote.Voter))] = true
 	}
 
+	for _, snapshot := range data.Snapshots {
+		if _, ok := voteMap[string(keyVote(snapshot.ProgramID, snapshot.Voter))]; !ok {
+			return fmt.Errorf("snapshot of program %d for %s has no matching vote", snapshot.ProgramID, snapshot.Voter)
+		}
+	}
+
+	if data.CarryOver.IsNil() || data.CarryOver.IsNegative() {
+		return fmt.Errorf("budget carry over should be non-negative, is %s", data.CarryOver)
+	}
+
+	claimMap := make(map[uint64]bool)
+	for _, claim := range data.Claims {
+		if _, ok := programMap[claim.ProgramID]; !ok {
+			return ErrProgramNotFound(claim.ProgramID)
+		}
+
+		if claimMap[claim.ProgramID] {
+			return fmt.Errorf("duplicate claim of program %d", claim.ProgramID)
+		}
+		claimMap[claim.ProgramID] = true
+
+		if claim.Weight.IsNegative() {
+			return fmt.Errorf("claim of program %d should have a non-negative weight, is %s", claim.ProgramID, claim.Weight)
+		}
+	}
+
+	return nil
+}
+
+// validateProgramFunding checks the deposit, requested amount, total cap, paid amount and payout of a program
+func validateProgramFunding(program Program) error {
+	if program.Deposit.Denom != assets.MicroSDRDenom || program.Deposit.IsNegative() {
+		return fmt.Errorf("program %d deposit should be a non-negative amount of TerraSDR, is %s", program.ProgramID, program.Deposit)
+	}
+	if program.RequestedAmount.Denom != assets.MicroSDRDenom || !program.RequestedAmount.IsPositive() {
+		return ErrInvalidRequestedAmount(program.RequestedAmount)
+	}
+	if program.TotalCap.Denom != assets.MicroSDRDenom || program.TotalCap.IsNegative() {
+		return ErrInvalidTotalCap(program.TotalCap)
+	}
+	if program.Paid.Denom != assets.MicroSDRDenom || program.Paid.IsNegative() {
+		return fmt.Errorf("program %d paid amount should be a non-negative amount of TerraSDR, is %s", program.ProgramID, program.Paid)
+	}
+	if program.TotalCap.IsPositive() && !program.Paid.IsLT(program.TotalCap) {
+		return fmt.Errorf("program %d is paid up to its total cap %s and should be completed", program.ProgramID, program.TotalCap)
+	}
+	if program.Deferred.Denom != assets.MicroSDRDenom || program.Deferred.IsNegative() {
+		return fmt.Errorf("program %d deferred amount should be a non-negative amount of TerraSDR, is %s", program.ProgramID, program.Deferred)
+	}
+	if !assets.IsTerraDenom(program.PayoutDenom) {
+		return ErrInvalidPayoutDenom(program.PayoutDenom)
+	}
 	return nil
 }
@@ -0,0 +1,43 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepForZeroHeightGenesis(t *testing.T) {
+	input := createTestInput(t)
+	ctx := input.ctx.WithBlockHeight(100)
+
+	input.treasuryKeeper.SetRewardWeight(ctx, sdk.OneDec())
+	pool := sdk.NewDec(1000 * assets.MicroUnit)
+
+	program := generateTestProgram(ctx, input.budgetKeeper, addrs[0], addrs[1])
+	input.budgetKeeper.StoreProgram(ctx, program)
+
+	// Without a TerraSDR rate, the pool and the claims are kept for the restarted chain
+	input.budgetKeeper.SetCarryOver(ctx, pool)
+	input.budgetKeeper.addClaim(ctx, program.ProgramID, sdk.OneInt())
+	PrepForZeroHeightGenesis(ctx, input.budgetKeeper)
+
+	genesis := ExportGenesis(ctx, input.budgetKeeper)
+	require.Nil(t, ValidateGenesis(genesis))
+	require.True(t, pool.Equal(genesis.CarryOver))
+	require.Equal(t, []Claim{NewClaim(program.ProgramID, sdk.OneInt())}, genesis.Claims)
+
+	restarted := createTestInput(t)
+	InitGenesis(restarted.ctx, restarted.budgetKeeper, genesis)
+	require.Equal(t, 1, countClaimPool(restarted.ctx, restarted.budgetKeeper))
+
+	// With the rate, the claims are settled
+	input.oracleKeeper.SetLunaSwapRate(ctx, assets.MicroSDRDenom, sdk.OneDec())
+	PrepForZeroHeightGenesis(ctx, input.budgetKeeper)
+
+	genesis = ExportGenesis(ctx, input.budgetKeeper)
+	require.Nil(t, ValidateGenesis(genesis))
+	require.Empty(t, genesis.Claims)
+}
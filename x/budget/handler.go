@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/x/budget/tags"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,6 +21,8 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleMsgWithdrawProgram(ctx, k, msg)
 		case MsgVoteProgram:
 			return handleMsgVoteProgram(ctx, k, msg)
+		case MsgUpdateProgram:
+			return handleMsgUpdateProgram(ctx, k, msg)
 
 		default:
 			errMsg := "Unrecognized budget Msg type: " + reflect.TypeOf(msg).Name()
@@ -32,11 +35,21 @@ func NewHandler(k Keeper) sdk.Handler {
 func handleMsgSubmitProgram(ctx sdk.Context, k Keeper, msg MsgSubmitProgram) sdk.Result {
 
 	// Subtract coins from the submitter balance and updates it
-	depositErr := k.PayDeposit(ctx, msg.Submitter)
+	deposit, depositErr := k.PayDeposit(ctx, msg.Submitter)
 	if depositErr != nil {
 		return depositErr.Result()
 	}
 
+	totalCap := msg.TotalCap
+	if totalCap.Denom == "" {
+		totalCap = sdk.NewCoin(msg.RequestedAmount.Denom, sdk.ZeroInt())
+	}
+
+	payoutDenom := msg.PayoutDenom
+	if payoutDenom == "" {
+		payoutDenom = msg.RequestedAmount.Denom
+	}
+
 	// Create and add program
 	programID := k.NewProgramID(ctx)
 	program := NewProgram(
@@ -46,6 +59,10 @@ func handleMsgSubmitProgram(ctx sdk.Context, k Keeper, msg MsgSubmitProgram) sdk
 		msg.Submitter,
 		msg.Executor,
 		ctx.BlockHeight(),
+		deposit,
+		msg.RequestedAmount,
+		totalCap,
+		payoutDenom,
 	)
 
 	k.StoreProgram(ctx, program)
@@ -74,7 +91,11 @@ func handleMsgWithdrawProgram(ctx sdk.Context, k Keeper, msg MsgWithdrawProgram)
 	prgmEndBlock := program.getVotingEndBlock(ctx, k)
 	if k.CandQueueHas(ctx, prgmEndBlock, msg.ProgramID) {
 		k.CandQueueRemove(ctx, prgmEndBlock, msg.ProgramID)
-		err := k.RefundDeposit(ctx, program.Submitter)
+	}
+
+	// Refund the deposit if it is still held
+	if program.Deposit.IsPositive() {
+		err := k.RefundDeposit(ctx, program.Submitter, program.Deposit)
 
 		if err != nil {
 			return ErrRefundFailed(msg.Submitter, msg.ProgramID).Result()
@@ -101,13 +122,18 @@ func handleMsgVoteProgram(ctx sdk.Context, k Keeper, msg MsgVoteProgram) sdk.Res
 		return ErrProgramNotFound(msg.ProgramID).Result()
 	}
 
-	// Check the voter is a validator
-	val := k.valset.Validator(ctx, sdk.ValAddress(msg.Voter))
-	if val == nil {
+	// Check the voter is a validator or a delegator, with enough stake bonded that its vote is worth tallying
+	stake, staked := voterStake(ctx, k, msg.Voter)
+	if !staked {
 		return staking.ErrNoDelegatorForAddress(DefaultCodespace).Result()
 	}
 
+	if minStake := k.GetParams(ctx).MinVoterStake; stake.LT(minStake) {
+		return ErrInsufficientVoterStake(msg.Voter, stake, minStake).Result()
+	}
+
 	k.AddVote(ctx, msg.ProgramID, msg.Voter, msg.Option)
+	k.SetVoteSnapshot(ctx, snapshotVotePower(ctx, k, msg.ProgramID, msg.Voter))
 
 	return sdk.Result{
 		Tags: resTags.AppendTags(
@@ -119,3 +145,62 @@ func handleMsgVoteProgram(ctx sdk.Context, k Keeper, msg MsgVoteProgram) sdk.Res
 		),
 	}
 }
+
+// handleMsgUpdateProgram handles the logic of a MsgUpdateProgram
+func handleMsgUpdateProgram(ctx sdk.Context, k Keeper, msg MsgUpdateProgram) sdk.Result {
+	program, err := k.GetProgram(ctx, msg.ProgramID)
+	if err != nil {
+		return ErrProgramNotFound(msg.ProgramID).Result()
+	}
+
+	// Only submitters can update the program
+	if !program.Submitter.Equals(msg.Submitter) {
+		return ErrInvalidSubmitter(msg.Submitter).Result()
+	}
+
+	prevExecutor := program.Executor
+	if !program.amend(ctx.BlockHeight(), msg.Title, msg.Description, msg.Executor) {
+		return ErrEmptyAmendment(msg.ProgramID).Result()
+	}
+
+	resTags := sdk.NewTags(
+		tags.Action, tags.ActionProgramUpdated,
+		tags.ProgramID, strconv.FormatUint(msg.ProgramID, 10),
+	)
+
+	// Votes were cast, and grants deferred, for the previous executor
+	if !program.Executor.Equals(prevExecutor) {
+		program.Deferred = sdk.NewCoin(assets.MicroSDRDenom, sdk.ZeroInt())
+
+		switch k.GetParams(ctx).ExecutorChangePolicy {
+		case ExecutorChangeResetVotes:
+			// The program keeps its place, but the claim it holds was weighed with the cleared votes
+			k.deleteClaim(ctx, msg.ProgramID)
+			k.DeleteVotesForProgram(ctx, msg.ProgramID)
+			resTags = resTags.AppendTag(tags.Action, tags.ActionVotesReset)
+
+		case ExecutorChangeRevote:
+			// Restart the voting period from no votes; the program has to clear the active threshold again.
+			// A candidate leaves its place in the queue, and an active program drops its pending claim.
+			prgmEndBlock := program.getVotingEndBlock(ctx, k)
+			if k.CandQueueHas(ctx, prgmEndBlock, msg.ProgramID) {
+				k.CandQueueRemove(ctx, prgmEndBlock, msg.ProgramID)
+			} else {
+				k.deleteClaim(ctx, msg.ProgramID)
+			}
+
+			k.DeleteVotesForProgram(ctx, msg.ProgramID)
+			program.SubmitBlock = ctx.BlockHeight()
+			k.CandQueueInsert(ctx, program.getVotingEndBlock(ctx, k), msg.ProgramID)
+			resTags = resTags.AppendTag(tags.Action, tags.ActionProgramRevote)
+		}
+
+		resTags = resTags.AppendTag(tags.Executor, program.Executor.String())
+	}
+
+	k.StoreProgram(ctx, program)
+
+	return sdk.Result{
+		Tags: resTags,
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/terra-project/core/types/assets"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestHandlerMsgSubmitProgram(t *testing.T) {
@@ -12,7 +16,7 @@ func TestHandlerMsgSubmitProgram(t *testing.T) {
 	h := NewHandler(input.budgetKeeper)
 
 	// Regular submit msg passes
-	msg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1])
+	msg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, "")
 	res := h(input.ctx, msg)
 	require.True(t, res.IsOK())
 
@@ -25,7 +29,7 @@ func TestHandlerMsgWithdrawProgram(t *testing.T) {
 	h := NewHandler(input.budgetKeeper)
 
 	// Submit program
-	submitMsg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1])
+	submitMsg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, "")
 	res := h(input.ctx, submitMsg)
 	require.True(t, res.IsOK())
 
@@ -56,7 +60,7 @@ func TestHandlerMsgVoteCandidate(t *testing.T) {
 	h := NewHandler(input.budgetKeeper)
 
 	// Submit program
-	submitMsg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1])
+	submitMsg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, "")
 	res := h(input.ctx, submitMsg)
 	require.True(t, res.IsOK())
 
@@ -70,3 +74,154 @@ func TestHandlerMsgVoteCandidate(t *testing.T) {
 	res = h(input.ctx, voteMsg)
 	require.False(t, res.IsOK())
 }
+
+func TestHandlerMsgVoteMinVoterStake(t *testing.T) {
+	input := createTestInput(t)
+
+	h := NewHandler(input.budgetKeeper)
+
+	submitMsg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, "")
+	res := h(input.ctx, submitMsg)
+	require.True(t, res.IsOK())
+
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.MinVoterStake = uLunaAmt
+	input.budgetKeeper.SetParams(input.ctx, params)
+
+	// Delegators below the minimum stake cannot vote
+	small := delegate(t, input, addrs[0], uLunaAmt.SubRaw(1))
+	res = h(input.ctx, NewMsgVoteProgram(1, true, small))
+	require.Equal(t, CodeInsufficientVoterStake, res.Code)
+
+	// Delegators and validators with enough stake can
+	large := delegate(t, input, addrs[0], uLunaAmt)
+	res = h(input.ctx, NewMsgVoteProgram(1, true, large))
+	require.True(t, res.IsOK())
+
+	res = h(input.ctx, NewMsgVoteProgram(1, true, addrs[0]))
+	require.True(t, res.IsOK())
+}
+
+func TestHandlerMsgUpdateProgram(t *testing.T) {
+	input := createTestInput(t)
+
+	h := NewHandler(input.budgetKeeper)
+
+	// Submit program and make it active
+	submitMsg := NewMsgSubmitProgram("test", "testdescription", addrs[0], addrs[1], testRequestedAmount, sdk.Coin{}, "")
+	res := h(input.ctx, submitMsg)
+	require.True(t, res.IsOK())
+
+	program, err := input.budgetKeeper.GetProgram(input.ctx, 1)
+	require.Nil(t, err)
+	input.budgetKeeper.CandQueueRemove(input.ctx, program.getVotingEndBlock(input.ctx, input.budgetKeeper), 1)
+
+	voteMsg := NewMsgVoteProgram(1, true, addrs[0])
+	res = h(input.ctx, voteMsg)
+	require.True(t, res.IsOK())
+
+	// Updating from a different submitter address doesn't work
+	updateMsg := NewMsgUpdateProgram(1, addrs[2], "newtitle", "", nil)
+	res = h(input.ctx, updateMsg)
+	require.False(t, res.IsOK())
+
+	// Updating an unsubmitted program doesn't work
+	updateMsg = NewMsgUpdateProgram(4, addrs[0], "newtitle", "", nil)
+	res = h(input.ctx, updateMsg)
+	require.False(t, res.IsOK())
+
+	// An update that changes nothing doesn't work
+	updateMsg = NewMsgUpdateProgram(1, addrs[0], "test", "", addrs[1])
+	res = h(input.ctx, updateMsg)
+	require.False(t, res.IsOK())
+
+	// Changing the title keeps the votes and the active status
+	input.ctx = input.ctx.WithBlockHeight(input.ctx.BlockHeight() + 1)
+	updateMsg = NewMsgUpdateProgram(1, addrs[0], "newtitle", "", nil)
+	res = h(input.ctx, updateMsg)
+	require.True(t, res.IsOK())
+
+	program, err = input.budgetKeeper.GetProgram(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, "newtitle", program.Title)
+	require.Equal(t, []Amendment{NewAmendment(input.ctx.BlockHeight(), "test", "testdescription", addrs[1])}, program.Amendments)
+	require.False(t, input.budgetKeeper.CandQueueHas(input.ctx, program.getVotingEndBlock(input.ctx, input.budgetKeeper), 1))
+
+	_, err = input.budgetKeeper.GetVote(input.ctx, 1, addrs[0])
+	require.Nil(t, err)
+
+	// Changing the executor of an active program sends it back to the candidate queue, without its
+	// votes and its pending claim
+	input.budgetKeeper.addClaim(input.ctx, 1, sdk.OneInt())
+
+	updateMsg = NewMsgUpdateProgram(1, addrs[0], "", "", addrs[2])
+	res = h(input.ctx, updateMsg)
+	require.True(t, res.IsOK())
+
+	program, err = input.budgetKeeper.GetProgram(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, addrs[2], program.Executor)
+	require.Equal(t, input.ctx.BlockHeight(), program.SubmitBlock)
+	require.Equal(t, 2, len(program.Amendments))
+	require.Equal(t, addrs[1], program.Amendments[1].Executor)
+	require.True(t, input.budgetKeeper.CandQueueHas(input.ctx, program.getVotingEndBlock(input.ctx, input.budgetKeeper), 1))
+
+	_, err = input.budgetKeeper.GetVote(input.ctx, 1, addrs[0])
+	require.NotNil(t, err)
+
+	claimed := false
+	input.budgetKeeper.iterateClaimPool(input.ctx, func(programID uint64, weight sdk.Int) (stop bool) {
+		claimed = true
+		return true
+	})
+	require.False(t, claimed)
+
+	// Changing the executor of a candidate restarts its voting period, without its votes
+	res = h(input.ctx, NewMsgVoteProgram(1, true, addrs[0]))
+	require.True(t, res.IsOK())
+
+	prevEndBlock := program.getVotingEndBlock(input.ctx, input.budgetKeeper)
+	input.ctx = input.ctx.WithBlockHeight(input.ctx.BlockHeight() + 1)
+	updateMsg = NewMsgUpdateProgram(1, addrs[0], "", "", addrs[1])
+	res = h(input.ctx, updateMsg)
+	require.True(t, res.IsOK())
+
+	program, err = input.budgetKeeper.GetProgram(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, input.ctx.BlockHeight(), program.SubmitBlock)
+	require.Equal(t, 3, len(program.Amendments))
+	require.False(t, input.budgetKeeper.CandQueueHas(input.ctx, prevEndBlock, 1))
+	require.True(t, input.budgetKeeper.CandQueueHas(input.ctx, program.getVotingEndBlock(input.ctx, input.budgetKeeper), 1))
+
+	_, err = input.budgetKeeper.GetVote(input.ctx, 1, addrs[0])
+	require.NotNil(t, err)
+
+	// With the reset policy, changing the executor clears the votes instead
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.ExecutorChangePolicy = ExecutorChangeResetVotes
+	input.budgetKeeper.SetParams(input.ctx, params)
+
+	res = h(input.ctx, NewMsgVoteProgram(1, true, addrs[0]))
+	require.True(t, res.IsOK())
+
+	// along with the claim and the deferred grant of the previous executor
+	input.budgetKeeper.addClaim(input.ctx, 1, sdk.OneInt())
+	program, err = input.budgetKeeper.GetProgram(input.ctx, 1)
+	require.Nil(t, err)
+	program.Deferred = sdk.NewInt64Coin(assets.MicroSDRDenom, 10)
+	input.budgetKeeper.StoreProgram(input.ctx, program)
+
+	updateMsg = NewMsgUpdateProgram(1, addrs[0], "", "", addrs[2])
+	res = h(input.ctx, updateMsg)
+	require.True(t, res.IsOK())
+
+	_, err = input.budgetKeeper.GetVote(input.ctx, 1, addrs[0])
+	require.NotNil(t, err)
+	require.Equal(t, 0, countClaimPool(input.ctx, input.budgetKeeper))
+
+	program, err = input.budgetKeeper.GetProgram(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, addrs[2], program.Executor)
+	require.Equal(t, 4, len(program.Amendments))
+	require.True(t, program.Deferred.IsZero())
+}
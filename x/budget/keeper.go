@@ -4,7 +4,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/types/assets"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,13 +13,15 @@ import (
 
 // nolint
 type Keeper struct {
-	cdc    *codec.Codec     // Codec to encore/decode structs
-	key    sdk.StoreKey     // Key to our module's store
-	valset sdk.ValidatorSet // Needed to compute voting power.
-
-	mrk        MarketKeeper   // Needed to handle claims. This module only requires read swap rate between SDR and LUNA
-	mk         MintKeeper     // Needed to handle deposits. This module only requires read/writes to Terra balance and read seigniorage
-	tk         TreasuryKeeper // Needed to handle claims. This module only requires read current reward weight
+	cdc    *codec.Codec      // Codec to encore/decode structs
+	key    sdk.StoreKey      // Key to our module's store
+	valset sdk.ValidatorSet  // Needed to compute voting power.
+	ds     sdk.DelegationSet // Needed to compute the voting power of delegators.
+
+	mrk        MarketKeeper       // Needed to handle claims. This module only requires read swap rate between SDR and LUNA
+	mk         MintKeeper         // Needed to handle deposits. This module only requires read/writes to Terra balance and read seigniorage
+	tk         TreasuryKeeper     // Needed to handle claims. This module only requires read current reward weight
+	dk         DistributionKeeper // Needed to hand leftovers to the community pool
 	paramSpace params.Subspace
 }
 
@@ -29,7 +31,8 @@ func NewKeeper(cdc *codec.Codec,
 	mrk MarketKeeper,
 	mk MintKeeper,
 	tk TreasuryKeeper,
-	valset sdk.ValidatorSet,
+	dk DistributionKeeper,
+	ds sdk.DelegationSet,
 	paramspace params.Subspace) Keeper {
 	return Keeper{
 		cdc:        cdc,
@@ -37,7 +40,9 @@ func NewKeeper(cdc *codec.Codec,
 		mrk:        mrk,
 		mk:         mk,
 		tk:         tk,
-		valset:     valset,
+		dk:         dk,
+		valset:     ds.GetValidatorSet(),
+		ds:         ds,
 		paramSpace: paramspace.WithKeyTable(paramKeyTable()),
 	}
 }
@@ -63,19 +68,27 @@ func (k Keeper) AddVote(ctx sdk.Context, programID uint64, voter sdk.AccAddress,
 	store.Set(keyVote(programID, voter), bz)
 }
 
-// DeleteVote deletes the vote from the store
+// DeleteVote deletes the vote, and its snapshot, from the store
 func (k Keeper) DeleteVote(ctx sdk.Context, programID uint64, voter sdk.AccAddress) {
 	store := ctx.KVStore(k.key)
 	store.Delete(keyVote(programID, voter))
+	store.Delete(keyVoteSnapshot(programID, voter))
 }
 
-// DeleteVotesForProgram deletes the votes for the program from the store
+// DeleteVotesForProgram deletes the votes for the program, and their snapshots, from the store
 func (k Keeper) DeleteVotesForProgram(ctx sdk.Context, programID uint64) {
 	store := ctx.KVStore(k.key)
-	iter := sdk.KVStorePrefixIterator(store, keyVote(programID, sdk.AccAddress{}))
-	defer iter.Close()
-	for ; iter.Valid(); iter.Next() {
-		store.Delete(iter.Key())
+	var keys [][]byte
+	for _, prefix := range [][]byte{keyVote(programID, sdk.AccAddress{}), keyVoteSnapshot(programID, sdk.AccAddress{})} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		iter.Close()
+	}
+
+	for _, key := range keys {
+		store.Delete(key)
 	}
 }
 
@@ -112,23 +125,67 @@ func (k Keeper) IterateVotesWithPrefix(ctx sdk.Context, prefix []byte, handler f
 	}
 }
 
+//-----------------------------------
+// Vote snapshot logic
+
+// GetVoteSnapshot returns the snapshot of the stake behind a vote
+func (k Keeper) GetVoteSnapshot(ctx sdk.Context, programID uint64, voter sdk.AccAddress) (snapshot VoteSnapshot, found bool) {
+	store := ctx.KVStore(k.key)
+	if bz := store.Get(keyVoteSnapshot(programID, voter)); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &snapshot)
+		found = true
+	}
+	return
+}
+
+// SetVoteSnapshot stores the snapshot of the stake behind a vote
+func (k Keeper) SetVoteSnapshot(ctx sdk.Context, snapshot VoteSnapshot) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(snapshot)
+	store.Set(keyVoteSnapshot(snapshot.ProgramID, snapshot.Voter), bz)
+}
+
+// IterateVoteSnapshots iterates vote snapshots in the store
+func (k Keeper) IterateVoteSnapshots(ctx sdk.Context, handler func(VoteSnapshot) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefixSnapshot)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var snapshot VoteSnapshot
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &snapshot)
+
+		if handler(snapshot) {
+			break
+		}
+	}
+}
+
 //-----------------------------------
 // Deposit logic
 
-// PayDeposit pays the deposit by withdrawing from the submitter's balance.
-func (k Keeper) PayDeposit(ctx sdk.Context, submitter sdk.AccAddress) (err sdk.Error) {
-	deposit := k.GetParams(ctx).Deposit
+// PayDeposit pays the deposit by withdrawing from the submitter's balance, and returns the amount paid.
+func (k Keeper) PayDeposit(ctx sdk.Context, submitter sdk.AccAddress) (deposit sdk.Coin, err sdk.Error) {
+	deposit = k.GetParams(ctx).Deposit
 	err = k.mk.Burn(ctx, submitter, deposit)
 	return
 }
 
-// RefundDeposit refunds the deposit, by crediting the submitter's balance.
-func (k Keeper) RefundDeposit(ctx sdk.Context, submitter sdk.AccAddress) (err sdk.Error) {
-	deposit := k.GetParams(ctx).Deposit
+// RefundDeposit refunds the {deposit}, by crediting the submitter's balance.
+func (k Keeper) RefundDeposit(ctx sdk.Context, submitter sdk.AccAddress, deposit sdk.Coin) (err sdk.Error) {
 	err = k.mk.Mint(ctx, submitter, deposit)
 	return
 }
 
+// forfeitDeposit handles the {deposit} of a rejected program according to the rejected deposit policy.
+// The deposit was burned when it was paid, so only a community pool policy has anything left to do.
+func (k Keeper) forfeitDeposit(ctx sdk.Context, deposit sdk.Coin) (err sdk.Error) {
+	// Luna would have to be accounted for in the staking pool; it stays burned
+	if k.GetParams(ctx).RejectedDepositPolicy == DepositPolicyCommunityPool && deposit.Denom != assets.MicroLunaDenom {
+		err = k.addCommunityPool(ctx, deposit)
+	}
+	return
+}
+
 //-----------------------------------
 // Params logic
 
@@ -242,6 +299,21 @@ func (k Keeper) CandQueueIterateExpired(ctx sdk.Context, endBlock int64, handler
 	}
 }
 
+// CandQueueIterateEndBlock iterate all the Programs in the candidate queue whose voteperiod ends at endBlock
+func (k Keeper) CandQueueIterateEndBlock(ctx sdk.Context, endBlock int64, handler func(uint64) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefixCandQueueEndBlock(endBlock))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var programID uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &programID)
+
+		if handler(programID) {
+			break
+		}
+	}
+}
+
 // CandQueueInsert Inserts a ProgramID into the Candidate Program queue at endTime
 func (k Keeper) CandQueueInsert(ctx sdk.Context, endBlock int64, programID uint64) {
 	store := ctx.KVStore(k.key)
@@ -265,47 +337,86 @@ func (k Keeper) CandQueueRemove(ctx sdk.Context, endBlock int64, programID uint6
 //-----------------------------------
 // Claim pool logic
 
-// Iterate over oracle reward claims in the store
-func (k Keeper) iterateClaimPool(ctx sdk.Context, handler func(recipient sdk.AccAddress, weight sdk.Int) (stop bool)) {
+// Iterate over program claims in the store
+func (k Keeper) iterateClaimPool(ctx sdk.Context, handler func(programID uint64, weight sdk.Int) (stop bool)) {
 	store := ctx.KVStore(k.key)
 	iter := sdk.KVStorePrefixIterator(store, prefixClaim)
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
-		recipientAddress := strings.Split(string(iter.Key()), ":")[1]
-		recipient, _ := sdk.AccAddressFromBech32(recipientAddress)
+		programID, err := strconv.ParseUint(strings.Split(string(iter.Key()), ":")[1], 10, 0)
+		if err != nil {
+			continue
+		}
 
 		var weight sdk.Int
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &weight)
-		if handler(recipient, weight) {
+		if handler(programID, weight) {
 			break
 		}
 	}
 }
 
-// addClaimPool adds a claim to the the claim pool in the store
-func (k Keeper) addClaimPool(ctx sdk.Context, pool types.ClaimPool) {
+// addClaim adds the weight of a program's claim to the claim pool in the store
+func (k Keeper) addClaim(ctx sdk.Context, programID uint64, weight sdk.Int) {
 	store := ctx.KVStore(k.key)
 
-	for _, claim := range pool {
-		storeKeyClaim := keyClaim(claim.Recipient)
-		b := store.Get(storeKeyClaim)
-		weight := claim.Weight
-		if b != nil {
-			var prevWeight sdk.Int
-			k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &prevWeight)
+	storeKeyClaim := keyClaim(programID)
+	if b := store.Get(storeKeyClaim); b != nil {
+		var prevWeight sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &prevWeight)
 
-			weight = weight.Add(prevWeight)
-		}
-		b = k.cdc.MustMarshalBinaryLengthPrefixed(weight)
-		store.Set(storeKeyClaim, b)
+		weight = weight.Add(prevWeight)
 	}
+
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(weight)
+	store.Set(storeKeyClaim, b)
+}
+
+// deleteClaim removes a program's claim from the claim pool in the store
+func (k Keeper) deleteClaim(ctx sdk.Context, programID uint64) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyClaim(programID))
 }
 
 // clearClaimPool clears the claim pool from the store
 func (k Keeper) clearClaimPool(ctx sdk.Context) {
-	store := ctx.KVStore(k.key)
-	k.iterateClaimPool(ctx, func(recipient sdk.AccAddress, weight sdk.Int) (stop bool) {
-		store.Delete(keyClaim(recipient))
+	var programIDs []uint64
+	k.iterateClaimPool(ctx, func(programID uint64, weight sdk.Int) (stop bool) {
+		programIDs = append(programIDs, programID)
 		return false
 	})
+
+	for _, programID := range programIDs {
+		k.deleteClaim(ctx, programID)
+	}
+}
+
+//-----------------------------------
+// Leftover logic
+
+// GetCarryOver returns the budget pool carried over from previous distributions, in Luna
+func (k Keeper) GetCarryOver(ctx sdk.Context) (carryOver sdk.Dec) {
+	store := ctx.KVStore(k.key)
+	if bz := store.Get(keyCarryOver); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &carryOver)
+	} else {
+		carryOver = sdk.ZeroDec()
+	}
+	return
+}
+
+// SetCarryOver stores the budget pool to be carried over to the next distribution, in Luna
+func (k Keeper) SetCarryOver(ctx sdk.Context, carryOver sdk.Dec) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(carryOver)
+	store.Set(keyCarryOver, bz)
+}
+
+// addCommunityPool mints {coin} into the distribution community pool
+func (k Keeper) addCommunityPool(ctx sdk.Context, coin sdk.Coin) sdk.Error {
+	feePool := k.dk.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(sdk.NewDecCoins(sdk.Coins{coin}))
+	k.dk.SetFeePool(ctx, feePool)
+
+	return k.mk.ChangeIssuance(ctx, coin.Denom, coin.Amount)
 }
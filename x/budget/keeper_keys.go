@@ -11,11 +11,13 @@ import (
 var (
 	keyDelimiter     = []byte(":")
 	keyNextProgramID = []byte("new-program-id")
+	keyCarryOver     = []byte("carry-over")
 
 	prefixProgram   = []byte("program")
 	prefixVote      = []byte("vote")
 	prefixCandQueue = []byte("candidate-queue")
 	prefixClaim     = []byte("claim")
+	prefixSnapshot  = []byte("snapshot")
 
 	paramStoreKeyParams = []byte("params")
 )
@@ -28,6 +30,10 @@ func keyVote(programID uint64, voterAddr sdk.AccAddress) []byte {
 	return []byte(fmt.Sprintf("%s:%d:%s", prefixVote, programID, voterAddr))
 }
 
+func keyVoteSnapshot(programID uint64, voterAddr sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("%s:%d:%s", prefixSnapshot, programID, voterAddr))
+}
+
 func prefixCandQueueEndBlock(endBlock int64) []byte {
 	return []byte(fmt.Sprintf("%s:%020d", prefixCandQueue, endBlock))
 }
@@ -36,8 +42,8 @@ func keyCandidate(endBlock int64, programID uint64) []byte {
 	return []byte(fmt.Sprintf("%s:%020d:%d", prefixCandQueue, endBlock, programID))
 }
 
-func keyClaim(recipient sdk.AccAddress) []byte {
-	return []byte(fmt.Sprintf("%s:%s", prefixClaim, recipient))
+func keyClaim(programID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", prefixClaim, programID))
 }
 
 func paramKeyTable() params.KeyTable {
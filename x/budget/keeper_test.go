@@ -8,7 +8,6 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
-	"github.com/terra-project/core/types"
 )
 
 func TestKeeperProgramID(t *testing.T) {
@@ -31,15 +30,16 @@ func TestKeeperDeposit(t *testing.T) {
 	require.Nil(t, err)
 
 	// addr0 has enough coins to pay the deposit
-	err = input.budgetKeeper.PayDeposit(input.ctx, addrs[0])
+	paid, err := input.budgetKeeper.PayDeposit(input.ctx, addrs[0])
 	require.Nil(t, err)
+	require.Equal(t, deposit[0], paid)
 
 	// Doesn't have enough coins to pay the deposit
-	err = input.budgetKeeper.PayDeposit(input.ctx, addrs[0])
+	_, err = input.budgetKeeper.PayDeposit(input.ctx, addrs[0])
 	require.NotNil(t, err)
 
 	// Refund works
-	err = input.budgetKeeper.RefundDeposit(input.ctx, addrs[0])
+	err = input.budgetKeeper.RefundDeposit(input.ctx, addrs[0], paid)
 	require.Nil(t, err)
 
 	// After refund, addr0's balance equals the deposit he paid previously
@@ -74,7 +74,7 @@ func TestKeeperProgram(t *testing.T) {
 	numTests := rand.Int() % maxTests
 	for i := 0; i < numTests; i++ {
 		programID := uint64(rand.Int63() % int64(idCeiling))
-		testProgram := NewProgram(programID, "", "", addrs[0], addrs[1], 0)
+		testProgram := NewProgram(programID, "", "", addrs[0], addrs[1], 0, input.budgetKeeper.GetParams(input.ctx).Deposit, testRequestedAmount, testRequestedAmount, testRequestedAmount.Denom)
 		action := rand.Int() % 2
 		if action == 0 {
 			programBitmap[programID] = true
@@ -219,25 +219,23 @@ func TestKeeperCandidateQueue(t *testing.T) {
 func TestKeeperClaimPool(t *testing.T) {
 	input := createTestInput(t)
 
-	// Test addClaimPool
-	claim := types.NewClaim(sdk.NewInt(10), addrs[0])
-	claim2 := types.NewClaim(sdk.NewInt(20), addrs[1])
-	claimPool := types.ClaimPool{claim, claim2}
-	input.budgetKeeper.addClaimPool(input.ctx, claimPool)
-
-	claim = types.NewClaim(sdk.NewInt(15), addrs[0])
-	claim2 = types.NewClaim(sdk.NewInt(30), addrs[2])
-	claimPool = types.ClaimPool{claim, claim2}
-	input.budgetKeeper.addClaimPool(input.ctx, claimPool)
+	// Test addClaim
+	input.budgetKeeper.addClaim(input.ctx, 1, sdk.NewInt(10))
+	input.budgetKeeper.addClaim(input.ctx, 2, sdk.NewInt(20))
+	input.budgetKeeper.addClaim(input.ctx, 1, sdk.NewInt(15))
+	input.budgetKeeper.addClaim(input.ctx, 3, sdk.NewInt(30))
 
 	// Test iterateClaimPool
-	input.budgetKeeper.iterateClaimPool(input.ctx, func(recipient sdk.AccAddress, weight sdk.Int) (stop bool) {
-		if recipient.Equals(addrs[0]) {
+	input.budgetKeeper.iterateClaimPool(input.ctx, func(programID uint64, weight sdk.Int) (stop bool) {
+		switch programID {
+		case 1:
 			require.Equal(t, sdk.NewInt(25), weight)
-		} else if recipient.Equals(addrs[1]) {
+		case 2:
 			require.Equal(t, sdk.NewInt(20), weight)
-		} else if recipient.Equals(addrs[2]) {
+		case 3:
 			require.Equal(t, sdk.NewInt(30), weight)
+		default:
+			t.Fatalf("unexpected claim for program %d", programID)
 		}
 		return false
 	})
@@ -0,0 +1,109 @@
+package budget
+
+import (
+	"encoding/json"
+
+	"github.com/terra-project/core/types/module"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+var (
+	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule      = AppModule{}
+)
+
+// moduleCdc encodes the genesis of the budget module
+var moduleCdc = codec.New()
+
+func init() {
+	RegisterCodec(moduleCdc)
+	codec.RegisterCrypto(moduleCdc)
+}
+
+// AppModuleBasic is the app module basics of the budget module
+type AppModuleBasic struct{}
+
+// Name returns the name of the module
+func (AppModuleBasic) Name() string {
+	return ModuleName
+}
+
+// RegisterCodec registers the types of the module
+func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
+	RegisterCodec(cdc)
+}
+
+// ValidateGenesis validates the genesis of the module
+func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return ValidateGenesis(data)
+}
+
+// AppModule is the app module of the budget module
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper) AppModule {
+	return AppModule{
+		AppModuleBasic: AppModuleBasic{},
+		keeper:         keeper,
+	}
+}
+
+// RegisterInvariants registers the invariants of the module; it has none
+func (AppModule) RegisterInvariants(_ *crisis.Keeper) {}
+
+// Route returns the message route of the module
+func (AppModule) Route() string {
+	return RouterKey
+}
+
+// NewHandler returns the message handler of the module
+func (am AppModule) NewHandler() sdk.Handler {
+	return NewHandler(am.keeper)
+}
+
+// QuerierRoute returns the query route of the module
+func (AppModule) QuerierRoute() string {
+	return QuerierRoute
+}
+
+// NewQuerierHandler returns the querier of the module
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return NewQuerier(am.keeper)
+}
+
+// InitGenesis inits the genesis of the module
+func (am AppModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the genesis of the module
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock does nothing; the module has no begin blocker
+func (AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	return sdk.EmptyTags()
+}
+
+// EndBlock runs the end blocker of the module
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) ([]abci.ValidatorUpdate, sdk.Tags) {
+	return nil, EndBlocker(ctx, am.keeper)
+}
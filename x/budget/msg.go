@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/terra-project/core/types/assets"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -13,16 +15,24 @@ type MsgSubmitProgram struct {
 	Description string         `json:"description"` // Description of the Program
 	Submitter   sdk.AccAddress `json:"submitter"`   // Address of the submitter
 	Executor    sdk.AccAddress `json:"executor"`    // Address of the executor
+
+	RequestedAmount sdk.Coin `json:"requested_amount"` // Grant requested per distribution period, in TerraSDR
+	TotalCap        sdk.Coin `json:"total_cap"`        // Optional cap on the cumulative grant, in TerraSDR
+	PayoutDenom     string   `json:"payout_denom"`     // Optional denom to mint the grants in; TerraSDR if empty
 }
 
 // NewMsgSubmitProgram submits a message with a new Program
 func NewMsgSubmitProgram(title string, description string,
-	submitter sdk.AccAddress, executor sdk.AccAddress) MsgSubmitProgram {
+	submitter sdk.AccAddress, executor sdk.AccAddress,
+	requestedAmount sdk.Coin, totalCap sdk.Coin, payoutDenom string) MsgSubmitProgram {
 	return MsgSubmitProgram{
-		Title:       title,
-		Description: description,
-		Submitter:   submitter,
-		Executor:    executor,
+		Title:           title,
+		Description:     description,
+		Submitter:       submitter,
+		Executor:        executor,
+		RequestedAmount: requestedAmount,
+		TotalCap:        totalCap,
+		PayoutDenom:     payoutDenom,
 	}
 }
 
@@ -56,6 +66,24 @@ func (msg MsgSubmitProgram) ValidateBasic() sdk.Error {
 	if len(strings.TrimSpace(msg.Description)) <= 0 {
 		return ErrInvalidDescription()
 	}
+	if msg.RequestedAmount.Denom != assets.MicroSDRDenom || !msg.RequestedAmount.IsPositive() {
+		return ErrInvalidRequestedAmount(msg.RequestedAmount)
+	}
+
+	// An empty total cap leaves the program uncapped
+	if msg.TotalCap.Denom != "" {
+		if msg.TotalCap.Denom != assets.MicroSDRDenom || msg.TotalCap.IsNegative() {
+			return ErrInvalidTotalCap(msg.TotalCap)
+		}
+		if msg.TotalCap.IsPositive() && msg.TotalCap.IsLT(msg.RequestedAmount) {
+			return ErrInvalidTotalCap(msg.TotalCap)
+		}
+	}
+
+	// Grants are paid in Terra stablecoins
+	if len(msg.PayoutDenom) != 0 && !assets.IsTerraDenom(msg.PayoutDenom) {
+		return ErrInvalidPayoutDenom(msg.PayoutDenom)
+	}
 
 	return nil
 }
@@ -65,7 +93,10 @@ func (msg MsgSubmitProgram) String() string {
 	return fmt.Sprintf(`MsgSubmitProgram
 	Title: %v
 	Submitter: %v
-	Executor: %v`, msg.Title, msg.Submitter, msg.Executor)
+	Executor: %v
+	RequestedAmount: %v
+	TotalCap: %v
+	PayoutDenom: %v`, msg.Title, msg.Submitter, msg.Executor, msg.RequestedAmount, msg.TotalCap, msg.PayoutDenom)
 }
 
 //--------------------------------------------------------
@@ -172,3 +203,73 @@ func (msg MsgVoteProgram) String() string {
 	Voter: %v
 	Option: %v`, msg.ProgramID, msg.Voter, msg.Option)
 }
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgUpdateProgram defines the msg of a submitter amending its Program.
+// Empty fields are left unchanged.
+type MsgUpdateProgram struct {
+	ProgramID   uint64         `json:"program_id"`  // ID of the Program
+	Submitter   sdk.AccAddress `json:"submitter"`   // Address of the submitter
+	Title       string         `json:"title"`       // New title of the Program
+	Description string         `json:"description"` // New description of the Program
+	Executor    sdk.AccAddress `json:"executor"`    // Address of the new executor
+}
+
+// NewMsgUpdateProgram creates a MsgUpdateProgram instance
+func NewMsgUpdateProgram(programID uint64, submitter sdk.AccAddress, title string, description string, executor sdk.AccAddress) MsgUpdateProgram {
+	return MsgUpdateProgram{
+		ProgramID:   programID,
+		Submitter:   submitter,
+		Title:       title,
+		Description: description,
+		Executor:    executor,
+	}
+}
+
+// Route returns msg route
+func (msg MsgUpdateProgram) Route() string { return "budget" }
+
+// Type returns msg type
+func (msg MsgUpdateProgram) Type() string { return "updateprogram" }
+
+// GetSignBytes returns sign byptes
+func (msg MsgUpdateProgram) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgUpdateProgram) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Submitter}
+}
+
+// ValidateBasic validate msg
+func (msg MsgUpdateProgram) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Submitter.String())
+	}
+	if msg.ProgramID == 0 {
+		return ErrInvalidProgramID(msg.ProgramID)
+	}
+	if len(msg.Title) != 0 && len(strings.TrimSpace(msg.Title)) <= 0 {
+		return ErrInvalidTitle()
+	}
+	if len(msg.Description) != 0 && len(strings.TrimSpace(msg.Description)) <= 0 {
+		return ErrInvalidDescription()
+	}
+	if len(msg.Title) == 0 && len(msg.Description) == 0 && len(msg.Executor) == 0 {
+		return ErrEmptyAmendment(msg.ProgramID)
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgUpdateProgram) String() string {
+	return fmt.Sprintf(`MsgUpdateProgram
+	ProgramID: %v
+	Submitter: %v
+	Title: %v
+	Executor: %v`, msg.ProgramID, msg.Submitter, msg.Title, msg.Executor)
+}
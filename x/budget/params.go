@@ -9,21 +9,61 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// Leftover policies; what happens to the part of the budget pool that is not granted
+const (
+	LeftoverCarryOver     = "carry-over"     // added to the pool of the next distribution
+	LeftoverBurn          = "burn"           // never minted
+	LeftoverCommunityPool = "community-pool" // minted to the distribution community pool
+)
+
+// Rejected deposit policies; what happens to the deposit of a program that is rejected
+const (
+	DepositPolicyBurn          = "burn"           // stays burned
+	DepositPolicyCommunityPool = "community-pool" // minted to the distribution community pool
+)
+
+// Executor change policies; how the votes of a program react when its executor is replaced
+const (
+	ExecutorChangeResetVotes = "reset-votes" // votes are cleared, the program keeps its status
+	ExecutorChangeRevote     = "revote"      // votes are cleared, the program re-enters the candidate queue
+)
+
+// Snapshot policies; when the stake behind a vote is recorded for tallying
+const (
+	SnapshotAtVote         = "vote"     // when the vote is cast
+	SnapshotBeforeDeadline = "deadline" // when the vote is cast, and again SnapshotOffset blocks before each tally
+)
+
 // Params budget parameters
 type Params struct {
 	ActiveThreshold sdk.Dec  `json:"active_threshold"` // threshold of vote that will transition a program open -> active budget queue
 	LegacyThreshold sdk.Dec  `json:"legacy_threshold"` // threshold of vote that will transition a program active -> legacy budget queue
-	VotePeriod      int64    `json:"vote_period"`      // vote period
+	VotePeriod      int64    `json:"vote_period"`      // vote period, in blocks; whole days for re-weights once the clock has started
 	Deposit         sdk.Coin `json:"deposit"`          // Minimum deposit in TerraSDR
+	LeftoverPolicy  string   `json:"leftover_policy"`  // what to do with the pool left after grants
+
+	RejectedDepositPolicy string  `json:"rejected_deposit_policy"` // what to do with the deposit of a rejected program
+	ExecutorChangePolicy  string  `json:"executor_change_policy"`  // what to do with the votes of a program whose executor changed
+	SnapshotPolicy        string  `json:"snapshot_policy"`         // when the stake behind votes is recorded
+	SnapshotOffset        int64   `json:"snapshot_offset"`         // blocks before a tally the deadline snapshot is taken
+	MinVoterStake         sdk.Int `json:"min_voter_stake"`         // bonded uluna an account needs to vote
 }
 
 // NewParams creates a new param instance
-func NewParams(activeThreshold sdk.Dec, legacyThreshold sdk.Dec, votePeriod int64, deposit sdk.Coin) Params {
+func NewParams(activeThreshold sdk.Dec, legacyThreshold sdk.Dec, votePeriod int64, deposit sdk.Coin, leftoverPolicy string, rejectedDepositPolicy string, executorChangePolicy string,
+	snapshotPolicy string, snapshotOffset int64, minVoterStake sdk.Int) Params {
 	return Params{
 		ActiveThreshold: activeThreshold,
 		LegacyThreshold: legacyThreshold,
 		VotePeriod:      votePeriod,
 		Deposit:         deposit,
+		LeftoverPolicy:  leftoverPolicy,
+
+		RejectedDepositPolicy: rejectedDepositPolicy,
+		ExecutorChangePolicy:  executorChangePolicy,
+		SnapshotPolicy:        snapshotPolicy,
+		SnapshotOffset:        snapshotOffset,
+		MinVoterStake:         minVoterStake,
 	}
 }
 
@@ -34,6 +74,12 @@ func DefaultParams() Params {
 		sdk.NewDecWithPrec(0, 2), // 0%
 		util.BlocksPerMonth,
 		sdk.NewInt64Coin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit).Int64()),
+		LeftoverBurn,
+		DepositPolicyBurn,
+		ExecutorChangeRevote,
+		SnapshotAtVote,
+		util.BlocksPerDay,
+		sdk.NewInt(assets.MicroUnit), // 1 Luna
 	)
 }
 
@@ -50,6 +96,36 @@ func validateParams(params Params) error {
 	if params.Deposit.Amount.LTE(sdk.ZeroInt()) {
 		return fmt.Errorf("budget parameter Deposit must be > 0, is %v", params.Deposit.String())
 	}
+	switch params.LeftoverPolicy {
+	case LeftoverCarryOver, LeftoverBurn, LeftoverCommunityPool:
+	default:
+		return fmt.Errorf("budget parameter LeftoverPolicy must be one of %s, %s or %s, is %s",
+			LeftoverCarryOver, LeftoverBurn, LeftoverCommunityPool, params.LeftoverPolicy)
+	}
+	switch params.RejectedDepositPolicy {
+	case DepositPolicyBurn, DepositPolicyCommunityPool:
+	default:
+		return fmt.Errorf("budget parameter RejectedDepositPolicy must be one of %s or %s, is %s",
+			DepositPolicyBurn, DepositPolicyCommunityPool, params.RejectedDepositPolicy)
+	}
+	switch params.ExecutorChangePolicy {
+	case ExecutorChangeResetVotes, ExecutorChangeRevote:
+	default:
+		return fmt.Errorf("budget parameter ExecutorChangePolicy must be one of %s or %s, is %s",
+			ExecutorChangeResetVotes, ExecutorChangeRevote, params.ExecutorChangePolicy)
+	}
+	switch params.SnapshotPolicy {
+	case SnapshotAtVote, SnapshotBeforeDeadline:
+	default:
+		return fmt.Errorf("budget parameter SnapshotPolicy must be one of %s or %s, is %s",
+			SnapshotAtVote, SnapshotBeforeDeadline, params.SnapshotPolicy)
+	}
+	if params.SnapshotOffset < 0 || params.SnapshotOffset >= params.VotePeriod {
+		return fmt.Errorf("budget parameter SnapshotOffset must be >= 0 and < VotePeriod, is %d", params.SnapshotOffset)
+	}
+	if params.MinVoterStake.IsNegative() {
+		return fmt.Errorf("budget parameter MinVoterStake must be >= 0, is %s", params.MinVoterStake)
+	}
 	return nil
 }
 
@@ -59,5 +135,12 @@ func (params Params) String() string {
 	LegacyThreshold: %s
 	VotePeriod: %d
 	Deposit: %s
-  `, params.ActiveThreshold, params.LegacyThreshold, params.VotePeriod, params.Deposit)
+	LeftoverPolicy: %s
+	RejectedDepositPolicy: %s
+	ExecutorChangePolicy: %s
+	SnapshotPolicy: %s
+	SnapshotOffset: %d
+	MinVoterStake: %s
+  `, params.ActiveThreshold, params.LegacyThreshold, params.VotePeriod, params.Deposit, params.LeftoverPolicy,
+		params.RejectedDepositPolicy, params.ExecutorChangePolicy, params.SnapshotPolicy, params.SnapshotOffset, params.MinVoterStake)
 }
@@ -15,6 +15,15 @@ type Program struct {
 	Submitter   sdk.AccAddress `json:"submitter"`   // Validator address of the proposer
 	Executor    sdk.AccAddress `json:"executor"`    // Account address of the executor
 	SubmitBlock int64          `json:"submit_time"` // Block height from which the Program is open for votations
+	Deposit     sdk.Coin       `json:"deposit"`     // Deposit held until the program passes or is rejected
+
+	RequestedAmount sdk.Coin `json:"requested_amount"` // Maximum grant per distribution period, in TerraSDR
+	TotalCap        sdk.Coin `json:"total_cap"`        // Maximum cumulative grant, in TerraSDR; zero if uncapped
+	Paid            sdk.Coin `json:"paid"`             // Cumulative amount granted to the executor so far
+	PayoutDenom     string   `json:"payout_denom"`     // Denom the grants are minted in, at oracle rates
+	Deferred        sdk.Coin `json:"deferred"`         // Grant owed but not yet minted for lack of a payout rate, in TerraSDR
+
+	Amendments []Amendment `json:"amendments"` // History of updates, oldest first
 }
 
 // NewProgram validates deposit and creates a new Program
@@ -24,14 +33,24 @@ func NewProgram(
 	description string,
 	submitter sdk.AccAddress,
 	executor sdk.AccAddress,
-	submitBlock int64) Program {
+	submitBlock int64,
+	deposit sdk.Coin,
+	requestedAmount sdk.Coin,
+	totalCap sdk.Coin,
+	payoutDenom string) Program {
 	return Program{
-		ProgramID:   programID,
-		Title:       title,
-		Description: description,
-		Submitter:   submitter,
-		Executor:    executor,
-		SubmitBlock: submitBlock,
+		ProgramID:       programID,
+		Title:           title,
+		Description:     description,
+		Submitter:       submitter,
+		Executor:        executor,
+		SubmitBlock:     submitBlock,
+		Deposit:         deposit,
+		RequestedAmount: requestedAmount,
+		TotalCap:        totalCap,
+		Paid:            sdk.NewCoin(requestedAmount.Denom, sdk.ZeroInt()),
+		PayoutDenom:     payoutDenom,
+		Deferred:        sdk.NewCoin(requestedAmount.Denom, sdk.ZeroInt()),
 	}
 }
 
@@ -39,6 +58,47 @@ func (p *Program) getVotingEndBlock(ctx sdk.Context, k Keeper) int64 {
 	return p.SubmitBlock + k.GetParams(ctx).VotePeriod
 }
 
+// remainingGrant returns the most that can still be granted to the program in one period
+func (p Program) remainingGrant() sdk.Int {
+	remaining := p.RequestedAmount.Amount
+	if p.TotalCap.IsPositive() {
+		left := p.TotalCap.Amount.Sub(p.Paid.Amount).Sub(p.Deferred.Amount)
+		if left.LT(remaining) {
+			remaining = left
+		}
+	}
+
+	if remaining.IsNegative() {
+		return sdk.ZeroInt()
+	}
+	return remaining
+}
+
+// amend applies the non-empty fields to the program and records the replaced values.
+// Returns false if nothing changed.
+func (p *Program) amend(height int64, title, description string, executor sdk.AccAddress) bool {
+	amendment := NewAmendment(height, p.Title, p.Description, p.Executor)
+
+	changed := false
+	if len(title) != 0 && title != p.Title {
+		p.Title = title
+		changed = true
+	}
+	if len(description) != 0 && description != p.Description {
+		p.Description = description
+		changed = true
+	}
+	if len(executor) != 0 && !executor.Equals(p.Executor) {
+		p.Executor = executor
+		changed = true
+	}
+
+	if changed {
+		p.Amendments = append(p.Amendments, amendment)
+	}
+	return changed
+}
+
 // String implements fmt.Stringer
 func (p Program) String() string {
 	return fmt.Sprintf(`Program
@@ -47,8 +107,16 @@ func (p Program) String() string {
 	Description: %s
 	Submitter: %v
 	Executor: %v
-	SubmitBlock: %d`,
-		p.ProgramID, p.Title, p.Description, p.Submitter, p.Executor, p.SubmitBlock)
+	SubmitBlock: %d
+	Deposit: %s
+	RequestedAmount: %s
+	TotalCap: %s
+	Paid: %s
+	PayoutDenom: %s
+	Deferred: %s
+	Amendments: %v`,
+		p.ProgramID, p.Title, p.Description, p.Submitter, p.Executor, p.SubmitBlock, p.Deposit,
+		p.RequestedAmount, p.TotalCap, p.Paid, p.PayoutDenom, p.Deferred, p.Amendments)
 }
 
 // Programs is a collection of Program
@@ -60,3 +128,30 @@ func (p Programs) String() (out string) {
 	}
 	return strings.TrimSpace(out)
 }
+
+// Amendment records the values a program had until it was updated at Height
+type Amendment struct {
+	Height      int64          `json:"height"`      // Block height of the update
+	Title       string         `json:"title"`       // Title before the update
+	Description string         `json:"description"` // Description before the update
+	Executor    sdk.AccAddress `json:"executor"`    // Executor before the update
+}
+
+// NewAmendment creates a new Amendment instance
+func NewAmendment(height int64, title string, description string, executor sdk.AccAddress) Amendment {
+	return Amendment{
+		Height:      height,
+		Title:       title,
+		Description: description,
+		Executor:    executor,
+	}
+}
+
+// String implements fmt.Stringer
+func (a Amendment) String() string {
+	return fmt.Sprintf(`Amendment
+	Height: %d
+	Title: %s
+	Description: %s
+	Executor: %v`, a.Height, a.Title, a.Description, a.Executor)
+}
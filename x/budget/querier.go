@@ -17,6 +17,7 @@ const (
 	QueryActiveList    = "active-list"
 	QueryCandidateList = "candidate-list"
 	QueryParams        = "params"
+	QueryTally         = "tally"
 )
 
 // NewQuerier is the module level router for state queries
@@ -33,6 +34,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryCandidateList(ctx, req, keeper)
 		case QueryParams:
 			return queryParams(ctx, req, keeper)
+		case QueryTally:
+			return queryTally(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown oracle query endpoint")
 		}
@@ -61,6 +64,30 @@ func queryProgram(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 	return bz, nil
 }
 
+// nolint: unparam
+func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	programID, strConvertError := strconv.ParseUint(path[0], 10, 64)
+	if strConvertError != nil {
+		return nil, sdk.ErrInternal("ProgramID must be a valid int")
+	}
+
+	program, pErr := keeper.GetProgram(ctx, programID)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	// Tallying prunes stale votes; keep the query read only
+	cacheCtx, _ := ctx.CacheContext()
+	preview := previewTally(cacheCtx, keeper, program)
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, preview)
+	if err != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 // Params for query 'custom/oracle/votes'
 type QueryVotesParams struct {
 	Voter     sdk.AccAddress
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/terra-project/core/types/util"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -99,6 +101,99 @@ func getQueriedParams(t *testing.T, ctx sdk.Context, cdc *codec.Codec, querier s
 	return params
 }
 
+func getQueriedTally(t *testing.T, ctx sdk.Context, cdc *codec.Codec, querier sdk.Querier, programID uint64) TallyPreview {
+	query := abci.RequestQuery{
+		Path: strings.Join([]string{custom, QuerierRoute, QueryTally}, "/"),
+		Data: []byte{},
+	}
+
+	bz, err := querier(ctx, []string{QueryTally, strconv.FormatUint(programID, 10)}, query)
+	require.Nil(t, err)
+	require.NotNil(t, bz)
+
+	var result TallyPreview
+	err2 := cdc.UnmarshalJSON(bz, &result)
+	require.Nil(t, err2)
+
+	return result
+}
+
+func TestQueryTally(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.budgetKeeper)
+
+	testProgram := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, testProgram)
+
+	delegator := delegate(t, input, addrs[1], uLunaAmt)
+	input.budgetKeeper.AddVote(input.ctx, testProgram.ProgramID, addrs[0], true)
+	input.budgetKeeper.AddVote(input.ctx, testProgram.ProgramID, addrs[1], true)
+	input.budgetKeeper.AddVote(input.ctx, testProgram.ProgramID, delegator, false)
+
+	queriedTally := getQueriedTally(t, input.ctx, input.cdc, querier, testProgram.ProgramID)
+	require.Equal(t, testProgram.ProgramID, queriedTally.Tally.ProgramID)
+	require.Equal(t, 2, len(queriedTally.Tally.Validators))
+	require.Equal(t, 1, len(queriedTally.Tally.Delegators))
+	require.Equal(t, delegator, queriedTally.Tally.Delegators[0].Delegator)
+	require.True(t, uLunaAmt.Equal(queriedTally.Tally.VotePower()))
+	require.True(t, uLunaAmt.Equal(queriedTally.NetPower))
+
+	// Active program is held to the legacy threshold
+	require.False(t, queriedTally.Candidate)
+	require.True(t, input.budgetKeeper.GetParams(input.ctx).LegacyThreshold.Equal(queriedTally.Threshold))
+	require.True(t, queriedTally.ClearsThreshold)
+
+	// Unknown programs can't be tallied
+	_, err := querier(input.ctx, []string{QueryTally, "100"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestQueryTallyProjectedShare(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.budgetKeeper)
+
+	// Re-weight programs before the next distribution
+	params := input.budgetKeeper.GetParams(input.ctx)
+	params.VotePeriod = util.BlocksPerEpoch
+	input.budgetKeeper.SetParams(input.ctx, params)
+
+	// Two active programs with equal vote power
+	program1 := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, program1)
+	input.budgetKeeper.AddVote(input.ctx, program1.ProgramID, addrs[0], true)
+
+	program2 := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, program2)
+	input.budgetKeeper.AddVote(input.ctx, program2.ProgramID, addrs[1], true)
+
+	// A candidate that won't be active by the next re-weight
+	program3 := generateTestProgram(input.ctx, input.budgetKeeper)
+	program3.SubmitBlock = input.ctx.BlockHeight() + util.BlocksPerEpoch
+	input.budgetKeeper.StoreProgram(input.ctx, program3)
+	input.budgetKeeper.CandQueueInsert(input.ctx, program3.getVotingEndBlock(input.ctx, input.budgetKeeper), program3.ProgramID)
+	input.budgetKeeper.AddVote(input.ctx, program3.ProgramID, addrs[2], true)
+
+	queriedTally := getQueriedTally(t, input.ctx, input.cdc, querier, program1.ProgramID)
+	require.True(t, sdk.NewDecWithPrec(5, 1).Equal(queriedTally.ProjectedShare))
+
+	queriedTally = getQueriedTally(t, input.ctx, input.cdc, querier, program3.ProgramID)
+	require.True(t, queriedTally.Candidate)
+	require.True(t, params.ActiveThreshold.Equal(queriedTally.Threshold))
+	require.True(t, queriedTally.ClearsThreshold)
+	require.True(t, sdk.ZeroDec().Equal(queriedTally.ProjectedShare))
+
+	// Without a re-weight before the distribution, only the claim pool counts
+	params.VotePeriod = util.BlocksPerEpoch * 2
+	input.budgetKeeper.SetParams(input.ctx, params)
+	input.budgetKeeper.addClaim(input.ctx, program2.ProgramID, sdk.OneInt())
+
+	queriedTally = getQueriedTally(t, input.ctx, input.cdc, querier, program1.ProgramID)
+	require.True(t, sdk.ZeroDec().Equal(queriedTally.ProjectedShare))
+
+	queriedTally = getQueriedTally(t, input.ctx, input.cdc, querier, program2.ProgramID)
+	require.True(t, sdk.OneDec().Equal(queriedTally.ProjectedShare))
+}
+
 func TestQueryParams(t *testing.T) {
 	input := createTestInput(t)
 	querier := NewQuerier(input.budgetKeeper)
@@ -121,6 +216,15 @@ func TestQueryProgram(t *testing.T) {
 	queriedProgram := getQueriedProgram(t, input.ctx, input.cdc, querier, testProgram.ProgramID)
 
 	require.Equal(t, queriedProgram, testProgram)
+
+	// Amendment history is returned along with the program
+	require.True(t, testProgram.amend(input.ctx.BlockHeight(), "newTitle", "", addrs[2]))
+	input.budgetKeeper.StoreProgram(input.ctx, testProgram)
+
+	queriedProgram = getQueriedProgram(t, input.ctx, input.cdc, querier, testProgram.ProgramID)
+
+	require.Equal(t, queriedProgram, testProgram)
+	require.Equal(t, 1, len(queriedProgram.Amendments))
 }
 
 func TestQueryVotes(t *testing.T) {
@@ -0,0 +1,71 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/terra-project/core/simulation"
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/x/budget"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// SimulateMsgSubmitProgram returns an operation submitting a program of a random account, granting
+// a random amount to a random executor, optionally capped and paid out in one of {denoms}
+func SimulateMsgSubmitProgram(ak auth.AccountKeeper, denoms []string) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (simulation.OperationMsg, error) {
+
+		submitter := simulation.RandomAcc(r, accs)
+		executor := simulation.RandomAcc(r, accs)
+
+		requestedAmount := sdk.NewInt64Coin(assets.MicroSDRDenom, (1+r.Int63n(1000))*assets.MicroUnit)
+
+		totalCap := sdk.Coin{}
+		if r.Intn(2) == 0 {
+			totalCap = sdk.NewCoin(assets.MicroSDRDenom, requestedAmount.Amount.MulRaw(1+r.Int63n(10)))
+		}
+
+		payoutDenom := ""
+		if r.Intn(2) == 0 {
+			payoutDenom = denoms[r.Intn(len(denoms))]
+		}
+
+		msg := budget.NewMsgSubmitProgram(
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 100),
+			submitter.Address,
+			executor.Address,
+			requestedAmount,
+			totalCap,
+			payoutDenom,
+		)
+
+		return simulation.DeliverMsg(app, ctx, ak, chainID, msg, submitter)
+	}
+}
+
+// SimulateMsgVoteProgram returns an operation voting a random option of a random account on a
+// random program
+func SimulateMsgVoteProgram(ak auth.AccountKeeper, k budget.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (simulation.OperationMsg, error) {
+
+		var programIDs []uint64
+		k.IteratePrograms(ctx, false, func(program budget.Program) (stop bool) {
+			programIDs = append(programIDs, program.ProgramID)
+			return false
+		})
+
+		if len(programIDs) == 0 {
+			return simulation.NoOpMsg(budget.RouterKey), nil
+		}
+
+		voter := simulation.RandomAcc(r, accs)
+		msg := budget.NewMsgVoteProgram(programIDs[r.Intn(len(programIDs))], r.Intn(2) == 0, voter.Address)
+
+		return simulation.DeliverMsg(app, ctx, ak, chainID, msg, voter)
+	}
+}
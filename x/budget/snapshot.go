@@ -0,0 +1,179 @@
+package budget
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidatorSnapshot is the state of a validator at the height of a snapshot
+type ValidatorSnapshot struct {
+	Validator       sdk.ValAddress `json:"validator"`
+	BondedTokens    sdk.Int        `json:"bonded_tokens"`
+	DelegatorShares sdk.Dec        `json:"delegator_shares"`
+}
+
+// DelegationSnapshot is a delegation of a voter, along with the state of its validator, at the height of a snapshot
+type DelegationSnapshot struct {
+	Validator ValidatorSnapshot `json:"validator"`
+	Shares    sdk.Dec           `json:"shares"`
+}
+
+// VoterShares is the delegation of another voter on the program to the validator of a snapshot
+type VoterShares struct {
+	Voter  sdk.AccAddress `json:"voter"`
+	Shares sdk.Dec        `json:"shares"`
+}
+
+// VoteSnapshot is the stake behind a vote as of Height. Votes are tallied with their snapshot,
+// so stake moved after the snapshot does not change the outcome.
+type VoteSnapshot struct {
+	ProgramID   uint64               `json:"program_id"`
+	Voter       sdk.AccAddress       `json:"voter"`
+	Height      int64                `json:"height"`
+	TotalPower  sdk.Int              `json:"total_power"` // Total bonded tokens at Height
+	Validator   ValidatorSnapshot    `json:"validator"`   // Validator operated by the voter; empty if none
+	Delegations []DelegationSnapshot `json:"delegations"`
+	VoterShares []VoterShares        `json:"voter_shares"` // Delegations to the validator of the accounts that had voted on the program
+}
+
+// voterShares returns the shares {voter} held in the validator of the snapshot, if it had voted by then
+func (vs VoteSnapshot) voterShares(voter sdk.AccAddress) (shares sdk.Dec, found bool) {
+	for _, vsh := range vs.VoterShares {
+		if vsh.Voter.Equals(voter) {
+			return vsh.Shares, true
+		}
+	}
+	return sdk.ZeroDec(), false
+}
+
+// IsValidator returns true if the voter operated a validator at the snapshot
+func (vs VoteSnapshot) IsValidator() bool {
+	return len(vs.Validator.Validator) != 0
+}
+
+// HasStake returns true if the voter validated or delegated at the snapshot
+func (vs VoteSnapshot) HasStake() bool {
+	return vs.IsValidator() || len(vs.Delegations) != 0
+}
+
+func (vs VoteSnapshot) String() string {
+	return fmt.Sprintf(`VoteSnapshot
+	ProgramID: %d
+	Voter: %s
+	Height: %d
+	TotalPower: %s
+	Validator: %s
+	Delegations: %d`, vs.ProgramID, vs.Voter, vs.Height, vs.TotalPower, vs.Validator.Validator, len(vs.Delegations))
+}
+
+// VoteSnapshots is a collection of VoteSnapshot
+type VoteSnapshots []VoteSnapshot
+
+func (v VoteSnapshots) String() (out string) {
+	for _, val := range v {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// snapshotVotePower records the stake of {voter} at the current block, along with the total bonded
+// tokens. If the voter operates a validator, the delegations to it of the accounts that have voted
+// on the program are recorded too, so that their stake is taken out of the validator's exactly once.
+func snapshotVotePower(ctx sdk.Context, k Keeper, programID uint64, voter sdk.AccAddress) VoteSnapshot {
+	snapshot := VoteSnapshot{
+		ProgramID:   programID,
+		Voter:       voter,
+		Height:      ctx.BlockHeight(),
+		TotalPower:  k.valset.TotalBondedTokens(ctx),
+		Delegations: []DelegationSnapshot{},
+		VoterShares: []VoterShares{},
+	}
+
+	snapshotValidator := func(valAddr sdk.ValAddress) (ValidatorSnapshot, bool) {
+		validator := k.valset.Validator(ctx, valAddr)
+		if validator == nil {
+			return ValidatorSnapshot{}, false
+		}
+
+		return ValidatorSnapshot{
+			Validator:       valAddr,
+			BondedTokens:    validator.GetBondedTokens(),
+			DelegatorShares: validator.GetDelegatorShares(),
+		}, true
+	}
+
+	if vs, ok := snapshotValidator(sdk.ValAddress(voter)); ok {
+		snapshot.Validator = vs
+
+		k.IterateVotesWithPrefix(ctx, keyVote(programID, sdk.AccAddress{}), func(_ uint64, other sdk.AccAddress, _ bool) (stop bool) {
+			if other.Equals(voter) {
+				return false
+			}
+
+			if delegation := k.valset.Delegation(ctx, other, vs.Validator); delegation != nil {
+				snapshot.VoterShares = append(snapshot.VoterShares, VoterShares{
+					Voter:  other,
+					Shares: delegation.GetShares(),
+				})
+			}
+			return false
+		})
+	}
+
+	k.ds.IterateDelegations(ctx, voter, func(_ int64, delegation sdk.Delegation) (stop bool) {
+		if vs, ok := snapshotValidator(delegation.GetValidatorAddr()); ok {
+			snapshot.Delegations = append(snapshot.Delegations, DelegationSnapshot{
+				Validator: vs,
+				Shares:    delegation.GetShares(),
+			})
+		}
+		return false
+	})
+
+	return snapshot
+}
+
+// snapshotProgramVotes refreshes the snapshots of all the votes on a program at the current block
+func snapshotProgramVotes(ctx sdk.Context, k Keeper, programID uint64) {
+	var voters []sdk.AccAddress
+	k.IterateVotesWithPrefix(ctx, keyVote(programID, sdk.AccAddress{}), func(_ uint64, voter sdk.AccAddress, _ bool) (stop bool) {
+		voters = append(voters, voter)
+		return false
+	})
+
+	for _, voter := range voters {
+		k.SetVoteSnapshot(ctx, snapshotVotePower(ctx, k, programID, voter))
+	}
+}
+
+// voterStake returns the bonded tokens behind {voter}; the bonded tokens of the validator it operates,
+// and the worth of its delegations to other validators. staked is false if it neither validates nor
+// delegates.
+func voterStake(ctx sdk.Context, k Keeper, voter sdk.AccAddress) (stake sdk.Int, staked bool) {
+	stake = sdk.ZeroInt()
+	if validator := k.valset.Validator(ctx, sdk.ValAddress(voter)); validator != nil {
+		stake = stake.Add(validator.GetBondedTokens())
+		staked = true
+	}
+
+	k.ds.IterateDelegations(ctx, voter, func(_ int64, delegation sdk.Delegation) (stop bool) {
+		staked = true
+
+		valAddr := delegation.GetValidatorAddr()
+		if valAddr.Equals(sdk.ValAddress(voter)) {
+			return false
+		}
+
+		validator := k.valset.Validator(ctx, valAddr)
+		if validator == nil || !validator.GetDelegatorShares().IsPositive() {
+			return false
+		}
+
+		stake = stake.Add(delegation.GetShares().MulInt(validator.GetBondedTokens()).Quo(validator.GetDelegatorShares()).TruncateInt())
+		return false
+	})
+
+	return stake, staked
+}
@@ -0,0 +1,50 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestSnapshotVotePower(t *testing.T) {
+	input := createTestInput(t)
+
+	testProgram := generateTestProgram(input.ctx, input.budgetKeeper)
+	input.budgetKeeper.StoreProgram(input.ctx, testProgram)
+
+	delegator := delegate(t, input, addrs[0], uLunaAmt.MulRaw(2))
+	input.budgetKeeper.AddVote(input.ctx, testProgram.ProgramID, delegator, true)
+
+	// The delegator holds its delegation, along with the state of the validator
+	snapshot := snapshotVotePower(input.ctx, input.budgetKeeper, testProgram.ProgramID, delegator)
+	require.True(t, snapshot.HasStake())
+	require.False(t, snapshot.IsValidator())
+	require.Equal(t, uLunaAmt.MulRaw(5), snapshot.TotalPower)
+	require.Equal(t, 1, len(snapshot.Delegations))
+	require.Equal(t, sdk.ValAddress(addrs[0]), snapshot.Delegations[0].Validator.Validator)
+	require.Equal(t, uLunaAmt.MulRaw(3), snapshot.Delegations[0].Validator.BondedTokens)
+	require.Equal(t, sdk.NewDecFromInt(uLunaAmt.MulRaw(2)), snapshot.Delegations[0].Shares)
+
+	// The validator records the shares of the delegator that had voted before it
+	snapshot = snapshotVotePower(input.ctx, input.budgetKeeper, testProgram.ProgramID, addrs[0])
+	require.True(t, snapshot.IsValidator())
+	require.Equal(t, uLunaAmt.MulRaw(3), snapshot.Validator.BondedTokens)
+	require.Equal(t, 1, len(snapshot.VoterShares))
+
+	shares, found := snapshot.voterShares(delegator)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecFromInt(uLunaAmt.MulRaw(2)), shares)
+
+	// Validators the voters do not delegate to record no shares
+	snapshot = snapshotVotePower(input.ctx, input.budgetKeeper, testProgram.ProgramID, addrs[1])
+	require.True(t, snapshot.IsValidator())
+	require.Empty(t, snapshot.VoterShares)
+
+	// Accounts without stake have none in their snapshot
+	outsider := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	snapshot = snapshotVotePower(input.ctx, input.budgetKeeper, testProgram.ProgramID, outsider)
+	require.False(t, snapshot.HasStake())
+}
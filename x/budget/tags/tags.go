@@ -6,10 +6,20 @@ import (
 
 // Governance tags
 var (
-	ActionProgramLegacied = "program-legacied"
-	ActionProgramPassed   = "program-passed"
-	ActionProgramRejected = "program-rejected"
-	ActionProgramGranted  = "program-grant"
+	ActionProgramLegacied  = "program-legacied"
+	ActionProgramPassed    = "program-passed"
+	ActionProgramRejected  = "program-rejected"
+	ActionProgramGranted   = "program-grant"
+	ActionProgramPaid      = "program-paid"
+	ActionLeftover         = "budget-leftover"
+	ActionDepositRefunded  = "deposit-refunded"
+	ActionDepositForfeited = "deposit-forfeited"
+	ActionProgramUpdated   = "program-updated"
+	ActionVotesReset       = "votes-reset"
+	ActionProgramRevote    = "program-revote"
+	ActionGrantDeferred    = "grant-deferred"
+	ActionGrantsDeferred   = "budget-deferred"
+	ActionProgramCompleted = "program-completed"
 
 	Action            = sdk.TagAction
 	Submitter         = "submitter"
@@ -19,4 +29,7 @@ var (
 	Voter             = "voter"
 	Weight            = "weight"
 	Option            = "option"
+	Amount            = "amount"
+	LeftoverPolicy    = "leftover-policy"
+	Deposit           = "deposit"
 )
@@ -0,0 +1,294 @@
+package budget
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidatorTally is the vote of a validator on a program. Its power is its bonded tokens
+// minus the share of delegators that voted themselves.
+type ValidatorTally struct {
+	Validator           sdk.ValAddress `json:"validator"`
+	Option              bool           `json:"option"`
+	BondedTokens        sdk.Int        `json:"bonded_tokens"`
+	DelegatorShares     sdk.Dec        `json:"delegator_shares"`
+	DelegatorDeductions sdk.Dec        `json:"delegator_deductions"`
+	Power               sdk.Dec        `json:"power"`
+}
+
+func (vt ValidatorTally) String() string {
+	return fmt.Sprintf("%s: option %v, power %s (deducted %s of %s shares)",
+		vt.Validator, vt.Option, vt.Power, vt.DelegatorDeductions, vt.DelegatorShares)
+}
+
+// DelegatorTally is the vote of a delegator on a program, overriding the votes of the
+// validators it delegates to for its share of their bonded tokens.
+type DelegatorTally struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Option    bool           `json:"option"`
+	Power     sdk.Dec        `json:"power"`
+}
+
+func (dt DelegatorTally) String() string {
+	return fmt.Sprintf("%s: option %v, power %s", dt.Delegator, dt.Option, dt.Power)
+}
+
+// TallyResult is the tally of the votes on a program, broken down by voter
+type TallyResult struct {
+	ProgramID  uint64           `json:"program_id"`
+	YesPower   sdk.Dec          `json:"yes_power"`
+	NoPower    sdk.Dec          `json:"no_power"`
+	TotalPower sdk.Int          `json:"total_power"`
+	Validators []ValidatorTally `json:"validators"`
+	Delegators []DelegatorTally `json:"delegators"`
+}
+
+// VotePower returns the net vote power of the program; yes power minus no power
+func (tr TallyResult) VotePower() sdk.Int {
+	return tr.YesPower.Sub(tr.NoPower).TruncateInt()
+}
+
+func (tr TallyResult) String() (out string) {
+	out = fmt.Sprintf(`TallyResult
+	ProgramID: %d
+	YesPower: %s
+	NoPower: %s
+	TotalPower: %s
+	Validators:`, tr.ProgramID, tr.YesPower, tr.NoPower, tr.TotalPower)
+	for _, vt := range tr.Validators {
+		out += "\n\t\t" + vt.String()
+	}
+	out += "\n\tDelegators:"
+	for _, dt := range tr.Delegators {
+		out += "\n\t\t" + dt.String()
+	}
+	return strings.TrimSpace(out)
+}
+
+// TallyPreview is the live tally of a program, along with the outcome it would have if tallied now
+type TallyPreview struct {
+	Tally           TallyResult `json:"tally"`
+	NetPower        sdk.Int     `json:"net_power"`        // yes power minus no power
+	Candidate       bool        `json:"candidate"`        // whether the program is still in the candidate queue
+	Threshold       sdk.Dec     `json:"threshold"`        // ActiveThreshold for candidates, LegacyThreshold for active programs
+	ClearsThreshold bool        `json:"clears_threshold"` // whether the net power clears the threshold
+	ProjectedShare  sdk.Dec     `json:"projected_share"`  // share of the next epoch's budget pool if the votes stand
+}
+
+func (tp TallyPreview) String() string {
+	return fmt.Sprintf(`TallyPreview
+	ProgramID: %d
+	YesPower: %s
+	NoPower: %s
+	NetPower: %s
+	TotalPower: %s
+	Candidate: %v
+	Threshold: %s
+	ClearsThreshold: %v
+	ProjectedShare: %s`,
+		tp.Tally.ProgramID, tp.Tally.YesPower, tp.Tally.NoPower, tp.NetPower, tp.Tally.TotalPower,
+		tp.Candidate, tp.Threshold, tp.ClearsThreshold, tp.ProjectedShare)
+}
+
+// previewTally tallies a program with the same functions the end blocker uses, and projects its
+// share of the next distribution. Tallying prunes stale votes; callers that must not write pass
+// a cache context.
+func previewTally(ctx sdk.Context, k Keeper, program Program) TallyPreview {
+	params := k.GetParams(ctx)
+	result, threshold, candidate := evaluateProgram(ctx, k, params, program)
+
+	projectedShare := sdk.ZeroDec()
+	claims := projectClaims(ctx, k, params)
+	if claim, ok := claims[program.ProgramID]; ok {
+		claimSum := sdk.ZeroInt()
+		for _, weight := range claims {
+			claimSum = claimSum.Add(weight)
+		}
+
+		if claimSum.IsPositive() {
+			projectedShare = sdk.NewDecFromInt(claim).QuoInt(claimSum)
+		}
+	}
+
+	return TallyPreview{
+		Tally:           result,
+		NetPower:        result.VotePower(),
+		Candidate:       candidate,
+		Threshold:       threshold,
+		ClearsThreshold: clearsThreshold(result.VotePower(), result.TotalPower, threshold),
+		ProjectedShare:  projectedShare,
+	}
+}
+
+// tallyProgram tallies the votes on a program the way cosmos gov does. Validators vote with their
+// bonded tokens, and delegators that vote themselves take their share of each of their validators'
+// tokens with them. Each vote is counted with the stake of its snapshot, or with the current stake
+// if it has none, and the thresholds are measured against the total bonded tokens of the latest
+// snapshot. Votes of accounts that neither validate nor delegate are deleted once tallied.
+func tallyProgram(ctx sdk.Context, k Keeper, programID uint64) TallyResult {
+	result := TallyResult{
+		ProgramID:  programID,
+		YesPower:   sdk.ZeroDec(),
+		NoPower:    sdk.ZeroDec(),
+		TotalPower: k.valset.TotalBondedTokens(ctx),
+		Validators: []ValidatorTally{},
+		Delegators: []DelegatorTally{},
+	}
+
+	var votes Votes
+	k.IterateVotesWithPrefix(ctx, keyVote(programID, sdk.AccAddress{}), func(programID uint64, voter sdk.AccAddress, option bool) (stop bool) {
+		votes = append(votes, NewVote(programID, option, voter))
+		return false
+	})
+
+	var counted Votes
+	var snapshots []VoteSnapshot
+	var stale []sdk.AccAddress
+	latest := int64(-1)
+	for _, vote := range votes {
+		snapshot, found := k.GetVoteSnapshot(ctx, programID, vote.Voter)
+		if !found {
+			snapshot = snapshotVotePower(ctx, k, programID, vote.Voter)
+		}
+
+		if !snapshot.HasStake() {
+			stale = append(stale, vote.Voter)
+			continue
+		}
+
+		if snapshot.Height > latest {
+			latest = snapshot.Height
+			result.TotalPower = snapshot.TotalPower
+		}
+
+		counted = append(counted, vote)
+		snapshots = append(snapshots, snapshot)
+	}
+
+	// Index the voters, and the shares they hold in each validator, in one pass over the votes
+	voted := make(map[string]bool, len(counted))
+	held := make(map[string][]heldShares)
+	for i, vote := range counted {
+		voted[vote.Voter.String()] = true
+
+		for _, delegation := range snapshots[i].Delegations {
+			valAddr := delegation.Validator.Validator.String()
+			held[valAddr] = append(held[valAddr], heldShares{
+				voter:  vote.Voter.String(),
+				height: snapshots[i].Height,
+				shares: delegation.Shares,
+			})
+		}
+	}
+
+	for i, vote := range counted {
+		snapshot := snapshots[i]
+
+		delegatorPower := sdk.ZeroDec()
+		for _, delegation := range snapshot.Delegations {
+			vs := delegation.Validator
+
+			// A validator's self delegation follows its own vote
+			if snapshot.IsValidator() && vs.Validator.Equals(snapshot.Validator.Validator) {
+				continue
+			}
+
+			if !vs.DelegatorShares.IsPositive() {
+				continue
+			}
+
+			delegatorPower = delegatorPower.Add(delegation.Shares.MulInt(vs.BondedTokens).Quo(vs.DelegatorShares))
+		}
+
+		if !snapshot.IsValidator() || delegatorPower.IsPositive() {
+			result.Delegators = append(result.Delegators, DelegatorTally{
+				Delegator: vote.Voter,
+				Option:    vote.Option,
+				Power:     delegatorPower,
+			})
+		}
+
+		result.addPower(vote.Option, delegatorPower)
+	}
+
+	// Validators vote with what is left after their delegators' deductions
+	for i, vote := range counted {
+		snapshot := snapshots[i]
+		if !snapshot.IsValidator() {
+			continue
+		}
+
+		vt := ValidatorTally{
+			Validator:           snapshot.Validator.Validator,
+			Option:              vote.Option,
+			BondedTokens:        snapshot.Validator.BondedTokens,
+			DelegatorShares:     snapshot.Validator.DelegatorShares,
+			DelegatorDeductions: delegatorDeductions(snapshot, voted, held[snapshot.Validator.Validator.String()]),
+		}
+
+		vt.Power = sdk.NewDecFromInt(vt.BondedTokens)
+		if vt.DelegatorShares.IsPositive() {
+			// Snapshots taken at different heights may deduct more than the validator had
+			sharesAfterDeductions := vt.DelegatorShares.Sub(vt.DelegatorDeductions)
+			if sharesAfterDeductions.IsNegative() {
+				sharesAfterDeductions = sdk.ZeroDec()
+			}
+
+			vt.Power = sharesAfterDeductions.MulInt(vt.BondedTokens).Quo(vt.DelegatorShares)
+		}
+
+		result.Validators = append(result.Validators, vt)
+		result.addPower(vt.Option, vt.Power)
+	}
+
+	for _, voter := range stale {
+		k.DeleteVote(ctx, programID, voter)
+	}
+
+	return result
+}
+
+// heldShares are the shares a voter held in a validator at the height of its snapshot
+type heldShares struct {
+	voter  string
+	height int64
+	shares sdk.Dec
+}
+
+// delegatorDeductions returns the shares of the validator of {valSnapshot} that the other voters take
+// away with their own votes. For the voters that had voted by the validator's snapshot, they are the
+// shares that snapshot recorded, so that stake redelegated in after the voter's snapshot is not counted
+// twice; for the rest, they are the {held} shares of their own snapshots taken since.
+func delegatorDeductions(valSnapshot VoteSnapshot, voted map[string]bool, held []heldShares) sdk.Dec {
+	deductions := sdk.ZeroDec()
+	self := valSnapshot.Voter.String()
+
+	recorded := make(map[string]bool, len(valSnapshot.VoterShares))
+	for _, vsh := range valSnapshot.VoterShares {
+		voter := vsh.Voter.String()
+		recorded[voter] = true
+
+		if voter != self && voted[voter] {
+			deductions = deductions.Add(vsh.Shares)
+		}
+	}
+
+	for _, hs := range held {
+		if hs.voter == self || recorded[hs.voter] || hs.height < valSnapshot.Height {
+			continue
+		}
+
+		deductions = deductions.Add(hs.shares)
+	}
+	return deductions
+}
+
+func (tr *TallyResult) addPower(option bool, power sdk.Dec) {
+	if option {
+		tr.YesPower = tr.YesPower.Add(power)
+	} else {
+		tr.NoPower = tr.NoPower.Add(power)
+	}
+}
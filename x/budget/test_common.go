@@ -57,8 +57,13 @@ var (
 
 	uSDRAmt  = sdk.NewInt(1005 * assets.MicroUnit)
 	uLunaAmt = sdk.NewInt(10 * assets.MicroUnit)
+
+	testRequestedAmount = sdk.NewInt64Coin(assets.MicroSDRDenom, 100*assets.MicroUnit)
 )
 
+// faucet is the module the tests fund accounts as; it may mint and burn every denom
+const faucet = "faucet"
+
 type testInput struct {
 	ctx            sdk.Context
 	cdc            *codec.Codec
@@ -66,6 +71,9 @@ type testInput struct {
 	bankKeeper     bank.Keeper
 	budgetKeeper   Keeper
 	treasuryKeeper TreasuryKeeper
+	oracleKeeper   oracle.Keeper
+	distrKeeper    distr.Keeper
+	stakingKeeper  staking.Keeper
 }
 
 func newTestCodec() *codec.Codec {
@@ -152,6 +160,7 @@ func createTestInput(t *testing.T) testInput {
 		cdc, keyDistr, paramsKeeper.Subspace(distr.DefaultParamspace),
 		bankKeeper, &stakingKeeper, feeCollectionKeeper, distr.DefaultCodespace,
 	)
+	distrKeeper.SetFeePool(ctx, distr.InitialFeePool())
 
 	mintKeeper := mint.NewKeeper(
 		cdc,
@@ -159,8 +168,14 @@ func createTestInput(t *testing.T) testInput {
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(faucet, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+	mintKeeper.RegisterPermission(market.ModuleName, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+
 	oracleKeeper := oracle.NewKeeper(
 		cdc,
 		keyOracle,
@@ -175,7 +190,7 @@ func createTestInput(t *testing.T) testInput {
 		cdc,
 		keyMarket,
 		oracleKeeper,
-		mintKeeper,
+		mintKeeper.ForModule(market.ModuleName),
 		paramsKeeper.Subspace(market.DefaultParamspace),
 	)
 
@@ -183,15 +198,15 @@ func createTestInput(t *testing.T) testInput {
 		cdc,
 		keyTreasury,
 		stakingKeeper.GetValidatorSet(),
-		mintKeeper,
+		mintKeeper.ForModule(treasury.ModuleName),
 		marketKeeper,
 		paramsKeeper.Subspace(treasury.DefaultParamspace),
 	)
 
 	sh := staking.NewHandler(stakingKeeper)
 	for i, addr := range addrs {
-		err := mintKeeper.Mint(ctx, addr, sdk.NewCoin(assets.MicroSDRDenom, uSDRAmt))
-		err2 := mintKeeper.Mint(ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
+		err := mintKeeper.ForModule(faucet).Mint(ctx, addr, sdk.NewCoin(assets.MicroSDRDenom, uSDRAmt))
+		err2 := mintKeeper.ForModule(faucet).Mint(ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
 
 		require.NoError(t, err)
 		require.NoError(t, err2)
@@ -207,19 +222,22 @@ func createTestInput(t *testing.T) testInput {
 		staking.EndBlocker(ctx, stakingKeeper)
 	}
 
+	mintKeeper.RegisterPermission(ModuleName, mint.NewPermission(
+		[]string{mint.OperationMint, mint.OperationBurn, mint.OperationPool}, assets.TerraDenoms))
 	budgetKeeper := NewKeeper(
 		cdc,
 		keyBudget,
 		marketKeeper,
-		mintKeeper,
+		mintKeeper.ForModule(ModuleName),
 		treasuryKeeper,
-		stakingKeeper.GetValidatorSet(),
+		distrKeeper,
+		stakingKeeper,
 		paramsKeeper.Subspace(DefaultParamspace),
 	)
 
 	InitGenesis(ctx, budgetKeeper, DefaultGenesisState())
 
-	return testInput{ctx, cdc, mintKeeper, bankKeeper, budgetKeeper, treasuryKeeper}
+	return testInput{ctx, cdc, mintKeeper, bankKeeper, budgetKeeper, treasuryKeeper, oracleKeeper, distrKeeper, stakingKeeper}
 }
 
 func generateTestProgram(ctx sdk.Context, budgetKeeper Keeper, accounts ...sdk.AccAddress) Program {
@@ -235,5 +253,20 @@ func generateTestProgram(ctx sdk.Context, budgetKeeper Keeper, accounts ...sdk.A
 
 	testProgramID := budgetKeeper.NewProgramID(ctx)
 
-	return NewProgram(testProgramID, "testTitle", "testDescription", submitter, executor, util.GetEpoch(ctx).Int64())
+	return NewProgram(testProgramID, "testTitle", "testDescription", submitter, executor, util.GetEpoch(ctx).Int64(),
+		budgetKeeper.GetParams(ctx).Deposit, testRequestedAmount, sdk.NewCoin(assets.MicroSDRDenom, sdk.ZeroInt()), assets.MicroSDRDenom)
+}
+
+// delegate creates a new delegator account that bonds {amount} to the validator operated by {valAccAddr}
+func delegate(t *testing.T, input testInput, valAccAddr sdk.AccAddress, amount sdk.Int) sdk.AccAddress {
+	delAddr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	err := input.mintKeeper.ForModule(faucet).Mint(input.ctx, delAddr, sdk.NewCoin(assets.MicroLunaDenom, amount))
+	require.NoError(t, err)
+
+	msg := staking.NewMsgDelegate(delAddr, sdk.ValAddress(valAccAddr), sdk.NewCoin(assets.MicroLunaDenom, amount))
+	res := staking.NewHandler(input.stakingKeeper)(input.ctx, msg)
+	require.True(t, res.IsOK())
+
+	staking.EndBlocker(input.ctx, input.stakingKeeper)
+	return delAddr
 }
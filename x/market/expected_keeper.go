@@ -13,4 +13,5 @@ type MintKeeper interface {
 	Mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) (err sdk.Error)
 	Burn(ctx sdk.Context, payer sdk.AccAddress, coin sdk.Coin) (err sdk.Error)
 	GetIssuance(ctx sdk.Context, denom string, day sdk.Int) (issuance sdk.Int)
+	GetDay(ctx sdk.Context) sdk.Int
 }
@@ -3,6 +3,7 @@ package market
 import (
 	"reflect"
 
+	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/x/market/tags"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -41,9 +42,17 @@ func handleMsgSwap(ctx sdk.Context, k Keeper, msg MsgSwap) sdk.Result {
 		swapFeeAmt := spread.MulInt(swapCoin.Amount).TruncateInt()
 		if swapFeeAmt.IsPositive() {
 			swapFee = sdk.NewCoin(swapCoin.Denom, swapFeeAmt)
-			k.ok.AddSwapFeePool(ctx, sdk.NewCoins(swapFee))
-
 			swapCoin = swapCoin.Sub(swapFee)
+
+			// The fee pool only holds Terra, whose issuance the oracle may change; if Luna is asked,
+			// the fee is the same share of the offered coin
+			if swapFee.Denom == assets.MicroLunaDenom {
+				swapFee = sdk.NewCoin(msg.OfferCoin.Denom, spread.MulInt(msg.OfferCoin.Amount).TruncateInt())
+			}
+
+			if swapFee.IsPositive() {
+				k.ok.AddSwapFeePool(ctx, sdk.NewCoins(swapFee))
+			}
 		}
 	}
 
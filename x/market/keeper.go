@@ -2,7 +2,6 @@ package market
 
 import (
 	"github.com/terra-project/core/types/assets"
-	"github.com/terra-project/core/types/util"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -31,7 +30,7 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ok OracleKeeper, mk MintKeepe
 
 // ComputeLunaDelta returns the issuance rate change of Luna for the day post-swap
 func (k Keeper) ComputeLunaDelta(ctx sdk.Context, change sdk.Int) sdk.Dec {
-	curDay := ctx.BlockHeight() / util.BlocksPerDay
+	curDay := k.mk.GetDay(ctx).Int64()
 
 	// Start limits on day 2
 	if curDay == 0 {
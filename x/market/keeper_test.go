@@ -40,7 +40,7 @@ func TestKeeperSwapCoinsLunaCap(t *testing.T) {
 
 	baseAmount := sdk.NewInt(int64(math.Pow10(9)))
 	// Set day to 2 and issuance as the same as the day before
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, baseAmount))
+	input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, baseAmount))
 	input.ctx = input.ctx.WithBlockHeight(util.BlocksPerDay + 1)
 
 	// Set exchange rate. Keep it at 1:1 for simplicity
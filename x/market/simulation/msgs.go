@@ -0,0 +1,39 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/terra-project/core/simulation"
+	"github.com/terra-project/core/x/market"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// SimulateMsgSwap returns an operation swapping a random part of a random coin of a random account
+// to one of {denoms}
+func SimulateMsgSwap(ak auth.AccountKeeper, denoms []string) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (simulation.OperationMsg, error) {
+
+		trader := simulation.RandomAcc(r, accs)
+		acc := ak.GetAccount(ctx, trader.Address)
+		if acc == nil {
+			return simulation.NoOpMsg(market.RouterKey), nil
+		}
+
+		offerCoin, ok := simulation.RandomCoin(r, acc.GetCoins())
+		if !ok {
+			return simulation.NoOpMsg(market.RouterKey), nil
+		}
+
+		askDenom := denoms[r.Intn(len(denoms))]
+		if askDenom == offerCoin.Denom {
+			return simulation.NoOpMsg(market.RouterKey), nil
+		}
+
+		msg := market.NewMsgSwap(trader.Address, offerCoin, askDenom)
+		return simulation.DeliverMsg(app, ctx, ak, chainID, msg, trader)
+	}
+}
@@ -34,6 +34,9 @@ var (
 	uSDRAmt = sdk.NewInt(1005).MulRaw(assets.MicroUnit)
 )
 
+// faucet is the module the tests fund accounts as; it may mint and burn every denom
+const faucet = "faucet"
+
 type testInput struct {
 	ctx          sdk.Context
 	accKeeper    auth.AccountKeeper
@@ -126,8 +129,12 @@ func createTestInput(t *testing.T) testInput {
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(faucet, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+
 	oracleKeeper := oracle.NewKeeper(
 		cdc,
 		keyOracle,
@@ -138,18 +145,20 @@ func createTestInput(t *testing.T) testInput {
 		paramsKeeper.Subspace(oracle.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(ModuleName, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
 	marketKeeper := NewKeeper(
 		cdc,
 		keyMarket,
 		oracleKeeper,
-		mintKeeper,
+		mintKeeper.ForModule(ModuleName),
 		paramsKeeper.Subspace(DefaultParamspace),
 	)
 
 	marketKeeper.SetParams(ctx, DefaultParams())
 
 	for _, addr := range addrs {
-		err := mintKeeper.Mint(ctx, addr, sdk.NewCoin(assets.MicroSDRDenom, uSDRAmt))
+		err := mintKeeper.ForModule(faucet).Mint(ctx, addr, sdk.NewCoin(assets.MicroSDRDenom, uSDRAmt))
 		require.NoError(t, err)
 	}
 
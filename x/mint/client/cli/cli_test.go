@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/terra-project/core/testutil"
+	"github.com/terra-project/core/x/mint"
+)
+
+func TestQueryIssuance(t *testing.T) {
+	cdc, _, _, _ := testutil.PrepareCmdTest()
+
+	queryIssuance := GetCmdQueryIssuance(cdc)
+
+	// Name check
+	require.Equal(t, mint.QueryIssuance, queryIssuance.Name())
+
+	// NoArg check
+	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.NoArgs)), testutil.FS(queryIssuance.Args))
+
+	// Check Flags
+	denomFlag := queryIssuance.Flag(flagDenom)
+	require.NotNil(t, denomFlag)
+	require.Equal(t, []string{"true"}, denomFlag.Annotations[cobra.BashCompOneRequiredFlag])
+
+	startDayFlag := queryIssuance.Flag(flagStartDay)
+	require.NotNil(t, startDayFlag)
+
+	endDayFlag := queryIssuance.Flag(flagEndDay)
+	require.NotNil(t, endDayFlag)
+}
+
+func TestQuerySeigniorage(t *testing.T) {
+	cdc, _, _, _ := testutil.PrepareCmdTest()
+
+	querySeigniorage := GetCmdQuerySeigniorage(cdc)
+
+	// Name check
+	require.Equal(t, mint.QuerySeigniorage, querySeigniorage.Name())
+
+	// NoArg check
+	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.NoArgs)), testutil.FS(querySeigniorage.Args))
+
+	// Check Flags
+	epochFlag := querySeigniorage.Flag(flagEpoch)
+	require.NotNil(t, epochFlag)
+}
+
+func TestQuerySupply(t *testing.T) {
+	cdc, _, _, _ := testutil.PrepareCmdTest()
+
+	querySupply := GetCmdQuerySupply(cdc)
+
+	// Name check
+	require.Equal(t, mint.QuerySupply, querySupply.Name())
+
+	// NoArg check
+	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.NoArgs)), testutil.FS(querySupply.Args))
+
+	// Check Flags
+	denomFlag := querySupply.Flag(flagDenom)
+	require.NotNil(t, denomFlag)
+}
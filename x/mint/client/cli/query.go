@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/terra-project/core/x/mint"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagDenom    = "denom"
+	flagStartDay = "start-day"
+	flagEndDay   = "end-day"
+	flagEpoch    = "epoch"
+)
+
+// GetCmdQueryIssuance implements the query issuance command.
+func GetCmdQueryIssuance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   mint.QueryIssuance,
+		Args:  cobra.NoArgs,
+		Short: "Query the daily issuance history of a denom",
+		Long: strings.TrimSpace(`
+Query the issuance of a denom at the end of each day in a range of days after genesis.
+Without a range, returns the issuance of today; without an end day, of the start day only.
+
+$ terracli query mint issuance --denom=ukrw --start-day=0 --end-day=14
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			denom := viper.GetString(flagDenom)
+			startDayStr := viper.GetString(flagStartDay)
+			endDayStr := viper.GetString(flagEndDay)
+
+			for _, dayStr := range []string{startDayStr, endDayStr} {
+				if len(dayStr) != 0 {
+					_, err := strconv.ParseInt(dayStr, 10, 64)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			if len(startDayStr) == 0 && len(endDayStr) != 0 {
+				return fmt.Errorf("--%s requires --%s", flagEndDay, flagStartDay)
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s/%s", mint.QuerierRoute, mint.QueryIssuance, denom, startDayStr, endDayStr), nil)
+			if err != nil {
+				return err
+			}
+
+			var issuance mint.QueryIssuanceResponse
+			cdc.MustUnmarshalJSON(res, &issuance)
+			return cliCtx.PrintOutput(issuance)
+		},
+	}
+
+	cmd.Flags().String(flagDenom, "", "the denom which you want to know the issuance of")
+	cmd.Flags().String(flagStartDay, "", "(optional) the first day after genesis to query; default is today")
+	cmd.Flags().String(flagEndDay, "", "(optional) the last day after genesis to query; default is the start day")
+
+	cmd.MarkFlagRequired(flagDenom)
+
+	return cmd
+}
+
+// GetCmdQuerySeigniorage implements the query seigniorage command.
+func GetCmdQuerySeigniorage(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   mint.QuerySeigniorage,
+		Args:  cobra.NoArgs,
+		Short: "Query the seigniorage of an epoch",
+		Long: strings.TrimSpace(`
+Query the Luna seigniorage of an epoch, which is the increase of Luna issuance over the epoch.
+
+$ terracli query mint seigniorage --epoch=14
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			epochStr := viper.GetString(flagEpoch)
+			if len(epochStr) != 0 {
+				if _, ok := sdk.NewIntFromString(epochStr); !ok {
+					return fmt.Errorf("the given epoch {%s} is not a valid format; epoch should be formatted as an integer", epochStr)
+				}
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", mint.QuerierRoute, mint.QuerySeigniorage, epochStr), nil)
+			if err != nil {
+				return err
+			}
+
+			var seigniorage mint.QuerySeigniorageResponse
+			cdc.MustUnmarshalJSON(res, &seigniorage)
+			return cliCtx.PrintOutput(seigniorage)
+		},
+	}
+
+	cmd.Flags().String(flagEpoch, "", "(optional) an epoch number which you wants to get seigniorage of; default is current epoch")
+	return cmd
+}
+
+// GetCmdQuerySupply implements the query supply command.
+func GetCmdQuerySupply(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   mint.QuerySupply,
+		Args:  cobra.NoArgs,
+		Short: "Query the current supply of all denoms",
+		Long: strings.TrimSpace(`
+Query the current total supply of all denoms, or of a single denom.
+
+$ terracli query mint supply --denom=ukrw
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			denom := viper.GetString(flagDenom)
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", mint.QuerierRoute, mint.QuerySupply, denom), nil)
+			if err != nil {
+				return err
+			}
+
+			var supply mint.QuerySupplyResponse
+			cdc.MustUnmarshalJSON(res, &supply)
+			return cliCtx.PrintOutput(supply)
+		},
+	}
+
+	cmd.Flags().String(flagDenom, "", "(optional) the denom which you want to know the supply of; default is all denoms")
+	return cmd
+}
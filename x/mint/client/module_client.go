@@ -0,0 +1,42 @@
+package client
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	mintCli "github.com/terra-project/core/x/mint/client/cli"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+// ModuleClient exports all client functionality from this module
+type ModuleClient struct {
+	storeKey string
+	cdc      *amino.Codec
+}
+
+func NewModuleClient(storeKey string, cdc *amino.Codec) ModuleClient {
+	return ModuleClient{storeKey, cdc}
+}
+
+// GetQueryCmd returns the cli query commands for this module
+func (mc ModuleClient) GetQueryCmd() *cobra.Command {
+	// Group mint queries under a subcommand
+	mintQueryCmd := &cobra.Command{
+		Use:   "mint",
+		Short: "Querying commands for the mint module",
+	}
+
+	mintQueryCmd.AddCommand(client.GetCommands(
+		mintCli.GetCmdQueryIssuance(mc.cdc),
+		mintCli.GetCmdQuerySeigniorage(mc.cdc),
+		mintCli.GetCmdQuerySupply(mc.cdc),
+	)...)
+
+	return mintQueryCmd
+}
+
+// GetTxCmd The mint module returns no TX commands.
+func (mc ModuleClient) GetTxCmd() *cobra.Command {
+	return &cobra.Command{Hidden: true}
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/terra-project/core/app"
+)
+
+const (
+	storeKey = string("mint")
+)
+
+var (
+	queryCmdList = map[string]bool{
+		"issuance":    true,
+		"seigniorage": true,
+		"supply":      true,
+	}
+)
+
+func TestQueryCmdInvariant(t *testing.T) {
+
+	cdc := app.MakeCodec()
+	mc := NewModuleClient(storeKey, cdc)
+
+	for _, cmd := range mc.GetQueryCmd().Commands() {
+		_, ok := queryCmdList[cmd.Name()]
+		require.True(t, ok)
+	}
+
+	require.Equal(t, len(queryCmdList), len(mc.GetQueryCmd().Commands()))
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/terra-project/core/x/mint"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(fmt.Sprintf("/mint/%s/{%s}", mint.QueryIssuance, RestDenom), queryIssuanceHandlerFunction(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/mint/%s/{%s}/{%s}", mint.QueryIssuance, RestDenom, RestStartDay), queryIssuanceHandlerFunction(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/mint/%s/{%s}/{%s}/{%s}", mint.QueryIssuance, RestDenom, RestStartDay, RestEndDay), queryIssuanceHandlerFunction(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/mint/%s", mint.QuerySeigniorage), querySeigniorageHandlerFunction(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/mint/%s/{%s}", mint.QuerySeigniorage, RestEpoch), querySeigniorageHandlerFunction(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/mint/%s", mint.QuerySupply), querySupplyHandlerFunction(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/mint/%s/{%s}", mint.QuerySupply, RestDenom), querySupplyHandlerFunction(cdc, cliCtx)).Methods("GET")
+}
+
+func queryIssuanceHandlerFunction(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		denom := vars[RestDenom]
+		startDayStr := vars[RestStartDay]
+		endDayStr := vars[RestEndDay]
+
+		for _, dayStr := range []string{startDayStr, endDayStr} {
+			if len(dayStr) != 0 {
+				_, err := strconv.ParseInt(dayStr, 10, 64)
+				if err != nil {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+			}
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s/%s", mint.QuerierRoute, mint.QueryIssuance, denom, startDayStr, endDayStr), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func querySeigniorageHandlerFunction(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		epochStr := vars[RestEpoch]
+
+		if len(epochStr) != 0 {
+			if _, ok := sdk.NewIntFromString(epochStr); !ok {
+				err := fmt.Errorf("the given epoch {%s} is not a valid format; epoch should be formatted as an integer", epochStr)
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", mint.QuerierRoute, mint.QuerySeigniorage, epochStr), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func querySupplyHandlerFunction(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		denom := vars[RestDenom]
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", mint.QuerierRoute, mint.QuerySupply, denom), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -0,0 +1,35 @@
+package mint
+
+import (
+	"github.com/terra-project/core/types/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker starts the clock counting days by block time at the transition height. A clock
+// carried over from an exported chain starts its day with the first block. Must run before any
+// module reads the day or the epoch.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	if clock, found := k.GetClock(ctx); found {
+		if clock.DayEnd == 0 {
+			clock.DayEnd = ctx.BlockHeader().Time.Unix() + util.SecondsPerDay
+			k.setClock(ctx, clock)
+		}
+		return
+	}
+
+	transitionHeight := k.GetParams(ctx).ClockTransitionHeight
+	if transitionHeight == 0 || ctx.BlockHeight() < transitionHeight {
+		return
+	}
+
+	k.setClock(ctx, util.NewClock(ctx))
+}
+
+// EndBlocker moves the clock past the current block. Must run after every module that acts at
+// the end of a day or an epoch.
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	if clock, found := k.GetClock(ctx); found {
+		k.setClock(ctx, clock.Tick(ctx))
+	}
+}
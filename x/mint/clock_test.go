@@ -0,0 +1,162 @@
+package mint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func withBlock(ctx sdk.Context, height int64, blockTime time.Time) sdk.Context {
+	return ctx.WithBlockHeader(abci.Header{Time: blockTime}).WithBlockHeight(height)
+}
+
+func TestClockTransition(t *testing.T) {
+	input := createTestInput(t)
+
+	transitionHeight := util.BlocksPerDay + 100
+	input.mintKeeper.SetParams(input.ctx, NewParams(transitionHeight))
+
+	// Days are counted by blocks until the transition height
+	ctx := withBlock(input.ctx, transitionHeight-1, time.Unix(0, 0))
+	BeginBlocker(ctx, input.mintKeeper)
+	_, found := input.mintKeeper.GetClock(ctx)
+	require.False(t, found)
+	require.Equal(t, sdk.OneInt(), input.mintKeeper.GetDay(ctx))
+	EndBlocker(ctx, input.mintKeeper)
+
+	// Blocks come every minute from then on; the day counted by blocks ends as it would have at
+	// six seconds per block
+	start := time.Unix(util.SecondsPerDay, 0)
+	blockTime := int64(60)
+	dayEnd := start.Unix() + (util.BlocksPerDay-100)*util.SecondsPerBlock
+
+	var dayLastBlocks, epochLastBlocks []int64
+	height := transitionHeight
+	for day := int64(1); day < util.DaysPerEpoch; {
+		now := start.Unix() + (height-transitionHeight)*blockTime
+		ctx = withBlock(input.ctx, height, time.Unix(now, 0))
+		BeginBlocker(ctx, input.mintKeeper)
+
+		require.Equal(t, sdk.NewInt(day), input.mintKeeper.GetDay(ctx))
+		require.Equal(t, sdk.ZeroInt(), input.mintKeeper.GetEpoch(ctx))
+
+		if input.mintKeeper.IsDayLastBlock(ctx) {
+			require.True(t, now >= dayEnd)
+			require.True(t, now-blockTime < dayEnd)
+
+			dayLastBlocks = append(dayLastBlocks, height)
+			dayEnd += util.SecondsPerDay
+			day++
+		}
+
+		if input.mintKeeper.IsEpochLastBlock(ctx) {
+			epochLastBlocks = append(epochLastBlocks, height)
+		}
+
+		EndBlocker(ctx, input.mintKeeper)
+		height++
+	}
+
+	require.Equal(t, int(util.DaysPerEpoch-1), len(dayLastBlocks))
+	require.Equal(t, []int64{dayLastBlocks[len(dayLastBlocks)-1]}, epochLastBlocks)
+
+	// The first block of the next epoch
+	ctx = withBlock(input.ctx, height, time.Unix(dayEnd-util.SecondsPerDay+1, 0))
+	require.Equal(t, sdk.NewInt(util.DaysPerEpoch), input.mintKeeper.GetDay(ctx))
+	require.Equal(t, sdk.OneInt(), input.mintKeeper.GetEpoch(ctx))
+}
+
+func TestClockHalt(t *testing.T) {
+	input := createTestInput(t)
+	input.mintKeeper.SetParams(input.ctx, NewParams(1))
+
+	ctx := withBlock(input.ctx, 1, time.Unix(0, 0))
+	BeginBlocker(ctx, input.mintKeeper)
+	clock, found := input.mintKeeper.GetClock(ctx)
+	require.True(t, found)
+	EndBlocker(ctx, input.mintKeeper)
+
+	// The chain halts for three days; the days it was halted for are not counted, but the next day
+	// still ends on the same time of day
+	ctx = withBlock(input.ctx, 2, time.Unix(clock.DayEnd+3*util.SecondsPerDay+10, 0))
+	BeginBlocker(ctx, input.mintKeeper)
+	require.True(t, input.mintKeeper.IsDayLastBlock(ctx))
+	EndBlocker(ctx, input.mintKeeper)
+
+	next, _ := input.mintKeeper.GetClock(ctx)
+	require.Equal(t, clock.Day+1, next.Day)
+	require.Equal(t, clock.DayEnd+4*util.SecondsPerDay, next.DayEnd)
+}
+
+func TestClockEstimateEpochLastBlock(t *testing.T) {
+	input := createTestInput(t)
+	ctx := withBlock(input.ctx, 0, time.Unix(0, 0))
+	clock := util.Clock{Day: util.DaysPerEpoch - 1, DayEnd: 10 * util.SecondsPerBlock}
+	require.Equal(t, int64(10), clock.EstimateEpochLastBlock(ctx))
+
+	clock = util.Clock{Day: 0, DayEnd: util.SecondsPerBlock}
+	require.Equal(t, 1+(util.DaysPerEpoch-1)*util.BlocksPerDay, clock.EstimateEpochLastBlock(ctx))
+}
+
+func TestClockRestart(t *testing.T) {
+	input := createTestInput(t)
+
+	// A clock exported for a restart, on the first day of the third epoch; the clock runs without a
+	// transition height
+	clock := util.Clock{Day: 2 * util.DaysPerEpoch}
+	issuance := []Issuance{
+		NewIssuance(assets.MicroLunaDenom, sdk.ZeroInt(), sdk.NewInt(1000)),
+		NewIssuance(assets.MicroLunaDenom, sdk.NewInt(3), sdk.NewInt(1500)),
+	}
+
+	genesis := NewGenesisState(DefaultParams(), &clock, issuance)
+	require.NoError(t, ValidateGenesis(genesis))
+	InitGenesis(input.ctx, input.mintKeeper, genesis)
+
+	// The first block starts the day
+	start := time.Unix(util.SecondsPerDay/2, 0)
+	ctx := withBlock(input.ctx, 1, start)
+	BeginBlocker(ctx, input.mintKeeper)
+
+	restarted, found := input.mintKeeper.GetClock(ctx)
+	require.True(t, found)
+	require.Equal(t, clock.Day, restarted.Day)
+	require.Equal(t, start.Unix()+util.SecondsPerDay, restarted.DayEnd)
+	require.Equal(t, sdk.NewInt(2), input.mintKeeper.GetEpoch(ctx))
+	require.False(t, input.mintKeeper.IsDayLastBlock(ctx))
+
+	// The issuance carries on from the history
+	require.Equal(t, sdk.NewInt(1500), input.mintKeeper.GetIssuance(ctx, assets.MicroLunaDenom, restarted.GetDay()))
+
+	exported := ExportGenesis(ctx, input.mintKeeper)
+	require.Equal(t, restarted, *exported.Clock)
+	require.Equal(t, DefaultParams(), exported.Params)
+}
+
+func TestClockPrepForZeroHeight(t *testing.T) {
+	input := createTestInput(t)
+	ctx := withBlock(input.ctx, 100, time.Unix(0, 0))
+
+	// A chain without a transition height keeps none; the clock alone carries the days on
+	input.mintKeeper.SetParams(ctx, DefaultParams())
+	PrepForZeroHeightGenesis(ctx, input.mintKeeper)
+	require.Equal(t, DefaultParams(), input.mintKeeper.GetParams(ctx))
+	_, found := input.mintKeeper.GetClock(ctx)
+	require.True(t, found)
+
+	// A transition height set is made relative to the export
+	input.mintKeeper.SetParams(ctx, NewParams(150))
+	PrepForZeroHeightGenesis(ctx, input.mintKeeper)
+	require.Equal(t, NewParams(50), input.mintKeeper.GetParams(ctx))
+
+	input.mintKeeper.SetParams(ctx, NewParams(10))
+	PrepForZeroHeightGenesis(ctx, input.mintKeeper)
+	require.Equal(t, NewParams(1), input.mintKeeper.GetParams(ctx))
+}
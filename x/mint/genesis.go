@@ -0,0 +1,152 @@
+package mint
+
+import (
+	"fmt"
+
+	"github.com/terra-project/core/types/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all mint state that must be provided at genesis
+type GenesisState struct {
+	Params   Params      `json:"params"`   // mint params
+	Clock    *util.Clock `json:"clock"`    // clock counting days by block time; nil until it starts
+	Issuance []Issuance  `json:"issuance"` // issuance history by day, kept along with the clock
+}
+
+// Issuance holds the issuance of a denom recorded for a single day
+type Issuance struct {
+	Denom  string  `json:"denom"`
+	Day    sdk.Int `json:"day"`
+	Amount sdk.Int `json:"amount"`
+}
+
+// NewIssuance constructs a new issuance entry
+func NewIssuance(denom string, day sdk.Int, amount sdk.Int) Issuance {
+	return Issuance{
+		Denom:  denom,
+		Day:    day,
+		Amount: amount,
+	}
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(params Params, clock *util.Clock, issuance []Issuance) GenesisState {
+	return GenesisState{
+		Params:   params,
+		Clock:    clock,
+		Issuance: issuance,
+	}
+}
+
+// DefaultGenesisState get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:   DefaultParams(),
+		Clock:    nil,
+		Issuance: []Issuance{},
+	}
+}
+
+// InitGenesis new mint genesis
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetParams(ctx, data.Params)
+
+	if data.Clock != nil {
+		keeper.setClock(ctx, *data.Clock)
+	}
+
+	for _, issuance := range data.Issuance {
+		keeper.setIssuance(ctx, issuance.Denom, issuance.Day, issuance.Amount)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper. A chain restarted from the
+// export carries on the days counted by block time, along with their issuance, whatever its clock
+// transition height. Days counted by blocks start over with the heights, and their issuance is read
+// again from the supply.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	params := keeper.GetParams(ctx)
+
+	clock, found := keeper.GetClock(ctx)
+	if !found {
+		return NewGenesisState(params, nil, []Issuance{})
+	}
+
+	issuance := []Issuance{}
+	keeper.IterateIssuance(ctx, func(denom string, day sdk.Int, amount sdk.Int) (stop bool) {
+		issuance = append(issuance, NewIssuance(denom, day, amount))
+		return false
+	})
+
+	return NewGenesisState(params, &clock, issuance)
+}
+
+// PrepForZeroHeightGenesis moves the clock to the first day of the next epoch, for a chain restarted
+// from an export at height zero; the other modules settle the current epoch before. The restarted
+// chain counts days by block time from its first block, which starts the day, so that it carries on
+// the days, the epochs and the issuance history of this one. A clock transition height that is set
+// is made relative to the export; one already reached becomes 1.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	nextEpochDay := keeper.GetEpoch(ctx).AddRaw(1).MulRaw(util.DaysPerEpoch)
+	keeper.setClock(ctx, util.Clock{Day: nextEpochDay.Int64()})
+
+	params := keeper.GetParams(ctx)
+	if params.ClockTransitionHeight == 0 {
+		return
+	}
+
+	params.ClockTransitionHeight -= ctx.BlockHeight()
+	if params.ClockTransitionHeight < 1 {
+		params.ClockTransitionHeight = 1
+	}
+	keeper.SetParams(ctx, params)
+}
+
+// ValidateGenesis validates the provided mint genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	if data.Clock == nil {
+		if len(data.Issuance) != 0 {
+			return fmt.Errorf("mint issuance history can only be kept along with the clock")
+		}
+	} else if data.Clock.Day < 0 || data.Clock.DayEnd < 0 {
+		return fmt.Errorf("mint clock must not be negative: %s", data.Clock)
+	}
+
+	days := make(map[string]bool)
+	for _, issuance := range data.Issuance {
+		if len(issuance.Denom) == 0 {
+			return fmt.Errorf("mint issuance without a denom")
+		}
+
+		if issuance.Day.IsNegative() {
+			return fmt.Errorf("mint issuance day of %s must be >= 0, is %s", issuance.Denom, issuance.Day)
+		}
+
+		if issuance.Amount.IsNegative() {
+			return fmt.Errorf("mint issuance of %s on day %s must be >= 0, is %s",
+				issuance.Denom, issuance.Day, issuance.Amount)
+		}
+
+		key := fmt.Sprintf("%s:%s", issuance.Denom, issuance.Day)
+		if days[key] {
+			return fmt.Errorf("mint issuance of %s on day %s is duplicated", issuance.Denom, issuance.Day)
+		}
+		days[key] = true
+	}
+
+	return validateParams(data.Params)
+}
+
+// InitSupply initializes the supply of all denoms from the balances of accounts and pools, and
+// records it as the issuance of the genesis day unless the genesis carries the issuance history.
+// Must be called once, after every module genesis is loaded.
+func InitSupply(ctx sdk.Context, keeper Keeper, fck FeeCollectionKeeper, dk DistributionKeeper) {
+	supply, _ := countSupply(ctx, keeper, fck, dk).TruncateDecimal()
+	for _, coin := range supply {
+		keeper.SetSupply(ctx, coin.Denom, coin.Amount)
+		keeper.GetIssuance(ctx, coin.Denom, sdk.ZeroInt())
+	}
+}
@@ -0,0 +1,71 @@
+package mint
+
+import (
+	"fmt"
+
+	"github.com/terra-project/core/types/assets"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+// RegisterInvariants registers all mint invariants
+func RegisterInvariants(c *crisis.Keeper, k Keeper, fck FeeCollectionKeeper, dk DistributionKeeper) {
+	c.RegisterRoute(StoreKey, "supply", SupplyInvariant(k, fck, dk))
+}
+
+// SupplyInvariant checks that the supply of every denom matches the coins held by
+// accounts, the fee collector, the distribution module and the staking pool
+func SupplyInvariant(k Keeper, fck FeeCollectionKeeper, dk DistributionKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		expected := countSupply(ctx, k, fck, dk)
+		supply := k.GetTotalSupply(ctx)
+
+		for _, coin := range supply {
+			if !coin.Amount.ToDec().Equal(expected.AmountOf(coin.Denom)) {
+				return fmt.Errorf("supply of %s does not match the holdings: %s, expected %s",
+					coin.Denom, coin.Amount, expected.AmountOf(coin.Denom))
+			}
+		}
+
+		for _, coin := range expected {
+			if !supply.AmountOf(coin.Denom).ToDec().Equal(coin.Amount) {
+				return fmt.Errorf("supply of %s does not match the holdings: %s, expected %s",
+					coin.Denom, supply.AmountOf(coin.Denom), coin.Amount)
+			}
+		}
+
+		return nil
+	}
+}
+
+// countSupply sums the coins held by accounts, the fee collector and the distribution module.
+// Luna is counted from the staking pool, which tracks bonded and unbonded tokens alike.
+func countSupply(ctx sdk.Context, k Keeper, fck FeeCollectionKeeper, dk DistributionKeeper) sdk.DecCoins {
+	coins := fck.GetCollectedFees(ctx)
+	k.ak.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+		coins = coins.Add(acc.GetCoins())
+		return false
+	})
+
+	holdings := sdk.NewDecCoins(coins).Add(dk.GetFeePoolCommunityCoins(ctx))
+	k.sk.IterateValidators(ctx, func(_ int64, val sdk.Validator) (stop bool) {
+		holdings = holdings.Add(dk.GetValidatorOutstandingRewardsCoins(ctx, val.GetOperator()))
+		return false
+	})
+
+	supply := sdk.DecCoins{}
+	for _, coin := range holdings {
+		if coin.Denom != assets.MicroLunaDenom {
+			supply = append(supply, coin)
+		}
+	}
+
+	pool := k.sk.GetPool(ctx)
+	if lunaSupply := pool.BondedTokens.Add(pool.NotBondedTokens); lunaSupply.IsPositive() {
+		supply = supply.Add(sdk.DecCoins{sdk.NewDecCoin(assets.MicroLunaDenom, lunaSupply)})
+	}
+
+	return supply
+}
@@ -1,6 +1,9 @@
 package mint
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/types/util"
 
@@ -8,11 +11,21 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 )
 
-// StoreKey is string representation of the store key for mint
-const StoreKey = "mint"
+// nolint
+const (
+	// ModuleName is the name of the mint module
+	ModuleName = "mint"
+
+	// StoreKey is string representation of the store key for mint
+	StoreKey = ModuleName
+
+	// QuerierRoute is the querier route for mint
+	QuerierRoute = ModuleName
+)
 
 // Keeper is an instance of the Mint keeper module.
 // Adds / subtracts balances from accounts and maintains a global state
@@ -23,22 +36,76 @@ type Keeper struct {
 	sk  staking.Keeper
 	bk  bank.Keeper
 	ak  auth.AccountKeeper
+
+	paramSpace params.Subspace
+
+	permissions *permissionRegistry // Issuance permissions of each module, shared by all copies of the keeper
 }
 
 // NewKeeper creates a new instance of the mint module.
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, sk staking.Keeper, bk bank.Keeper, ak auth.AccountKeeper) Keeper {
+// The returned keeper is unrestricted; modules must be handed the keeper returned by ForModule.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, sk staking.Keeper, bk bank.Keeper, ak auth.AccountKeeper,
+	paramspace params.Subspace) Keeper {
 	return Keeper{
-		cdc: cdc,
-		key: key,
-		sk:  sk,
-		bk:  bk,
-		ak:  ak,
+		cdc:         cdc,
+		key:         key,
+		sk:          sk,
+		bk:          bk,
+		ak:          ak,
+		paramSpace:  paramspace.WithKeyTable(paramKeyTable()),
+		permissions: newPermissionRegistry(),
 	}
 }
 
-// Mint credits {coin} to the {recipient} account, and reflects the increase in issuance
-func (k Keeper) Mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) (err sdk.Error) {
+// RegisterPermission registers the issuance operations and denoms {module} is allowed.
+// Permissions can only be registered while the app is wired, before SealPermissions.
+func (k Keeper) RegisterPermission(module string, permission Permission) {
+	if k.permissions.sealed {
+		panic(fmt.Sprintf("mint permissions are sealed, cannot register module %s", module))
+	}
+
+	if _, exists := k.permissions.entries[module]; exists {
+		panic(fmt.Sprintf("mint permission of module %s is already registered", module))
+	}
+
+	k.permissions.entries[module] = NewPermission(permission.Operations, permission.Denoms)
+}
+
+// SealPermissions closes the registration of permissions; must be called once the app is wired
+func (k Keeper) SealPermissions() {
+	k.permissions.sealed = true
+}
+
+// GetPermission returns a copy of the permission registered for {module}
+func (k Keeper) GetPermission(module string) (permission Permission, ok bool) {
+	permission, ok = k.permissions.entries[module]
+	if ok {
+		permission = NewPermission(permission.Operations, permission.Denoms)
+	}
+	return
+}
+
+// ForModule returns the keeper to hand to {module}, which checks every issuance change against
+// the permission of the module. A module without a registered permission can only read.
+func (k Keeper) ForModule(module string) ModuleKeeper {
+	return ModuleKeeper{
+		keeper: k,
+		module: module,
+	}
+}
+
+// authorize returns an error if {module} is not allowed {operation} on {denom}
+func (k Keeper) authorize(module string, operation string, denom string) sdk.Error {
+	if permission, ok := k.permissions.entries[module]; ok && permission.Allows(operation, denom) {
+		return nil
+	}
+
+	return sdk.ErrUnauthorized(fmt.Sprintf("module %s is not allowed to %s %s", module, operation, denom))
+}
 
+// mint credits {coin} to the {recipient} account, and reflects the increase in issuance. Modules
+// mint through the ModuleKeeper returned by ForModule.
+func (k Keeper) mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) (err sdk.Error) {
 	_, _, err = k.bk.AddCoins(ctx, recipient, sdk.Coins{coin})
 	if err != nil {
 		return err
@@ -50,11 +117,12 @@ func (k Keeper) Mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) (
 		k.sk.SetPool(ctx, pool)
 	}
 
-	return k.ChangeIssuance(ctx, coin.Denom, coin.Amount)
+	return k.changeIssuance(ctx, coin.Denom, coin.Amount)
 }
 
-// Burn deducts {coin} from the {payer} account, and reflects the decrease in issuance
-func (k Keeper) Burn(ctx sdk.Context, payer sdk.AccAddress, coin sdk.Coin) (err sdk.Error) {
+// burn deducts {coin} from the {payer} account, and reflects the decrease in issuance. Modules
+// burn through the ModuleKeeper returned by ForModule.
+func (k Keeper) burn(ctx sdk.Context, payer sdk.AccAddress, coin sdk.Coin) (err sdk.Error) {
 	_, _, err = k.bk.SubtractCoins(ctx, payer, sdk.Coins{coin})
 	if err != nil {
 		return err
@@ -66,15 +134,28 @@ func (k Keeper) Burn(ctx sdk.Context, payer sdk.AccAddress, coin sdk.Coin) (err
 		k.sk.SetPool(ctx, pool)
 	}
 
-	return k.ChangeIssuance(ctx, coin.Denom, coin.Amount.Neg())
+	return k.changeIssuance(ctx, coin.Denom, coin.Amount.Neg())
 }
 
-// ChangeIssuance updates the issuance to reflect
+// ChangeIssuance reflects {delta} in the issuance for coins moved in or out of a module pool
+// without going through an account, e.g. swap fees rewarded to validators
 func (k Keeper) ChangeIssuance(ctx sdk.Context, denom string, delta sdk.Int) (err sdk.Error) {
+	return k.changeIssuance(ctx, denom, delta)
+}
+
+// changeIssuance updates the supply and the issuance of the current day to reflect {delta}
+func (k Keeper) changeIssuance(ctx sdk.Context, denom string, delta sdk.Int) (err sdk.Error) {
+	newSupply := k.GetSupply(ctx, denom).Add(delta)
+	if newSupply.IsNegative() {
+		return sdk.ErrInternal("Supply should never fall below 0")
+	}
+
+	k.SetSupply(ctx, denom, newSupply)
+
 	store := ctx.KVStore(k.key)
-	curDay := sdk.NewInt(ctx.BlockHeight() / util.BlocksPerDay)
+	curDay := k.GetDay(ctx)
 
-	// If genesis issuance is not on disk, GetIssuance will do a fresh read of account balances
+	// If genesis issuance is not on disk, GetIssuance will read the supply updated above
 	// and the change in issuance should be reported automatically.
 	if !store.Has(keyIssuance(denom, sdk.ZeroInt())) {
 		k.GetIssuance(ctx, denom, curDay)
@@ -96,7 +177,7 @@ func (k Keeper) ChangeIssuance(ctx sdk.Context, denom string, delta sdk.Int) (er
 
 // GetIssuance fetches the total issuance count of the coin matching {denom}. If the {day} applies
 // to a previous period, fetches the last stored snapshot issuance of the coin. For virgin calls,
-// iterates through the accountkeeper and computes the genesis issuance.
+// reads the genesis issuance from the supply.
 func (k Keeper) GetIssuance(ctx sdk.Context, denom string, day sdk.Int) (issuance sdk.Int) {
 	store := ctx.KVStore(k.key)
 
@@ -104,14 +185,9 @@ func (k Keeper) GetIssuance(ctx sdk.Context, denom string, day sdk.Int) (issuanc
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &issuance)
 	} else {
 		// Genesis epoch; nothing exists in store so we must read it
-		// from accountkeeper
+		// from the supply
 		if day.LTE(sdk.ZeroInt()) {
-			issuance = sdk.ZeroInt()
-			countIssuance := func(acc auth.Account) (stop bool) {
-				issuance = issuance.Add(acc.GetCoins().AmountOf(denom))
-				return false
-			}
-			k.ak.IterateAccounts(ctx, countIssuance)
+			issuance = k.GetSupply(ctx, denom)
 		} else {
 			// Fetch the issuance snapshot of the previous epoch
 			issuance = k.GetIssuance(ctx, denom, day.Sub(sdk.OneInt()))
@@ -126,19 +202,97 @@ func (k Keeper) GetIssuance(ctx sdk.Context, denom string, day sdk.Int) (issuanc
 	return
 }
 
+// setIssuance stores the issuance of the coin matching {denom} on {day}; used to restore history at genesis
+func (k Keeper) setIssuance(ctx sdk.Context, denom string, day sdk.Int, issuance sdk.Int) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(issuance)
+	store.Set(keyIssuance(denom, day), bz)
+}
+
+// IterateIssuance iterates the issuance of all denoms stored for each day
+func (k Keeper) IterateIssuance(ctx sdk.Context, handler func(denom string, day sdk.Int, issuance sdk.Int) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, append(prefixIssuance, ':'))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		parts := strings.Split(string(iter.Key()), ":")
+		if len(parts) != 3 {
+			continue
+		}
+
+		day, ok := sdk.NewIntFromString(parts[2])
+		if !ok {
+			continue
+		}
+
+		var issuance sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &issuance)
+
+		if handler(parts[1], day, issuance) {
+			break
+		}
+	}
+}
+
+// GetSupply returns the total supply of the coin matching {denom}
+func (k Keeper) GetSupply(ctx sdk.Context, denom string) sdk.Int {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(keySupply(denom))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+
+	var supply sdk.Coin
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &supply)
+	return supply.Amount
+}
+
+// SetSupply stores the total supply of the coin matching {denom}
+func (k Keeper) SetSupply(ctx sdk.Context, denom string, amount sdk.Int) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(sdk.NewCoin(denom, amount))
+	store.Set(keySupply(denom), bz)
+}
+
+// IterateSupply iterates the supply of all denoms in the store
+func (k Keeper) IterateSupply(ctx sdk.Context, handler func(supply sdk.Coin) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefixSupply)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var supply sdk.Coin
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &supply)
+
+		if handler(supply) {
+			break
+		}
+	}
+}
+
+// GetTotalSupply returns the supply of all denoms
+func (k Keeper) GetTotalSupply(ctx sdk.Context) (supply sdk.Coins) {
+	supply = sdk.Coins{}
+	k.IterateSupply(ctx, func(coin sdk.Coin) (stop bool) {
+		if coin.IsPositive() {
+			supply = append(supply, coin)
+		}
+		return false
+	})
+
+	return supply.Sort()
+}
+
 // PeekEpochSeigniorage retrieves the size of the seigniorage pool at epoch
 func (k Keeper) PeekEpochSeigniorage(ctx sdk.Context, epoch sdk.Int) (epochSeigniorage sdk.Int) {
 
-	daysPerEpoch := util.BlocksPerEpoch / util.BlocksPerDay
-	epochLastDay := epoch.Add(sdk.OneInt()).MulRaw(daysPerEpoch).Sub(sdk.OneInt())
+	epochLastDay := epoch.Add(sdk.OneInt()).MulRaw(util.DaysPerEpoch).Sub(sdk.OneInt())
 
-	//fmt.Println(epochLastDay)
-	today := sdk.NewInt(ctx.BlockHeight() / util.BlocksPerDay)
+	today := k.GetDay(ctx)
 	if epochLastDay.GT(today) {
 		epochLastDay = today
 	}
 
-	prevEpochLastDay := epochLastDay.SubRaw(daysPerEpoch)
+	prevEpochLastDay := epochLastDay.SubRaw(util.DaysPerEpoch)
 	if prevEpochLastDay.IsNegative() {
 		prevEpochLastDay = sdk.ZeroInt()
 	}
@@ -153,3 +307,77 @@ func (k Keeper) PeekEpochSeigniorage(ctx sdk.Context, epoch sdk.Int) (epochSeign
 
 	return
 }
+
+//-----------------------------------
+// Params logic
+
+// GetParams get mint params from the global param store. Chains started before the params existed
+// read the defaults.
+func (k Keeper) GetParams(ctx sdk.Context) Params {
+	resultParams := DefaultParams()
+	k.paramSpace.GetIfExists(ctx, paramStoreKeyParams, &resultParams)
+	return resultParams
+}
+
+// SetParams set mint params from the global param store
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.Set(ctx, paramStoreKeyParams, &params)
+}
+
+//-----------------------------------
+// Clock logic
+
+// GetClock returns the clock counting days by block time, if it has started
+func (k Keeper) GetClock(ctx sdk.Context) (clock util.Clock, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(keyClock)
+	if bz == nil {
+		return
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &clock)
+	return clock, true
+}
+
+// setClock stores the clock counting days by block time
+func (k Keeper) setClock(ctx sdk.Context, clock util.Clock) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(clock)
+	store.Set(keyClock, bz)
+}
+
+// GetDay returns the current day, starting from 0; counted by block time once the clock has started
+func (k Keeper) GetDay(ctx sdk.Context) sdk.Int {
+	if clock, found := k.GetClock(ctx); found {
+		return clock.GetDay()
+	}
+
+	return sdk.NewInt(ctx.BlockHeight() / util.BlocksPerDay)
+}
+
+// GetEpoch returns the current epoch, starting from 0; counted by block time once the clock has started
+func (k Keeper) GetEpoch(ctx sdk.Context) sdk.Int {
+	if clock, found := k.GetClock(ctx); found {
+		return clock.GetEpoch()
+	}
+
+	return util.GetEpoch(ctx)
+}
+
+// IsDayLastBlock returns true if the current block ends the day
+func (k Keeper) IsDayLastBlock(ctx sdk.Context) bool {
+	if clock, found := k.GetClock(ctx); found {
+		return clock.IsDayLastBlock(ctx)
+	}
+
+	return util.IsPeriodLastBlock(ctx, util.BlocksPerDay)
+}
+
+// IsEpochLastBlock returns true if the current block ends the epoch
+func (k Keeper) IsEpochLastBlock(ctx sdk.Context) bool {
+	if clock, found := k.GetClock(ctx); found {
+		return clock.IsEpochLastBlock(ctx)
+	}
+
+	return util.IsPeriodLastBlock(ctx, util.BlocksPerEpoch)
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // nolint
 var (
 	prefixIssuance        = []byte("issuance")
 	prefixSeignioragePool = []byte("seigniorage_pool")
+	prefixSupply          = []byte("supply")
+	keyClock              = []byte("clock")
+
+	paramStoreKeyParams = []byte("params")
 )
 
 func keyIssuance(denom string, day sdk.Int) []byte {
@@ -19,3 +24,13 @@ func keyIssuance(denom string, day sdk.Int) []byte {
 func keySeignioragePool(epoch sdk.Int) []byte {
 	return []byte(fmt.Sprintf("%s:%s", prefixSeignioragePool, epoch))
 }
+
+func keySupply(denom string) []byte {
+	return []byte(fmt.Sprintf("%s:%s", prefixSupply, denom))
+}
+
+func paramKeyTable() params.KeyTable {
+	return params.NewKeyTable(
+		paramStoreKeyParams, Params{},
+	)
+}
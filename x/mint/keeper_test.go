@@ -22,6 +22,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
@@ -36,10 +37,12 @@ var (
 )
 
 type testInput struct {
-	ctx        sdk.Context
-	accKeeper  auth.AccountKeeper
-	bankKeeper bank.Keeper
-	mintKeeper Keeper
+	ctx         sdk.Context
+	accKeeper   auth.AccountKeeper
+	bankKeeper  bank.Keeper
+	feeKeeper   auth.FeeCollectionKeeper
+	distrKeeper distr.Keeper
+	mintKeeper  Keeper
 }
 
 func newTestCodec() *codec.Codec {
@@ -60,6 +63,9 @@ func createTestInput(t *testing.T) testInput {
 	keyMint := sdk.NewKVStoreKey(StoreKey)
 	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
 	tKeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
+	tKeyDistr := sdk.NewTransientStoreKey(distr.TStoreKey)
+	keyFeeCollection := sdk.NewKVStoreKey(auth.FeeStoreKey)
 
 	cdc := newTestCodec()
 	db := dbm.NewMemDB()
@@ -72,6 +78,9 @@ func createTestInput(t *testing.T) testInput {
 	ms.MountStoreWithDB(keyMint, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(tKeyStaking, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyDistr, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
 
 	require.NoError(t, ms.LoadLatestVersion())
 
@@ -99,12 +108,24 @@ func createTestInput(t *testing.T) testInput {
 	stakingKeeper.SetPool(ctx, staking.InitialPool())
 	stakingKeeper.SetParams(ctx, staking.DefaultParams())
 
+	feeCollectionKeeper := auth.NewFeeCollectionKeeper(
+		cdc,
+		keyFeeCollection,
+	)
+
+	distrKeeper := distr.NewKeeper(
+		cdc, keyDistr, paramsKeeper.Subspace(distr.DefaultParamspace),
+		bankKeeper, &stakingKeeper, feeCollectionKeeper, distr.DefaultCodespace,
+	)
+	distrKeeper.SetFeePool(ctx, distr.InitialFeePool())
+
 	mintKeeper := NewKeeper(
 		cdc,
 		keyMint,
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(DefaultParamspace),
 	)
 
 	for _, addr := range addrs {
@@ -112,7 +133,9 @@ func createTestInput(t *testing.T) testInput {
 		require.NoError(t, err)
 	}
 
-	return testInput{ctx, accKeeper, bankKeeper, mintKeeper}
+	InitSupply(ctx, mintKeeper, feeCollectionKeeper, distrKeeper)
+
+	return testInput{ctx, accKeeper, bankKeeper, feeCollectionKeeper, distrKeeper, mintKeeper}
 }
 
 func TestKeeperIssuance(t *testing.T) {
@@ -159,33 +182,143 @@ func TestKeeperMintBurn(t *testing.T) {
 
 	// Minting new coins results in an issuance increase
 	increment := sdk.NewInt(10).MulRaw(assets.MicroUnit)
-	err := input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, increment))
+	err := input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, increment))
 	require.Nil(t, err)
 	newIssuance := input.mintKeeper.GetIssuance(input.ctx, assets.MicroSDRDenom, curDay)
 	require.Equal(t, issuance.Add(increment), newIssuance)
 
 	// Burning new coins results in an issuance decrease
 	decrement := sdk.NewInt(10).MulRaw(assets.MicroUnit)
-	err = input.mintKeeper.Burn(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, decrement))
+	err = input.mintKeeper.burn(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, decrement))
 	require.Nil(t, err)
 	newIssuance = input.mintKeeper.GetIssuance(input.ctx, assets.MicroSDRDenom, curDay)
 	require.Equal(t, issuance, newIssuance)
 
 	// Burning new coins errors if requested to burn too much
 	decrement = sdk.NewInt(100000).MulRaw(assets.MicroUnit)
-	err = input.mintKeeper.Burn(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, decrement))
+	err = input.mintKeeper.burn(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, decrement))
 	require.NotNil(t, err)
 	newIssuance = input.mintKeeper.GetIssuance(input.ctx, assets.MicroSDRDenom, curDay)
 	require.Equal(t, issuance, newIssuance)
 }
 
+func TestKeeperSupply(t *testing.T) {
+	input := createTestInput(t)
+	invariant := SupplyInvariant(input.mintKeeper, input.feeKeeper, input.distrKeeper)
+
+	// Genesis supply is read from the account balances
+	require.Equal(t, uSDRAmount.MulRaw(3), input.mintKeeper.GetSupply(input.ctx, assets.MicroSDRDenom))
+	require.Nil(t, invariant(input.ctx))
+
+	// Minting and burning are reflected in the supply
+	amt := sdk.NewInt(10).MulRaw(assets.MicroUnit)
+	err := input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, amt))
+	require.Nil(t, err)
+	err = input.mintKeeper.burn(input.ctx, addrs[1], sdk.NewCoin(assets.MicroSDRDenom, amt))
+	require.Nil(t, err)
+
+	require.Equal(t, amt, input.mintKeeper.GetSupply(input.ctx, assets.MicroLunaDenom))
+	require.Equal(t, uSDRAmount.MulRaw(3).Sub(amt), input.mintKeeper.GetSupply(input.ctx, assets.MicroSDRDenom))
+	require.Equal(t, sdk.Coins{
+		sdk.NewCoin(assets.MicroLunaDenom, amt),
+		sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount.MulRaw(3).Sub(amt)),
+	}, input.mintKeeper.GetTotalSupply(input.ctx))
+	require.Nil(t, invariant(input.ctx))
+
+	// Burning more than the balance leaves the supply untouched
+	err = input.mintKeeper.burn(input.ctx, addrs[1], sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount))
+	require.NotNil(t, err)
+	require.Equal(t, uSDRAmount.MulRaw(3).Sub(amt), input.mintKeeper.GetSupply(input.ctx, assets.MicroSDRDenom))
+
+	// Coins moved into the fee collector are still counted
+	_, _, err = input.bankKeeper.SubtractCoins(input.ctx, addrs[2], sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, amt)})
+	require.Nil(t, err)
+	input.feeKeeper.AddCollectedFees(input.ctx, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, amt)})
+	require.Nil(t, invariant(input.ctx))
+
+	// Coins added to the community pool are caught until the issuance is changed
+	feePool := input.distrKeeper.GetFeePool(input.ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(sdk.NewDecCoins(sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, amt)}))
+	input.distrKeeper.SetFeePool(input.ctx, feePool)
+	require.NotNil(t, invariant(input.ctx))
+
+	err = input.mintKeeper.ChangeIssuance(input.ctx, assets.MicroSDRDenom, amt)
+	require.Nil(t, err)
+	require.Nil(t, invariant(input.ctx))
+}
+
+func TestKeeperPermissions(t *testing.T) {
+	input := createTestInput(t)
+	amt := sdk.NewInt(10).MulRaw(assets.MicroUnit)
+	sdrCoin := sdk.NewCoin(assets.MicroSDRDenom, amt)
+	lunaCoin := sdk.NewCoin(assets.MicroLunaDenom, amt)
+
+	input.mintKeeper.RegisterPermission("market", NewPermission([]string{OperationMint, OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+	input.mintKeeper.RegisterPermission("budget", NewPermission([]string{OperationMint}, []string{assets.MicroSDRDenom}))
+	input.mintKeeper.RegisterPermission("oracle", NewPermission([]string{OperationPool}, assets.TerraDenoms))
+
+	// Registering a module twice is a programming error
+	require.Panics(t, func() {
+		input.mintKeeper.RegisterPermission("market", NewPermission([]string{OperationMint}, assets.TerraDenoms))
+	})
+
+	// Once sealed, no permission can be registered
+	input.mintKeeper.SealPermissions()
+	require.Panics(t, func() {
+		input.mintKeeper.RegisterPermission("treasury", NewPermission([]string{OperationMint}, assets.TerraDenoms))
+	})
+
+	// Allowed operations go through
+	market := input.mintKeeper.ForModule("market")
+	require.Nil(t, market.Mint(input.ctx, addrs[0], lunaCoin))
+	require.Nil(t, market.Burn(input.ctx, addrs[0], sdrCoin))
+
+	budget := input.mintKeeper.ForModule("budget")
+	require.Nil(t, budget.Mint(input.ctx, addrs[0], sdrCoin))
+
+	oracle := input.mintKeeper.ForModule("oracle")
+	require.Nil(t, oracle.ChangeIssuance(input.ctx, assets.MicroSDRDenom, amt))
+
+	// Operations or denoms outside the permission are rejected, and leave balances and supply untouched
+	balance := input.accKeeper.GetAccount(input.ctx, addrs[0]).GetCoins()
+	supply := input.mintKeeper.GetTotalSupply(input.ctx)
+
+	unknown := input.mintKeeper.ForModule("unknown")
+	for _, err := range []sdk.Error{
+		market.ChangeIssuance(input.ctx, assets.MicroSDRDenom, amt),
+		budget.Mint(input.ctx, addrs[0], lunaCoin),
+		budget.Burn(input.ctx, addrs[0], sdrCoin),
+		oracle.Mint(input.ctx, addrs[0], sdrCoin),
+		oracle.ChangeIssuance(input.ctx, assets.MicroLunaDenom, amt),
+		unknown.Mint(input.ctx, addrs[0], sdrCoin),
+		unknown.Burn(input.ctx, addrs[0], sdrCoin),
+		unknown.ChangeIssuance(input.ctx, assets.MicroSDRDenom, amt),
+	} {
+		require.NotNil(t, err)
+		require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	}
+
+	require.Equal(t, balance, input.accKeeper.GetAccount(input.ctx, addrs[0]).GetCoins())
+	require.Equal(t, supply, input.mintKeeper.GetTotalSupply(input.ctx))
+
+	// Permissions are shared by every copy of the keeper, and handed out as copies
+	permission, ok := input.mintKeeper.GetPermission("budget")
+	require.True(t, ok)
+	require.True(t, permission.Allows(OperationMint, assets.MicroSDRDenom))
+	require.False(t, permission.Allows(OperationMint, assets.MicroLunaDenom))
+
+	permission.Denoms[0] = assets.MicroLunaDenom
+	require.NotNil(t, budget.Mint(input.ctx, addrs[0], lunaCoin))
+}
+
 func TestKeeperSeigniorage(t *testing.T) {
 	input := createTestInput(t)
 
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(100)))
+	input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(100)))
 	input.mintKeeper.PeekEpochSeigniorage(input.ctx, sdk.NewInt(0))
 
-	input.mintKeeper.Mint(input.ctx.WithBlockHeight(util.BlocksPerEpoch-1), addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(100)))
+	input.mintKeeper.mint(input.ctx.WithBlockHeight(util.BlocksPerEpoch-1), addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(100)))
 	seigniorage := input.mintKeeper.PeekEpochSeigniorage(input.ctx.WithBlockHeight(util.BlocksPerEpoch), sdk.NewInt(0))
 
 	require.Equal(t, sdk.NewInt(100), seigniorage)
@@ -199,7 +332,7 @@ func TestKeeperMintStress(t *testing.T) {
 	epochDelta := int64(0)
 
 	// Genesis mint
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(balance)))
+	input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(balance)))
 
 	for day := int64(0); day < 100; day++ {
 		input.ctx = input.ctx.WithBlockHeight(day * util.BlocksPerDay)
@@ -208,14 +341,14 @@ func TestKeeperMintStress(t *testing.T) {
 
 		switch option {
 		case 0: // mint
-			err := input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(amt)))
+			err := input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(amt)))
 			require.Nil(t, err)
 
 			balance += amt
 			epochDelta += amt
 			break
 		case 1: // burn
-			err := input.mintKeeper.Burn(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(amt)))
+			err := input.mintKeeper.burn(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(amt)))
 			require.Nil(t, err)
 
 			balance -= amt
@@ -0,0 +1,114 @@
+package mint
+
+import (
+	"encoding/json"
+
+	"github.com/terra-project/core/types/module"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+)
+
+var (
+	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule      = AppModule{}
+)
+
+// moduleCdc encodes the genesis of the mint module
+var moduleCdc = codec.New()
+
+func init() {
+	codec.RegisterCrypto(moduleCdc)
+}
+
+// AppModuleBasic is the app module basics of the mint module
+type AppModuleBasic struct{}
+
+// Name returns the name of the module
+func (AppModuleBasic) Name() string {
+	return ModuleName
+}
+
+// RegisterCodec registers the types of the module; it has none
+func (AppModuleBasic) RegisterCodec(_ *codec.Codec) {}
+
+// ValidateGenesis validates the genesis of the module
+func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
+	var data GenesisState
+	if err := moduleCdc.UnmarshalJSON(bz, &data); err != nil {
+		return err
+	}
+
+	return ValidateGenesis(data)
+}
+
+// AppModule is the app module of the mint module
+type AppModule struct {
+	AppModuleBasic
+	keeper Keeper
+	fck    FeeCollectionKeeper
+	dk     DistributionKeeper
+}
+
+// NewAppModule creates a new AppModule object
+func NewAppModule(keeper Keeper, fck FeeCollectionKeeper, dk DistributionKeeper) AppModule {
+	return AppModule{
+		AppModuleBasic: AppModuleBasic{},
+		keeper:         keeper,
+		fck:            fck,
+		dk:             dk,
+	}
+}
+
+// RegisterInvariants registers the invariants of the module
+func (am AppModule) RegisterInvariants(ck *crisis.Keeper) {
+	RegisterInvariants(ck, am.keeper, am.fck, am.dk)
+}
+
+// Route returns no message route; the module has no messages
+func (AppModule) Route() string {
+	return ""
+}
+
+// NewHandler returns no message handler; the module has no messages
+func (AppModule) NewHandler() sdk.Handler {
+	return nil
+}
+
+// QuerierRoute returns the query route of the module
+func (AppModule) QuerierRoute() string {
+	return QuerierRoute
+}
+
+// NewQuerierHandler returns the querier of the module
+func (am AppModule) NewQuerierHandler() sdk.Querier {
+	return NewQuerier(am.keeper)
+}
+
+// InitGenesis inits the genesis of the module
+func (am AppModule) InitGenesis(ctx sdk.Context, bz json.RawMessage) []abci.ValidatorUpdate {
+	var data GenesisState
+	moduleCdc.MustUnmarshalJSON(bz, &data)
+	InitGenesis(ctx, am.keeper, data)
+	return nil
+}
+
+// ExportGenesis exports the genesis of the module
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return moduleCdc.MustMarshalJSON(ExportGenesis(ctx, am.keeper))
+}
+
+// BeginBlock runs the begin blocker of the module
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) sdk.Tags {
+	BeginBlocker(ctx, am.keeper)
+	return sdk.EmptyTags()
+}
+
+// EndBlock runs the end blocker of the module
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) ([]abci.ValidatorUpdate, sdk.Tags) {
+	EndBlocker(ctx, am.keeper)
+	return nil, sdk.EmptyTags()
+}
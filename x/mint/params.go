@@ -0,0 +1,39 @@
+package mint
+
+import (
+	"fmt"
+)
+
+// DefaultParamspace is the param space of the mint module
+const DefaultParamspace = "mint"
+
+// Params mint parameters
+type Params struct {
+	// height from which days and epochs follow block time instead of block counts; zero counts blocks forever
+	ClockTransitionHeight int64 `json:"clock_transition_height"`
+}
+
+// NewParams creates a new param instance
+func NewParams(clockTransitionHeight int64) Params {
+	return Params{
+		ClockTransitionHeight: clockTransitionHeight,
+	}
+}
+
+// DefaultParams creates default mint module parameters; days and epochs are counted in blocks
+func DefaultParams() Params {
+	return NewParams(0)
+}
+
+func validateParams(params Params) error {
+	if params.ClockTransitionHeight < 0 {
+		return fmt.Errorf("mint parameter ClockTransitionHeight must be >= 0, is %d", params.ClockTransitionHeight)
+	}
+	return nil
+}
+
+func (params Params) String() string {
+	return fmt.Sprintf(`Mint Params:
+	ClockTransitionHeight: %d
+	`, params.ClockTransitionHeight)
+}
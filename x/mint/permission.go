@@ -0,0 +1,144 @@
+package mint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terra-project/core/types/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Operations on the issuance that a module can be permitted
+const (
+	OperationMint   = "mint"   // Mint new coins to an account
+	OperationBurn   = "burn"   // Burn coins from an account
+	OperationPool   = "pool"   // Change the issuance for coins moved in or out of a module pool, e.g. the swap fee pool
+	OperationParams = "params" // Change the mint parameters, e.g. the clock transition height; applies to no denom
+)
+
+// Permission is the set of operations and denoms a module is allowed on the issuance
+type Permission struct {
+	Operations []string `json:"operations"`
+	Denoms     []string `json:"denoms"`
+}
+
+// NewPermission creates a new Permission allowing {operations} on the listed {denoms} only
+func NewPermission(operations []string, denoms []string) Permission {
+	return Permission{
+		Operations: append([]string{}, operations...),
+		Denoms:     append([]string{}, denoms...),
+	}
+}
+
+// Allows returns true if the permission allows {operation} on {denom}
+func (p Permission) Allows(operation string, denom string) bool {
+	return contains(p.Operations, operation) && contains(p.Denoms, denom)
+}
+
+func (p Permission) String() string {
+	return fmt.Sprintf(`Permission
+	Operations: %s
+	Denoms: %s`, strings.Join(p.Operations, ","), strings.Join(p.Denoms, ","))
+}
+
+// permissionRegistry holds the permissions of every module; it is sealed once the app is wired
+type permissionRegistry struct {
+	entries map[string]Permission
+	sealed  bool
+}
+
+func newPermissionRegistry() *permissionRegistry {
+	return &permissionRegistry{
+		entries: make(map[string]Permission),
+	}
+}
+
+// ModuleKeeper is the mint keeper handed to a module. It checks every issuance change against the
+// permission of the module, and cannot register or change permissions.
+type ModuleKeeper struct {
+	keeper Keeper
+	module string
+}
+
+// Mint credits {coin} to the {recipient} account, if the module is allowed to mint its denom
+func (mk ModuleKeeper) Mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) sdk.Error {
+	if err := mk.keeper.authorize(mk.module, OperationMint, coin.Denom); err != nil {
+		return err
+	}
+
+	return mk.keeper.mint(ctx, recipient, coin)
+}
+
+// Burn deducts {coin} from the {payer} account, if the module is allowed to burn its denom
+func (mk ModuleKeeper) Burn(ctx sdk.Context, payer sdk.AccAddress, coin sdk.Coin) sdk.Error {
+	if err := mk.keeper.authorize(mk.module, OperationBurn, coin.Denom); err != nil {
+		return err
+	}
+
+	return mk.keeper.burn(ctx, payer, coin)
+}
+
+// ChangeIssuance reflects {delta} in the issuance of {denom}, if the module is allowed to change it
+// for coins of its pool
+func (mk ModuleKeeper) ChangeIssuance(ctx sdk.Context, denom string, delta sdk.Int) sdk.Error {
+	if err := mk.keeper.authorize(mk.module, OperationPool, denom); err != nil {
+		return err
+	}
+
+	return mk.keeper.ChangeIssuance(ctx, denom, delta)
+}
+
+// SetParams sets the mint parameters, if the module is allowed to change them
+func (mk ModuleKeeper) SetParams(ctx sdk.Context, params Params) sdk.Error {
+	if permission, ok := mk.keeper.permissions.entries[mk.module]; !ok || !contains(permission.Operations, OperationParams) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("module %s is not allowed to change the mint params", mk.module))
+	}
+
+	mk.keeper.SetParams(ctx, params)
+	return nil
+}
+
+// GetParams returns the mint parameters
+func (mk ModuleKeeper) GetParams(ctx sdk.Context) Params {
+	return mk.keeper.GetParams(ctx)
+}
+
+// GetIssuance returns the issuance of {denom} on {day}
+func (mk ModuleKeeper) GetIssuance(ctx sdk.Context, denom string, day sdk.Int) sdk.Int {
+	return mk.keeper.GetIssuance(ctx, denom, day)
+}
+
+// PeekEpochSeigniorage returns the seigniorage of {epoch}
+func (mk ModuleKeeper) PeekEpochSeigniorage(ctx sdk.Context, epoch sdk.Int) sdk.Int {
+	return mk.keeper.PeekEpochSeigniorage(ctx, epoch)
+}
+
+// GetClock returns the clock counting days by block time, if it has started
+func (mk ModuleKeeper) GetClock(ctx sdk.Context) (util.Clock, bool) {
+	return mk.keeper.GetClock(ctx)
+}
+
+// GetDay returns the current day
+func (mk ModuleKeeper) GetDay(ctx sdk.Context) sdk.Int {
+	return mk.keeper.GetDay(ctx)
+}
+
+// GetEpoch returns the current epoch
+func (mk ModuleKeeper) GetEpoch(ctx sdk.Context) sdk.Int {
+	return mk.keeper.GetEpoch(ctx)
+}
+
+// IsEpochLastBlock returns true if the current block is the last of its epoch
+func (mk ModuleKeeper) IsEpochLastBlock(ctx sdk.Context) bool {
+	return mk.keeper.IsEpochLastBlock(ctx)
+}
+
+func contains(list []string, item string) bool {
+	for _, elem := range list {
+		if elem == item {
+			return true
+		}
+	}
+	return false
+}
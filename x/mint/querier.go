@@ -0,0 +1,170 @@
+package mint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the mint Querier
+const (
+	QueryIssuance    = "issuance"
+	QuerySeigniorage = "seigniorage"
+	QuerySupply      = "supply"
+)
+
+// MaxIssuanceQueryDays is the maximum number of days an issuance query can span
+const MaxIssuanceQueryDays = 365
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryIssuance:
+			return queryIssuance(ctx, path[1:], req, keeper)
+		case QuerySeigniorage:
+			return querySeigniorage(ctx, path[1:], req, keeper)
+		case QuerySupply:
+			return querySupply(ctx, path[1:], req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown mint query endpoint")
+		}
+	}
+}
+
+// DailyIssuance is the issuance of a denom at the end of a day
+type DailyIssuance struct {
+	Day      int64   `json:"day"`
+	Issuance sdk.Int `json:"issuance"`
+}
+
+// JSON response format
+type QueryIssuanceResponse struct {
+	Denom    string          `json:"denom"`
+	Issuance []DailyIssuance `json:"issuance"`
+}
+
+func (r QueryIssuanceResponse) String() (out string) {
+	out = fmt.Sprintf("Issuance of %s:\n", r.Denom)
+	for _, daily := range r.Issuance {
+		out += fmt.Sprintf("  Day %d: %s\n", daily.Day, daily.Issuance)
+	}
+	return strings.TrimSpace(out)
+}
+
+// nolint: unparam
+func queryIssuance(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || len(path[0]) == 0 {
+		return nil, sdk.ErrUnknownRequest("denom parameter is missing")
+	}
+	denom := path[0]
+
+	today := keeper.GetDay(ctx).Int64()
+	startDay, endDay := today, today
+	if len(path) > 1 && len(path[1]) != 0 {
+		day, err := strconv.ParseInt(path[1], 10, 64)
+		if err != nil || day < 0 {
+			return nil, sdk.ErrInternal("start day parameter is not correctly formatted")
+		}
+		startDay, endDay = day, day
+	}
+	if len(path) > 2 && len(path[2]) != 0 {
+		day, err := strconv.ParseInt(path[2], 10, 64)
+		if err != nil || day < 0 {
+			return nil, sdk.ErrInternal("end day parameter is not correctly formatted")
+		}
+		endDay = day
+	}
+
+	// Issuance is not known past today
+	if endDay > today {
+		endDay = today
+	}
+	if startDay > endDay {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("start day %d is after end day %d", startDay, endDay))
+	}
+	if endDay-startDay >= MaxIssuanceQueryDays {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("cannot query more than %d days of issuance", MaxIssuanceQueryDays))
+	}
+
+	response := QueryIssuanceResponse{Denom: denom, Issuance: []DailyIssuance{}}
+	for day := startDay; day <= endDay; day++ {
+		response.Issuance = append(response.Issuance, DailyIssuance{
+			Day:      day,
+			Issuance: keeper.GetIssuance(ctx, denom, sdk.NewInt(day)),
+		})
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, response)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// JSON response format
+type QuerySeigniorageResponse struct {
+	Epoch       sdk.Int `json:"epoch"`
+	Seigniorage sdk.Int `json:"seigniorage"`
+}
+
+func (r QuerySeigniorageResponse) String() (out string) {
+	out = fmt.Sprintf("Epoch %s: %s", r.Epoch, r.Seigniorage)
+	return strings.TrimSpace(out)
+}
+
+// nolint: unparam
+func querySeigniorage(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	curEpoch := keeper.GetEpoch(ctx)
+	epoch := curEpoch
+	if len(path) != 0 && len(path[0]) != 0 {
+		var ok bool
+		epoch, ok = sdk.NewIntFromString(path[0])
+		if !ok || epoch.IsNegative() {
+			return nil, sdk.ErrInternal("epoch parameter is not correctly formatted")
+		}
+	}
+
+	if epoch.GT(curEpoch) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("epoch %s has not started yet", epoch))
+	}
+
+	seigniorage := keeper.PeekEpochSeigniorage(ctx, epoch)
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, QuerySeigniorageResponse{Epoch: epoch, Seigniorage: seigniorage})
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// JSON response format
+type QuerySupplyResponse struct {
+	Supply sdk.Coins `json:"supply"`
+}
+
+func (r QuerySupplyResponse) String() (out string) {
+	out = r.Supply.String()
+	return strings.TrimSpace(out)
+}
+
+// nolint: unparam
+func querySupply(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var supply sdk.Coins
+	if len(path) != 0 && len(path[0]) != 0 {
+		// Supply of a single denom
+		supply = sdk.Coins{sdk.NewCoin(path[0], keeper.GetSupply(ctx, path[0]))}
+	} else {
+		supply = keeper.GetTotalSupply(ctx)
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, QuerySupplyResponse{Supply: supply})
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,141 @@
+package mint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func getQueriedSupply(t *testing.T, ctx sdk.Context, keeper Keeper, querier sdk.Querier, denom string) sdk.Coins {
+	query := abci.RequestQuery{
+		Path: "",
+		Data: []byte{},
+	}
+
+	bz, err := querier(ctx, []string{QuerySupply, denom}, query)
+	require.Nil(t, err)
+	require.NotNil(t, bz)
+
+	var response QuerySupplyResponse
+	err2 := keeper.cdc.UnmarshalJSON(bz, &response)
+	require.Nil(t, err2)
+	return response.Supply
+}
+
+func TestQuerySupply(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.mintKeeper)
+
+	lunaAmt := sdk.NewInt(10).MulRaw(assets.MicroUnit)
+	err := input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, lunaAmt))
+	require.Nil(t, err)
+
+	supply := getQueriedSupply(t, input.ctx, input.mintKeeper, querier, "")
+	require.True(t, supply.AmountOf(assets.MicroLunaDenom).Equal(lunaAmt))
+	require.True(t, supply.AmountOf(assets.MicroSDRDenom).Equal(uSDRAmount.MulRaw(3)))
+
+	supply = getQueriedSupply(t, input.ctx, input.mintKeeper, querier, assets.MicroSDRDenom)
+	require.Equal(t, 1, len(supply))
+	require.True(t, supply.AmountOf(assets.MicroSDRDenom).Equal(uSDRAmount.MulRaw(3)))
+}
+
+func TestQueryIssuance(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.mintKeeper)
+
+	amt := sdk.NewInt(10).MulRaw(assets.MicroUnit)
+	for day := int64(1); day <= 3; day++ {
+		err := input.mintKeeper.mint(input.ctx.WithBlockHeight(day*util.BlocksPerDay), addrs[0], sdk.NewCoin(assets.MicroSDRDenom, amt))
+		require.Nil(t, err)
+	}
+
+	ctx := input.ctx.WithBlockHeight(4 * util.BlocksPerDay)
+	query := func(path ...string) (QueryIssuanceResponse, sdk.Error) {
+		var response QueryIssuanceResponse
+		bz, err := querier(ctx, append([]string{QueryIssuance}, path...), abci.RequestQuery{})
+		if err == nil {
+			input.mintKeeper.cdc.MustUnmarshalJSON(bz, &response)
+		}
+		return response, err
+	}
+
+	// Range of days
+	response, err := query(assets.MicroSDRDenom, "0", "3")
+	require.Nil(t, err)
+	require.Equal(t, assets.MicroSDRDenom, response.Denom)
+	require.Equal(t, 4, len(response.Issuance))
+	for i, daily := range response.Issuance {
+		require.Equal(t, int64(i), daily.Day)
+		require.True(t, uSDRAmount.MulRaw(3).Add(amt.MulRaw(int64(i))).Equal(daily.Issuance))
+	}
+
+	// Defaults to today, and never goes past today
+	response, err = query(assets.MicroSDRDenom)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(response.Issuance))
+	require.Equal(t, int64(4), response.Issuance[0].Day)
+
+	response, err = query(assets.MicroSDRDenom, "2", "100")
+	require.Nil(t, err)
+	require.Equal(t, 3, len(response.Issuance))
+
+	// Invalid ranges
+	_, err = query(assets.MicroSDRDenom, "3", "1")
+	require.NotNil(t, err)
+
+	_, err = query(assets.MicroSDRDenom, "abc")
+	require.NotNil(t, err)
+
+	_, err = querier(ctx.WithBlockHeight((MaxIssuanceQueryDays+1)*util.BlocksPerDay), []string{QueryIssuance, assets.MicroSDRDenom, "0", fmt.Sprintf("%d", MaxIssuanceQueryDays)}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestQuerySeigniorage(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.mintKeeper)
+
+	amt := sdk.NewInt(100)
+	err := input.mintKeeper.mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, amt))
+	require.Nil(t, err)
+	err = input.mintKeeper.mint(input.ctx.WithBlockHeight(util.BlocksPerEpoch+util.BlocksPerDay), addrs[0], sdk.NewCoin(assets.MicroLunaDenom, amt))
+	require.Nil(t, err)
+
+	ctx := input.ctx.WithBlockHeight(util.BlocksPerEpoch * 2)
+	query := func(epoch string) (QuerySeigniorageResponse, sdk.Error) {
+		var response QuerySeigniorageResponse
+		bz, err := querier(ctx, []string{QuerySeigniorage, epoch}, abci.RequestQuery{})
+		if err == nil {
+			input.mintKeeper.cdc.MustUnmarshalJSON(bz, &response)
+		}
+		return response, err
+	}
+
+	response, err := query("1")
+	require.Nil(t, err)
+	require.True(t, sdk.NewInt(1).Equal(response.Epoch))
+	require.True(t, amt.Equal(response.Seigniorage))
+
+	// Defaults to the current epoch
+	response, err = query("")
+	require.Nil(t, err)
+	require.True(t, sdk.NewInt(2).Equal(response.Epoch))
+
+	// Future epochs are rejected
+	_, err = query("3")
+	require.NotNil(t, err)
+}
+
+func TestQueryUnknownEndpoint(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.mintKeeper)
+
+	_, err := querier(input.ctx, []string{"unknown"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
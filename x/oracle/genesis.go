@@ -1,18 +1,24 @@
 package oracle
 
 import (
+	"fmt"
+
+	"github.com/terra-project/core/types/assets"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // GenesisState - all oracle state that must be provided at genesis
 type GenesisState struct {
-	Params Params `json:"params"` // oracle params
+	Params Params       `json:"params"` // oracle params
+	Prices sdk.DecCoins `json:"prices"` // prices of Luna in each denom
 }
 
 // NewGenesisState creates new oracle GenesisState
-func NewGenesisState(params Params) GenesisState {
+func NewGenesisState(params Params, prices sdk.DecCoins) GenesisState {
 	return GenesisState{
 		Params: params,
+		Prices: prices,
 	}
 }
 
@@ -20,23 +26,72 @@ func NewGenesisState(params Params) GenesisState {
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		Params: DefaultParams(),
+		Prices: sdk.DecCoins{},
 	}
 }
 
 // InitGenesis creates new oracle genesis
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	keeper.SetParams(ctx, data.Params)
+
+	for _, price := range data.Prices {
+		keeper.SetLunaSwapRate(ctx, price.Denom, price.Amount)
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper. The
 // GenesisState will contain the pool, and validator/delegator distribution info's
 func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
 	params := keeper.GetParams(ctx)
-	return NewGenesisState(params)
+
+	prices := sdk.DecCoins{}
+	for _, denom := range keeper.getActiveDenoms(ctx) {
+		price, _ := keeper.GetLunaSwapRate(ctx, denom)
+		prices = append(prices, sdk.NewDecCoinFromDec(denom, price))
+	}
+
+	return NewGenesisState(params, prices)
+}
+
+// PrepForZeroHeightGenesis settles the oracle for a chain restarted from an export at height zero.
+// The winners of the last ballots are rewarded, and the swap fees no one won go to the fee collector.
+// Prevotes and votes refer to heights of the exporting chain and are dropped; validators vote
+// again in the first vote period of the restarted chain.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	rewardPrevBallotWinners(ctx, keeper)
+
+	if feePool := keeper.GetSwapFeePool(ctx); !feePool.Empty() {
+		keeper.fck.AddCollectedFees(ctx, feePool)
+
+		for _, feeCoin := range feePool {
+			// never return err, but handle err for lint
+			if err := keeper.mk.ChangeIssuance(ctx, feeCoin.Denom, feeCoin.Amount); err != nil {
+				panic(err)
+			}
+		}
+
+		keeper.clearSwapFeePool(ctx)
+	}
+
+	keeper.iteratePrevotes(ctx, func(prevote PricePrevote) (stop bool) {
+		keeper.deletePrevote(ctx, prevote)
+		return false
+	})
+
+	keeper.iterateVotes(ctx, func(vote PriceVote) (stop bool) {
+		keeper.deleteVote(ctx, vote)
+		return false
+	})
 }
 
 // ValidateGenesis validates the provided oracle genesis state to ensure the
 // expected invariants holds. (i.e. params in correct bounds, no duplicate validators)
 func ValidateGenesis(data GenesisState) error {
+	for _, price := range data.Prices {
+		if price.Denom == assets.MicroLunaDenom || !price.Amount.IsPositive() {
+			return fmt.Errorf("invalid genesis price of Luna in %s: %s", price.Denom, price.Amount)
+		}
+	}
+
 	return validateParams(data.Params)
 }
@@ -0,0 +1,35 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportInitGenesis(t *testing.T) {
+	input := createTestInput(t)
+
+	prices := sdk.DecCoins{
+		sdk.NewDecCoinFromDec(assets.MicroKRWDenom, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(assets.MicroSDRDenom, sdk.NewDecWithPrec(5, 1)),
+	}
+	genesis := NewGenesisState(DefaultParams(), prices)
+	require.Nil(t, ValidateGenesis(genesis))
+
+	InitGenesis(input.ctx, input.oracleKeeper, genesis)
+	price, err := input.oracleKeeper.GetLunaSwapRate(input.ctx, assets.MicroKRWDenom)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(1000), price)
+
+	exported := ExportGenesis(input.ctx, input.oracleKeeper)
+	require.Equal(t, genesis, exported)
+
+	// Luna has no price, and prices must be positive
+	genesis.Prices = sdk.DecCoins{sdk.NewDecCoinFromDec(assets.MicroLunaDenom, sdk.OneDec())}
+	require.NotNil(t, ValidateGenesis(genesis))
+	genesis.Prices = sdk.DecCoins{sdk.NewDecCoinFromDec(assets.MicroSDRDenom, sdk.ZeroDec())}
+	require.NotNil(t, ValidateGenesis(genesis))
+}
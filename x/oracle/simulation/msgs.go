@@ -0,0 +1,93 @@
+package simulation
+
+import (
+	"encoding/hex"
+	"math/rand"
+
+	"github.com/terra-project/core/simulation"
+	"github.com/terra-project/core/x/oracle"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+)
+
+// prevote is a prevote of the simulation, remembered to be revealed in the next vote period
+type prevote struct {
+	price       sdk.Dec
+	salt        string
+	submitBlock int64
+}
+
+// SimulateMsgPricePrevoteVote returns an operation running the prevote and vote cycles of the
+// validators on the prices of {denoms}. A random validator, feeding its own prices, reveals the
+// price it prevoted in the previous vote period, or else prevotes a price close to the current one.
+func SimulateMsgPricePrevoteVote(ak auth.AccountKeeper, sk staking.Keeper, k oracle.Keeper, denoms []string) simulation.Operation {
+	prevotes := make(map[string]prevote)
+
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (simulation.OperationMsg, error) {
+
+		validators := sk.GetAllValidators(ctx)
+		if len(validators) == 0 {
+			return simulation.NoOpMsg(oracle.RouterKey), nil
+		}
+
+		val := validators[r.Intn(len(validators))]
+		feeder, found := simulation.FindAccount(accs, val.OperatorAddress)
+		if !found {
+			return simulation.NoOpMsg(oracle.RouterKey), nil
+		}
+
+		denom := denoms[r.Intn(len(denoms))]
+		key := denom + val.OperatorAddress.String()
+		votePeriod := k.GetParams(ctx).VotePeriod
+
+		if pv, ok := prevotes[key]; ok {
+			switch ctx.BlockHeight()/votePeriod - pv.submitBlock/votePeriod {
+			case 0:
+				// wait for the next vote period to reveal the price
+				return simulation.NoOpMsg(oracle.RouterKey), nil
+			case 1:
+				delete(prevotes, key)
+				msg := oracle.NewMsgPriceVote(pv.price, pv.salt, denom, feeder.Address, val.OperatorAddress)
+				return simulation.DeliverMsg(app, ctx, ak, chainID, msg, feeder)
+			default:
+				// too late to reveal; prevote again
+				delete(prevotes, key)
+			}
+		}
+
+		price := randomPrice(r, ctx, k, denom)
+		salt := simulation.RandStringOfLength(r, 4)
+		hash, err := oracle.VoteHash(salt, price, denom, val.OperatorAddress)
+		if err != nil {
+			return simulation.NoOpMsg(oracle.RouterKey), err
+		}
+
+		msg := oracle.NewMsgPricePrevote(hex.EncodeToString(hash), denom, feeder.Address, val.OperatorAddress)
+		opMsg, err := simulation.DeliverMsg(app, ctx, ak, chainID, msg, feeder)
+		if opMsg.OK {
+			prevotes[key] = prevote{price, salt, ctx.BlockHeight()}
+		}
+
+		return opMsg, err
+	}
+}
+
+// randomPrice returns a price of Luna in {denom} within 10% of the current one, or a random price
+// if there is none
+func randomPrice(r *rand.Rand, ctx sdk.Context, k oracle.Keeper, denom string) sdk.Dec {
+	price, err := k.GetLunaSwapRate(ctx, denom)
+	if err != nil {
+		return sdk.NewDecWithPrec(int64(1+r.Intn(1000000)), 3)
+	}
+
+	price = price.Mul(sdk.NewDecWithPrec(int64(900+r.Intn(201)), 3))
+	if !price.IsPositive() {
+		return sdk.OneDec()
+	}
+
+	return price
+}
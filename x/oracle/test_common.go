@@ -71,6 +71,9 @@ func setup(t *testing.T) (testInput, sdk.Handler) {
 	return input, h
 }
 
+// faucet is the module the tests fund accounts as; it may mint and burn every denom
+const faucet = "faucet"
+
 type testInput struct {
 	ctx           sdk.Context
 	cdc           *codec.Codec
@@ -159,8 +162,12 @@ func createTestInput(t *testing.T) testInput {
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(faucet, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+
 	stakingKeeper.SetPool(ctx, staking.InitialPool())
 	stakingParams := staking.DefaultParams()
 	stakingParams.BondDenom = assets.MicroLunaDenom
@@ -168,7 +175,7 @@ func createTestInput(t *testing.T) testInput {
 
 	sh := staking.NewHandler(stakingKeeper)
 	for i, addr := range addrs {
-		err2 := mintKeeper.Mint(ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(3)))
+		err2 := mintKeeper.ForModule(faucet).Mint(ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt.MulRaw(3)))
 		require.NoError(t, err2)
 
 		// Add validators
@@ -182,10 +189,11 @@ func createTestInput(t *testing.T) testInput {
 		staking.EndBlocker(ctx, stakingKeeper)
 	}
 
+	mintKeeper.RegisterPermission(ModuleName, mint.NewPermission([]string{mint.OperationPool}, assets.TerraDenoms))
 	oracleKeeper := NewKeeper(
 		cdc,
 		keyOracle,
-		mintKeeper,
+		mintKeeper.ForModule(ModuleName),
 		distrKeeper,
 		feeCollectionKeeper,
 		stakingKeeper.GetValidatorSet(),
@@ -0,0 +1,138 @@
+package pay
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// clawbackDelegations hands {amount} of the bond denom tokens delegated by {grantee} over to {funder}.
+// Tokens already unbonding are taken first; the rest is undelegated from the validators of the grantee.
+// The unbonding entries taken are moved to the funder, who receives the tokens once they mature and
+// bears any slashing until then. Tokens undelegated from an unbonded validator are released at once,
+// and are sent to the funder right away. Returns the amount handed over, which is less than {amount}
+// only if the delegations of the grantee were slashed.
+func clawbackDelegations(ctx sdk.Context, k Keeper, grantee, funder sdk.AccAddress, amount sdk.Int) (clawedBack sdk.Int, err sdk.Error) {
+	clawedBack = sdk.ZeroInt()
+	if !amount.IsPositive() {
+		return
+	}
+
+	// Take the unbonding entries of the grantee first. If they don't cover the amount, the grantee
+	// has no unbonding entries left, so the undelegations below cannot hit the max entries limit.
+	clawedBack, err = moveUnbondingEntries(ctx, k, grantee, funder, amount)
+	if err != nil {
+		return
+	}
+
+	// Undelegate what is not unbonding yet
+	bondDenom := k.sk.BondDenom(ctx)
+	remaining := amount.Sub(clawedBack)
+	for _, delegation := range k.sk.GetDelegatorDelegations(ctx, grantee, math.MaxUint16) {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		validator, found := k.sk.GetValidator(ctx, delegation.ValidatorAddress)
+		if !found {
+			continue
+		}
+
+		undelegateAmt := sdk.MinInt(validator.TokensFromShares(delegation.Shares).TruncateInt(), remaining)
+		if !undelegateAmt.IsPositive() {
+			continue
+		}
+
+		shares, err := k.sk.ValidateUnbondAmount(ctx, grantee, delegation.ValidatorAddress, undelegateAmt)
+		if err != nil {
+			return clawedBack, err
+		}
+
+		balance := k.bk.GetCoins(ctx, grantee).AmountOf(bondDenom)
+		if _, err := k.sk.Undelegate(ctx, grantee, delegation.ValidatorAddress, shares); err != nil {
+			return clawedBack, err
+		}
+
+		// Undelegations from unbonded validators complete at once, and pay the grantee
+		released := k.bk.GetCoins(ctx, grantee).AmountOf(bondDenom).Sub(balance)
+		if released.IsPositive() {
+			if _, err := k.bk.SendCoins(ctx, grantee, funder, sdk.Coins{sdk.NewCoin(bondDenom, released)}); err != nil {
+				return clawedBack, err
+			}
+
+			clawedBack = clawedBack.Add(released)
+		}
+
+		remaining = remaining.Sub(undelegateAmt)
+	}
+
+	// Move the unbonding entries just created to the funder
+	moved, err := moveUnbondingEntries(ctx, k, grantee, funder, amount.Sub(clawedBack))
+	clawedBack = clawedBack.Add(moved)
+
+	return
+}
+
+// moveUnbondingEntries moves unbonding entries of {grantee} worth up to {amount} to {funder}, splitting
+// the last entry taken if needed. Returns the amount moved.
+func moveUnbondingEntries(ctx sdk.Context, k Keeper, grantee, funder sdk.AccAddress, amount sdk.Int) (moved sdk.Int, err sdk.Error) {
+	moved = sdk.ZeroInt()
+	for _, ubd := range k.sk.GetUnbondingDelegations(ctx, grantee, math.MaxUint16) {
+		var entries []stakingtypes.UnbondingDelegationEntry
+		for _, entry := range ubd.Entries {
+			moveAmt := sdk.MinInt(entry.Balance, amount.Sub(moved))
+			if !moveAmt.IsPositive() {
+				entries = append(entries, entry)
+				continue
+			}
+
+			if err = addUnbondingEntry(ctx, k, funder, ubd.ValidatorAddress, entry, moveAmt); err != nil {
+				return
+			}
+
+			moved = moved.Add(moveAmt)
+
+			if moveAmt.LT(entry.Balance) {
+				entry.Balance = entry.Balance.Sub(moveAmt)
+				entry.InitialBalance = sdk.MaxInt(entry.InitialBalance.Sub(moveAmt), entry.Balance)
+				entries = append(entries, entry)
+			}
+		}
+
+		if len(entries) == 0 {
+			k.sk.RemoveUnbondingDelegation(ctx, ubd)
+			continue
+		}
+
+		ubd.Entries = entries
+		k.sk.SetUnbondingDelegation(ctx, ubd)
+	}
+
+	return
+}
+
+// addUnbondingEntry adds {balance} maturing like {entry} to the unbonding delegation of {funder} with
+// {valAddr}. The balance is merged into an entry created at the same height and maturing at the same
+// time if the funder has one; a new entry is subject to the max entries limit of the funder.
+func addUnbondingEntry(ctx sdk.Context, k Keeper, funder sdk.AccAddress, valAddr sdk.ValAddress,
+	entry stakingtypes.UnbondingDelegationEntry, balance sdk.Int) sdk.Error {
+	if ubd, found := k.sk.GetUnbondingDelegation(ctx, funder, valAddr); found {
+		for i, funderEntry := range ubd.Entries {
+			if funderEntry.CreationHeight == entry.CreationHeight && funderEntry.CompletionTime.Equal(entry.CompletionTime) {
+				ubd.Entries[i].Balance = funderEntry.Balance.Add(balance)
+				ubd.Entries[i].InitialBalance = funderEntry.InitialBalance.Add(balance)
+				k.sk.SetUnbondingDelegation(ctx, ubd)
+				return nil
+			}
+		}
+	}
+
+	if k.sk.HasMaxUnbondingDelegationEntries(ctx, funder, valAddr) {
+		return ErrMaxUnbondingEntries(funder, valAddr)
+	}
+
+	ubd := k.sk.SetUnbondingDelegationEntry(ctx, funder, valAddr, entry.CreationHeight, entry.CompletionTime, balance)
+	k.sk.InsertUBDQueue(ctx, ubd, entry.CompletionTime)
+	return nil
+}
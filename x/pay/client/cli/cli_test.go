@@ -1,11 +1,17 @@
 package cli
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/spf13/cobra"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/terra-project/core/testutil"
+
+	"github.com/cosmos/cosmos-sdk/client"
 )
 
 func TestSendTx(t *testing.T) {
@@ -28,3 +34,213 @@ func TestSendTx(t *testing.T) {
 
 	require.Nil(t, err)
 }
+
+func TestSchedulePaymentTx(t *testing.T) {
+	// normal case all parameter given
+	err := executePayTx(
+		`schedule-payment`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000ukrw`,
+		`--start-height=1000`,
+		`--interval=100`,
+		`--count=12`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+
+	// zero count
+	err = executePayTx(
+		`schedule-payment`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000ukrw`,
+		`--start-height=1000`,
+		`--interval=100`,
+		`--count=0`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.NotNil(t, err)
+
+	err = executePayTx(
+		`cancel-payment`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--payment-id=1`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+}
+
+func TestHTLCTx(t *testing.T) {
+	// sha256("secret")
+	err := executePayTx(
+		`lock-htlc`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000ukrw`,
+		`--hash-lock=2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b`,
+		`--timeout-height=1000`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+
+	// hash lock of a wrong size
+	err = executePayTx(
+		`lock-htlc`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000ukrw`,
+		`--hash-lock=2bb80d53`,
+		`--timeout-height=1000`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.NotNil(t, err)
+
+	err = executePayTx(
+		`claim-htlc`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--lock-id=1`,
+		`--preimage=736563726574`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+
+	err = executePayTx(
+		`refund-htlc`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--lock-id=1`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+}
+
+func TestCreateLazyVestingAccountTx(t *testing.T) {
+	schedulesFile, err := ioutil.TempFile("", "schedules")
+	require.Nil(t, err)
+	defer os.Remove(schedulesFile.Name())
+
+	_, err = schedulesFile.WriteString(`[
+  {
+    "denom": "uluna",
+    "schedules": [
+      {"start_time": "1577836800", "end_time": "1609459200", "ratio": "0.5"},
+      {"start_time": "1609459200", "end_time": "1640995200", "ratio": "0.5"}
+    ]
+  }
+]`)
+	require.Nil(t, err)
+	require.Nil(t, schedulesFile.Close())
+
+	err = executePayTx(
+		`create-vesting-account`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000000uluna`,
+		`--schedules=`+schedulesFile.Name(),
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+
+	err = executePayTx(
+		`create-vesting-account`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000000uluna`,
+		`--schedules=`+schedulesFile.Name(),
+		`--clawback`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+
+	// funded denom without a schedule
+	err = executePayTx(
+		`create-vesting-account`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--to=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--coins=1000000uluna,1000ukrw`,
+		`--schedules=`+schedulesFile.Name(),
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.NotNil(t, err)
+}
+
+func TestClawbackTx(t *testing.T) {
+	err := executePayTx(
+		`clawback`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--account=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.Nil(t, err)
+
+	// invalid account address
+	err = executePayTx(
+		`clawback`,
+		`--from=terra1wg2mlrxdmnnkkykgqg4znky86nyrtc45q336yv`,
+		`--account=terra1invalid`,
+		`--generate-only`,
+		`--offline`,
+		`--chain-id=columbus`,
+	)
+
+	require.NotNil(t, err)
+}
+
+// executePayTx runs `tx pay {args}` on a fresh command tree; the flags of a tree are only
+// prepared once, so a tree cannot execute twice
+func executePayTx(args ...string) error {
+	cdc, rootCmd, txCmd, _ := testutil.PrepareCmdTest()
+
+	payTxCmd := &cobra.Command{
+		Use:   "pay",
+		Short: "pay transaction subcommands",
+	}
+
+	txCmd.AddCommand(payTxCmd)
+
+	payTxCmd.AddCommand(client.PostCommands(
+		GetCmdSchedulePayment(cdc),
+		GetCmdCancelPayment(cdc),
+		GetCmdLockHTLC(cdc),
+		GetCmdClaimHTLC(cdc),
+		GetCmdRefundHTLC(cdc),
+		GetCmdCreateLazyVestingAccount(cdc),
+		GetCmdClawback(cdc),
+	)...)
+
+	_, err := testutil.ExecuteCommand(rootCmd, append([]string{`tx`, `pay`}, args...)...)
+	return err
+}
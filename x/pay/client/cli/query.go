@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/terra-project/core/x/pay"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagSender    = "sender"
+	flagRecipient = "recipient"
+)
+
+// GetCmdQueryScheduledPayment implements the query scheduled payment command.
+func GetCmdQueryScheduledPayment(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   pay.QueryScheduledPayment,
+		Args:  cobra.NoArgs,
+		Short: "Query details of a single scheduled payment",
+		Long: strings.TrimSpace(`
+Query details for a scheduled payment.
+
+$ terracli query pay scheduled-payment --payment-id 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			paymentIDStr := viper.GetString(flagPaymentID)
+			paymentID, err := strconv.ParseUint(paymentIDStr, 10, 64)
+			if err != nil {
+				return fmt.Errorf("given payment-id %s not a valid format\n, payment-id should be formatted as integer", paymentIDStr)
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%d", pay.QuerierRoute, pay.QueryScheduledPayment, paymentID), nil)
+			if err != nil {
+				return err
+			}
+
+			var payment pay.ScheduledPayment
+			cdc.MustUnmarshalJSON(res, &payment)
+			return cliCtx.PrintOutput(payment)
+		},
+	}
+
+	cmd.Flags().String(flagPaymentID, "", "the scheduled payment ID to query")
+
+	cmd.MarkFlagRequired(flagPaymentID)
+
+	return cmd
+}
+
+// GetCmdQuerySenderPayments implements the query scheduled payments by sender command.
+func GetCmdQuerySenderPayments(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   pay.QuerySenderPayments,
+		Args:  cobra.NoArgs,
+		Short: "Query the scheduled payments sent by an address",
+		Long: strings.TrimSpace(`
+Query the scheduled payments sent by an address.
+
+$ terracli query pay sender-payments --sender terra1...
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryAddressPayments(cdc, pay.QuerySenderPayments, viper.GetString(flagSender))
+		},
+	}
+
+	cmd.Flags().String(flagSender, "", "the sender address to query")
+
+	cmd.MarkFlagRequired(flagSender)
+
+	return cmd
+}
+
+// GetCmdQueryRecipientPayments implements the query scheduled payments by recipient command.
+func GetCmdQueryRecipientPayments(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   pay.QueryRecipientPayments,
+		Args:  cobra.NoArgs,
+		Short: "Query the scheduled payments received by an address",
+		Long: strings.TrimSpace(`
+Query the scheduled payments received by an address.
+
+$ terracli query pay recipient-payments --recipient terra1...
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryAddressPayments(cdc, pay.QueryRecipientPayments, viper.GetString(flagRecipient))
+		},
+	}
+
+	cmd.Flags().String(flagRecipient, "", "the recipient address to query")
+
+	cmd.MarkFlagRequired(flagRecipient)
+
+	return cmd
+}
+
+func queryAddressPayments(cdc *codec.Codec, route string, addrStr string) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	addr, err := sdk.AccAddressFromBech32(addrStr)
+	if err != nil {
+		return err
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", pay.QuerierRoute, route, addr), nil)
+	if err != nil {
+		return err
+	}
+
+	var payments pay.ScheduledPayments
+	cdc.MustUnmarshalJSON(res, &payments)
+	return cliCtx.PrintOutput(payments)
+}
+
+// GetCmdQueryHTLC implements the query hash time lock command.
+func GetCmdQueryHTLC(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   pay.QueryHTLC,
+		Args:  cobra.NoArgs,
+		Short: "Query details of a single hash time lock",
+		Long: strings.TrimSpace(`
+Query details for a hash time lock.
+
+$ terracli query pay htlc --lock-id 1
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			lockID, err := parseLockID(viper.GetString(flagLockID))
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%d", pay.QuerierRoute, pay.QueryHTLC, lockID), nil)
+			if err != nil {
+				return err
+			}
+
+			var htlc pay.HTLC
+			cdc.MustUnmarshalJSON(res, &htlc)
+			return cliCtx.PrintOutput(htlc)
+		},
+	}
+
+	cmd.Flags().String(flagLockID, "", "the hash time lock ID to query")
+
+	cmd.MarkFlagRequired(flagLockID)
+
+	return cmd
+}
+
+// GetCmdQueryHTLCs implements the query hash time locks command.
+func GetCmdQueryHTLCs(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   pay.QueryHTLCs,
+		Args:  cobra.NoArgs,
+		Short: "Query hash time locks, optionally filtered by sender and recipient",
+		Long: strings.TrimSpace(`
+Query the outstanding hash time locks, optionally filtered by sender and recipient.
+
+$ terracli query pay htlcs --sender terra1...
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var sender, recipient sdk.AccAddress
+			var err error
+
+			if senderStr := viper.GetString(flagSender); len(senderStr) != 0 {
+				sender, err = sdk.AccAddressFromBech32(senderStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			if recipientStr := viper.GetString(flagRecipient); len(recipientStr) != 0 {
+				recipient, err = sdk.AccAddressFromBech32(recipientStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			params := pay.NewQueryHTLCsParams(sender, recipient)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", pay.QuerierRoute, pay.QueryHTLCs), bz)
+			if err != nil {
+				return err
+			}
+
+			var htlcs pay.HTLCs
+			cdc.MustUnmarshalJSON(res, &htlcs)
+			return cliCtx.PrintOutput(htlcs)
+		},
+	}
+
+	cmd.Flags().String(flagSender, "", "(optional) filter by sender address")
+	cmd.Flags().String(flagRecipient, "", "(optional) filter by recipient address")
+
+	return cmd
+}
@@ -32,6 +32,9 @@ func SendTxCmd(cdc *codec.Codec) *cobra.Command {
 		Long: strings.TrimSpace(`
 Create, sign and broadcast send tx.
 
+The --fees or --gas-prices only pay for the gas. The stability tax is charged to the sender on top of
+the fee when the send is executed; estimate it with "terracli query treasury estimate-tax".
+
 For generate-only, --from should be specified as address not key name.
 $ terracli tx send --to [to_address] --coins [amount] --from [from_address or key_name]
 `),
@@ -0,0 +1,520 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/x/pay"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+)
+
+const (
+	flagStartHeight = "start-height"
+	flagInterval    = "interval"
+	flagCount       = "count"
+	flagPaymentID   = "payment-id"
+
+	flagHashLock      = "hash-lock"
+	flagTimeoutHeight = "timeout-height"
+	flagLockID        = "lock-id"
+	flagPreimage      = "preimage"
+
+	flagSchedules = "schedules"
+	flagClawback  = "clawback"
+	flagAccount   = "account"
+)
+
+// GetCmdSchedulePayment will create a scheduled payment tx and sign it with the given key.
+func GetCmdSchedulePayment(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule-payment",
+		Args:  cobra.NoArgs,
+		Short: "Schedule recurring payments to an address",
+		Long: strings.TrimSpace(`
+Schedule --count payments of --coins to --to, every --interval blocks from --start-height.
+The coins for all payments are escrowed when the tx is processed; the stability tax
+is charged on each payment as it is made.
+
+$ terracli tx pay schedule-payment --to [to_address] --coins 1000ukrw --start-height 1000 --interval 100 --count 12 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			to, err := sdk.AccAddressFromBech32(viper.GetString(flagTo))
+			if err != nil {
+				return err
+			}
+
+			coins, err := sdk.ParseCoins(viper.GetString(flagCoins))
+			if err != nil {
+				return err
+			}
+
+			startHeight := viper.GetInt64(flagStartHeight)
+			interval := viper.GetInt64(flagInterval)
+			count := viper.GetInt64(flagCount)
+
+			from := cliCtx.GetFromAddress()
+
+			msg := pay.NewMsgCreateScheduledPayment(from, to, coins, startHeight, interval, count)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+
+				account, err := cliCtx.GetAccount(from)
+				if err != nil {
+					return err
+				}
+
+				// ensure account has enough coins to escrow every payment
+				var total sdk.Coins
+				for _, coin := range coins {
+					total = total.Add(sdk.NewCoins(sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(count))))
+				}
+
+				if !account.GetCoins().IsAllGTE(total) {
+					return fmt.Errorf("address %s doesn't have enough coins to escrow %s", from, total)
+				}
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagTo, "", "Destination address for the payments")
+	cmd.Flags().String(flagCoins, "", "Amount of coins per payment (e.g. 1000ukrw,100usdr)")
+	cmd.Flags().Int64(flagStartHeight, 0, "Block height of the first payment")
+	cmd.Flags().Int64(flagInterval, 0, "Number of blocks between payments")
+	cmd.Flags().Int64(flagCount, 0, "Number of payments")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagTo)
+	cmd.MarkFlagRequired(flagCoins)
+	cmd.MarkFlagRequired(flagStartHeight)
+	cmd.MarkFlagRequired(flagInterval)
+	cmd.MarkFlagRequired(flagCount)
+
+	return cmd
+}
+
+// GetCmdCancelPayment will create a cancel scheduled payment tx and sign it with the given key.
+func GetCmdCancelPayment(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-payment",
+		Args:  cobra.NoArgs,
+		Short: "Cancel a scheduled payment and refund the remaining escrow",
+		Long: strings.TrimSpace(`
+Cancel a scheduled payment; the coins escrowed for the payments not yet made are refunded to the sender.
+
+$ terracli tx pay cancel-payment --payment-id 1 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			paymentIDStr := viper.GetString(flagPaymentID)
+			paymentID, err := strconv.ParseUint(paymentIDStr, 10, 64)
+			if err != nil {
+				return fmt.Errorf("given payment-id %s not a valid format\n, payment-id should be formatted as integer", paymentIDStr)
+			}
+
+			from := cliCtx.GetFromAddress()
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+			}
+
+			msg := pay.NewMsgCancelScheduledPayment(paymentID, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagPaymentID, "", "ID of the scheduled payment to cancel")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagPaymentID)
+
+	return cmd
+}
+
+// GetCmdLockHTLC will create a hash time lock tx and sign it with the given key.
+func GetCmdLockHTLC(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock-htlc",
+		Args:  cobra.NoArgs,
+		Short: "Escrow coins for an address under a hash time lock",
+		Long: strings.TrimSpace(`
+Escrow --coins for --to until the preimage of --hash-lock (hex encoded SHA-256 hash) is revealed.
+If the lock is not claimed before --timeout-height, the coins are refunded to the sender.
+The stability tax is charged on the escrowed coins when they are claimed.
+
+$ terracli tx pay lock-htlc --to [to_address] --coins 1000ukrw --hash-lock [hash] --timeout-height 10000 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			to, err := sdk.AccAddressFromBech32(viper.GetString(flagTo))
+			if err != nil {
+				return err
+			}
+
+			coins, err := sdk.ParseCoins(viper.GetString(flagCoins))
+			if err != nil {
+				return err
+			}
+
+			hashLock, err := hex.DecodeString(viper.GetString(flagHashLock))
+			if err != nil {
+				return fmt.Errorf("given hash-lock is not a valid hex string: %s", err)
+			}
+
+			from := cliCtx.GetFromAddress()
+
+			msg := pay.NewMsgLockHTLC(from, to, coins, hashLock, viper.GetInt64(flagTimeoutHeight))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+
+				account, err := cliCtx.GetAccount(from)
+				if err != nil {
+					return err
+				}
+
+				// ensure account has enough coins
+				if !account.GetCoins().IsAllGTE(coins) {
+					return fmt.Errorf("address %s doesn't have enough coins to pay for this transaction", from)
+				}
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagTo, "", "Recipient address of the lock")
+	cmd.Flags().String(flagCoins, "", "Amount of coins to escrow (e.g. 1000ukrw,100usdr)")
+	cmd.Flags().String(flagHashLock, "", "Hex encoded SHA-256 hash of the preimage")
+	cmd.Flags().Int64(flagTimeoutHeight, 0, "Block height from which the lock can no longer be claimed")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagTo)
+	cmd.MarkFlagRequired(flagCoins)
+	cmd.MarkFlagRequired(flagHashLock)
+	cmd.MarkFlagRequired(flagTimeoutHeight)
+
+	return cmd
+}
+
+// GetCmdClaimHTLC will create a claim hash time lock tx and sign it with the given key.
+func GetCmdClaimHTLC(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-htlc",
+		Args:  cobra.NoArgs,
+		Short: "Release a hash time lock to its recipient by revealing the preimage",
+		Long: strings.TrimSpace(`
+Reveal the hex encoded --preimage of a hash time lock to release its coins to the recipient, less the stability tax.
+
+$ terracli tx pay claim-htlc --lock-id 1 --preimage [preimage] --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			lockID, err := parseLockID(viper.GetString(flagLockID))
+			if err != nil {
+				return err
+			}
+
+			preimage, err := hex.DecodeString(viper.GetString(flagPreimage))
+			if err != nil {
+				return fmt.Errorf("given preimage is not a valid hex string: %s", err)
+			}
+
+			from := cliCtx.GetFromAddress()
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+			}
+
+			msg := pay.NewMsgClaimHTLC(lockID, from, preimage)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagLockID, "", "ID of the hash time lock to claim")
+	cmd.Flags().String(flagPreimage, "", "Hex encoded preimage of the hash lock")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagLockID)
+	cmd.MarkFlagRequired(flagPreimage)
+
+	return cmd
+}
+
+// GetCmdRefundHTLC will create a refund hash time lock tx and sign it with the given key.
+func GetCmdRefundHTLC(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refund-htlc",
+		Args:  cobra.NoArgs,
+		Short: "Refund an expired hash time lock to its sender",
+		Long: strings.TrimSpace(`
+Refund the coins of an expired hash time lock to its sender. Expired locks are also
+refunded automatically at the end of their timeout block.
+
+$ terracli tx pay refund-htlc --lock-id 1 --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			lockID, err := parseLockID(viper.GetString(flagLockID))
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+			}
+
+			msg := pay.NewMsgRefundHTLC(lockID, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagLockID, "", "ID of the hash time lock to refund")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagLockID)
+
+	return cmd
+}
+
+func parseLockID(lockIDStr string) (uint64, error) {
+	lockID, err := strconv.ParseUint(lockIDStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("given lock-id %s not a valid format\n, lock-id should be formatted as integer", lockIDStr)
+	}
+
+	return lockID, nil
+}
+
+// GetCmdCreateLazyVestingAccount will create a lazy graded vesting account tx and sign it with the given key.
+func GetCmdCreateLazyVestingAccount(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-vesting-account",
+		Args:  cobra.NoArgs,
+		Short: "Create a lazy graded vesting account funded by the sender",
+		Long: strings.TrimSpace(`
+Create the new account --to, funded with --coins that vest by the schedules in the --schedules JSON file.
+Every funded denom needs exactly one schedule, and the ratios of a schedule should sum to 1.
+The stability tax is charged on the funded coins. With --clawback, the sender can later claw back
+the coins that have not vested yet.
+
+$ terracli tx pay create-vesting-account --to [to_address] --coins 1000000uluna --schedules schedules.json --from mykey
+
+Where schedules.json contains:
+
+[
+  {
+    "denom": "uluna",
+    "schedules": [
+      {"start_time": "1577836800", "end_time": "1609459200", "ratio": "0.5"},
+      {"start_time": "1609459200", "end_time": "1640995200", "ratio": "0.5"}
+    ]
+  }
+]
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			to, err := sdk.AccAddressFromBech32(viper.GetString(flagTo))
+			if err != nil {
+				return err
+			}
+
+			coins, err := sdk.ParseCoins(viper.GetString(flagCoins))
+			if err != nil {
+				return err
+			}
+
+			schedules, err := parseLazyVestingSchedules(cdc, viper.GetString(flagSchedules))
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+
+			msg := pay.NewMsgCreateLazyVestingAccount(from, to, coins, schedules, viper.GetBool(flagClawback))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+
+				account, err := cliCtx.GetAccount(from)
+				if err != nil {
+					return err
+				}
+
+				// ensure account has enough coins
+				if !account.GetCoins().IsAllGTE(coins) {
+					return fmt.Errorf("address %s doesn't have enough coins to pay for this transaction", from)
+				}
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagTo, "", "Address of the new vesting account")
+	cmd.Flags().String(flagCoins, "", "Amount of coins to fund the account with (e.g. 1000000uluna)")
+	cmd.Flags().String(flagSchedules, "", "JSON file with the vesting schedule of each funded denom")
+	cmd.Flags().Bool(flagClawback, false, "Keep the right to claw back the unvested coins")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagTo)
+	cmd.MarkFlagRequired(flagCoins)
+	cmd.MarkFlagRequired(flagSchedules)
+
+	return cmd
+}
+
+// GetCmdClawback will create a clawback tx and sign it with the given key.
+func GetCmdClawback(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clawback",
+		Args:  cobra.NoArgs,
+		Short: "Claw back the unvested coins of a vesting account you funded",
+		Long: strings.TrimSpace(`
+Stop the vesting of the clawback vesting account --account and return its unvested coins to the funder.
+Unvested tokens that are delegated are undelegated, and returned at the end of the unbonding period.
+Coins that have already vested stay with the account.
+
+$ terracli tx pay clawback --account [account_address] --from mykey
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			account, err := sdk.AccAddressFromBech32(viper.GetString(flagAccount))
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+
+			msg := pay.NewMsgClawback(from, account)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			offline := viper.GetBool(flagOffline)
+			if !offline {
+				if err := cliCtx.EnsureAccountExists(); err != nil {
+					return err
+				}
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}, offline)
+		},
+	}
+
+	cmd.Flags().String(flagAccount, "", "Address of the clawback vesting account")
+	cmd.Flags().Bool(flagOffline, false, " Offline mode; Without full node connection the node can still build and sign tx")
+
+	cmd.MarkFlagRequired(client.FlagFrom)
+	cmd.MarkFlagRequired(flagAccount)
+
+	return cmd
+}
+
+func parseLazyVestingSchedules(cdc *codec.Codec, schedulesFile string) (schedules []types.LazyVestingSchedule, err error) {
+	contents, err := ioutil.ReadFile(schedulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cdc.UnmarshalJSON(contents, &schedules)
+	if err != nil {
+		return nil, fmt.Errorf("given schedules file is not a valid list of vesting schedules: %s", err)
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"github.com/terra-project/core/x/pay/client/cli"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+	amino "github.com/tendermint/go-amino"
+)
+
+// ModuleClient exports all client functionality from this module
+type ModuleClient struct {
+	storeKey string
+	cdc      *amino.Codec
+}
+
+func NewModuleClient(storeKey string, cdc *amino.Codec) ModuleClient {
+	return ModuleClient{storeKey, cdc}
+}
+
+// GetQueryCmd returns the cli query commands for this module
+func (mc ModuleClient) GetQueryCmd() *cobra.Command {
+	payQueryCmd := &cobra.Command{
+		Use:   "pay",
+		Short: "Querying commands for the pay module",
+	}
+	payQueryCmd.AddCommand(client.GetCommands(
+		cli.GetCmdQueryScheduledPayment(mc.cdc),
+		cli.GetCmdQuerySenderPayments(mc.cdc),
+		cli.GetCmdQueryRecipientPayments(mc.cdc),
+		cli.GetCmdQueryHTLC(mc.cdc),
+		cli.GetCmdQueryHTLCs(mc.cdc),
+	)...)
+
+	return payQueryCmd
+}
+
+// GetTxCmd returns the transaction commands for this module
+func (mc ModuleClient) GetTxCmd() *cobra.Command {
+	payTxCmd := &cobra.Command{
+		Use:   "pay",
+		Short: "pay transaction subcommands",
+	}
+
+	payTxCmd.AddCommand(client.PostCommands(
+		cli.GetCmdSchedulePayment(mc.cdc),
+		cli.GetCmdCancelPayment(mc.cdc),
+		cli.GetCmdLockHTLC(mc.cdc),
+		cli.GetCmdClaimHTLC(mc.cdc),
+		cli.GetCmdRefundHTLC(mc.cdc),
+		cli.GetCmdCreateLazyVestingAccount(mc.cdc),
+		cli.GetCmdClawback(mc.cdc),
+	)...)
+
+	return payTxCmd
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/terra-project/core/app"
+)
+
+const (
+	storeKey = string("pay")
+)
+
+var (
+	queryCmdList = map[string]bool{
+		"scheduled-payment":  true,
+		"sender-payments":    true,
+		"recipient-payments": true,
+		"htlc":               true,
+		"htlcs":              true,
+	}
+
+	txCmdList = map[string]bool{
+		"schedule-payment":       true,
+		"cancel-payment":         true,
+		"lock-htlc":              true,
+		"claim-htlc":             true,
+		"refund-htlc":            true,
+		"create-vesting-account": true,
+		"clawback":               true,
+	}
+)
+
+func TestQueryCmdInvariant(t *testing.T) {
+
+	cdc := app.MakeCodec()
+	mc := NewModuleClient(storeKey, cdc)
+
+	for _, cmd := range mc.GetQueryCmd().Commands() {
+		_, ok := queryCmdList[cmd.Name()]
+		require.True(t, ok)
+	}
+
+	require.Equal(t, len(queryCmdList), len(mc.GetQueryCmd().Commands()))
+}
+
+func TestTxCmdInvariant(t *testing.T) {
+
+	cdc := app.MakeCodec()
+	mc := NewModuleClient(storeKey, cdc)
+
+	for _, cmd := range mc.GetTxCmd().Commands() {
+		_, ok := txCmdList[cmd.Name()]
+		require.True(t, ok)
+	}
+
+	require.Equal(t, len(txCmdList), len(mc.GetTxCmd().Commands()))
+}
@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/terra-project/core/x/pay"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc(fmt.Sprintf("/pay/scheduled_payments/{%s}", RestPaymentID), queryScheduledPaymentHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/pay/senders/{%s}/scheduled_payments", RestAddress), queryAddressPaymentsHandlerFn(cdc, cliCtx, pay.QuerySenderPayments)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/pay/recipients/{%s}/scheduled_payments", RestAddress), queryAddressPaymentsHandlerFn(cdc, cliCtx, pay.QueryRecipientPayments)).Methods("GET")
+}
+
+func queryScheduledPaymentHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strPaymentID := vars[RestPaymentID]
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", pay.QuerierRoute, pay.QueryScheduledPayment, strPaymentID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryAddressPaymentsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext, route string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		addr, err := sdk.AccAddressFromBech32(vars[RestAddress])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", pay.QuerierRoute, route, addr), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
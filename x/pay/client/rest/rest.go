@@ -0,0 +1,23 @@
+package rest
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/gorilla/mux"
+)
+
+// REST Variable names
+// nolint
+const (
+	RestPaymentID = "payment-id"
+	RestAddress   = "address"
+)
+
+// RegisterRoutes - Central function to define routes that get registered by the main application
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
+	r.HandleFunc("/bank/accounts/{address}/transfers", SendRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+
+	registerTxRoutes(cliCtx, r, cdc)
+	registerQueryRoutes(cliCtx, r, cdc)
+}
@@ -1,28 +1,34 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
+	"github.com/terra-project/core/x/treasury"
+
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	clientrest "github.com/cosmos/cosmos-sdk/client/rest"
+	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
-// RegisterRoutes - Central function to define routes that get registered by the main application
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
-	r.HandleFunc("/bank/accounts/{address}/transfers", SendRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
-}
-
 // SendReq defines the properties of a send request's body.
+// If AutoFee is set, gas is simulated and the fee is filled in from the given gas prices. The fee
+// only pays for the gas: the stability tax is charged to the sender on execution, on top of the fee.
 type SendReq struct {
 	BaseReq rest.BaseReq `json:"base_req"`
 	Coins   sdk.Coins    `json:"coins"`
+	AutoFee bool         `json:"auto_fee"`
 }
 
 // SendRequestHandlerFn - http request handler to send coins to a address.
@@ -66,6 +72,81 @@ func SendRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIC
 		}
 
 		msg := bank.NewMsgSend(fromAddr, toAddr, req.Coins)
+
+		if req.AutoFee {
+			var ok bool
+			req.BaseReq, ok = fillAutoFee(w, cdc, cliCtx, req.BaseReq, account, req.Coins, []sdk.Msg{msg})
+			if !ok {
+				return
+			}
+		}
+
 		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
+
+// fillAutoFee simulates the gas of msgs and sets the fee of br from its gas prices. The stability
+// tax is left out of the fee, since the pay handler charges it to the sender on execution and a fee
+// covering it would have the sender pay the tax twice; it is only estimated to check that the
+// account can afford the coins, the tax and the fee together.
+func fillAutoFee(w http.ResponseWriter, cdc *codec.Codec, cliCtx context.CLIContext,
+	br rest.BaseReq, account auth.Account, coins sdk.Coins, msgs []sdk.Msg) (rest.BaseReq, bool) {
+
+	if !br.Fees.Empty() || br.GasPrices.Empty() {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, "auto_fee requires gas_prices and no fees")
+		return br, false
+	}
+
+	gasAdj, ok := rest.ParseFloat64OrReturnBadRequest(w, br.GasAdjustment, client.DefaultGasAdjustment)
+	if !ok {
+		return br, false
+	}
+
+	txBldr := authtxb.NewTxBuilder(
+		utils.GetTxEncoder(cdc), br.AccountNumber, br.Sequence, 0, gasAdj,
+		true, br.ChainID, br.Memo, nil, nil,
+	)
+
+	txBldr, err := utils.EnrichWithGas(txBldr, cliCtx, msgs)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return br, false
+	}
+
+	// Derive the fee from the simulated gas: fee = ceil(gasPrice * gas)
+	gas := sdk.NewDec(int64(txBldr.Gas()))
+	fees := sdk.Coins{}
+	for _, gp := range br.GasPrices {
+		fee := gp.Amount.Mul(gas).Ceil().RoundInt()
+		fees = fees.Add(sdk.NewCoins(sdk.NewCoin(gp.Denom, fee)))
+	}
+
+	params := treasury.NewQueryEstimateTaxParams(coins, auth.StdTx{})
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return br, false
+	}
+
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", treasury.QuerierRoute, treasury.QueryEstimateTax), bz)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return br, false
+	}
+
+	var estimate treasury.QueryEstimateTaxResponse
+	cdc.MustUnmarshalJSON(res, &estimate)
+
+	required := coins.Add(estimate.Total).Add(fees)
+	if !account.GetCoins().IsAllGTE(required) {
+		err := fmt.Errorf("insufficient funds: %s required for send, stability tax and fee, %s available", required, account.GetCoins())
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return br, false
+	}
+
+	br.Fees = fees
+	br.GasPrices = nil
+	br.Gas = strconv.FormatUint(txBldr.Gas(), 10)
+
+	return br, true
+}
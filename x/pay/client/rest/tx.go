@@ -0,0 +1,186 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/x/pay"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	clientrest "github.com/cosmos/cosmos-sdk/client/rest"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+)
+
+func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	r.HandleFunc("/pay/scheduled_payments", schedulePaymentHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/pay/scheduled_payments/{%s}/cancel", RestPaymentID), cancelPaymentHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/pay/vesting_accounts", createLazyVestingAccountHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/pay/vesting_accounts/{%s}/clawback", RestAddress), clawbackHandlerFn(cdc, cliCtx)).Methods("POST")
+}
+
+type schedulePaymentReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Recipient   sdk.AccAddress `json:"recipient"`    // Address of the recipient
+	Amount      sdk.Coins      `json:"amount"`       // Amount paid per installment
+	StartHeight int64          `json:"start_height"` // Block height of the first installment
+	Interval    int64          `json:"interval"`     // Number of blocks between installments
+	Count       int64          `json:"count"`        // Total number of installments
+}
+
+type cancelPaymentReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+}
+
+type createLazyVestingAccountReq struct {
+	BaseReq              rest.BaseReq                `json:"base_req"`
+	Recipient            sdk.AccAddress              `json:"recipient"`              // Address of the new account
+	Amount               sdk.Coins                   `json:"amount"`                 // Amount funded, all of it vesting
+	LazyVestingSchedules []types.LazyVestingSchedule `json:"vesting_lazy_schedules"` // Vesting schedule of each funded denom
+	Clawback             bool                        `json:"clawback"`               // Whether the sender can claw back unvested coins
+}
+
+type clawbackReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+}
+
+func schedulePaymentHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req schedulePaymentReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddress, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := pay.NewMsgCreateScheduledPayment(fromAddress, req.Recipient, req.Amount, req.StartHeight, req.Interval, req.Count)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func cancelPaymentHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strPaymentID := vars[RestPaymentID]
+
+		paymentID, err := strconv.ParseUint(strPaymentID, 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req cancelPaymentReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddress, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := pay.NewMsgCancelScheduledPayment(paymentID, fromAddress)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func createLazyVestingAccountHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req createLazyVestingAccountReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddress, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := pay.NewMsgCreateLazyVestingAccount(fromAddress, req.Recipient, req.Amount, req.LazyVestingSchedules, req.Clawback)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func clawbackHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		account, err := sdk.AccAddressFromBech32(vars[RestAddress])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req clawbackReq
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddress, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := pay.NewMsgClawback(fromAddress, account)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
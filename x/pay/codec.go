@@ -11,6 +11,13 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(bank.MsgSend{}, "pay/MsgSend", nil)
 	cdc.RegisterConcrete(bank.MsgMultiSend{}, "pay/MsgMultiSend", nil)
+	cdc.RegisterConcrete(MsgCreateScheduledPayment{}, "pay/MsgCreateScheduledPayment", nil)
+	cdc.RegisterConcrete(MsgCancelScheduledPayment{}, "pay/MsgCancelScheduledPayment", nil)
+	cdc.RegisterConcrete(MsgLockHTLC{}, "pay/MsgLockHTLC", nil)
+	cdc.RegisterConcrete(MsgClaimHTLC{}, "pay/MsgClaimHTLC", nil)
+	cdc.RegisterConcrete(MsgRefundHTLC{}, "pay/MsgRefundHTLC", nil)
+	cdc.RegisterConcrete(MsgCreateLazyVestingAccount{}, "pay/MsgCreateLazyVestingAccount", nil)
+	cdc.RegisterConcrete(MsgClawback{}, "pay/MsgClawback", nil)
 }
 
 var msgCdc = codec.New()
@@ -0,0 +1,26 @@
+package pay
+
+const (
+	// ModuleName is the name of the pay module
+	ModuleName = "pay"
+
+	// StoreKey is the string store representation
+	StoreKey = ModuleName
+
+	// RouterKey is the msg router key for the pay module; bank messages keep the bank route
+	RouterKey = ModuleName
+
+	// QuerierRoute is the query router key for the pay module
+	QuerierRoute = ModuleName
+
+	// MaxPaymentFailures is the number of consecutive installments that can fail before the
+	// scheduled payment is aborted and the rest of its escrow refunded to the sender
+	MaxPaymentFailures = int64(3)
+
+	// MaxPaymentCount is the maximum number of installments of a scheduled payment
+	MaxPaymentCount = int64(10000)
+
+	// MaxPaymentsPerBlock is the maximum number of installments paid out in a block; installments
+	// due beyond it are left in the queue for the following blocks
+	MaxPaymentsPerBlock = 100
+)
@@ -0,0 +1,147 @@
+package pay
+
+import (
+	"strconv"
+
+	"github.com/terra-project/core/x/pay/tags"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker refunds the hash time locks that expired at the previous height, then pays out the
+// scheduled payment installments that are due at the current height. A lock is only refunded the
+// block after its timeout, so that the sender can ask for the refund in the timeout block. A lock
+// that cannot be refunded is kept, and left to the sender to refund.
+// Each installment is taxed through payTax, charged to the sender, exactly like a MsgSend.
+// An installment that cannot be paid (e.g. the sender cannot cover the tax) is retried one interval
+// later; after MaxPaymentFailures consecutive failures the payment is aborted and the rest of its
+// escrow refunded to the sender. At most MaxPaymentsPerBlock installments are paid per block.
+func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
+	resTags = sdk.EmptyTags()
+
+	var expiredIDs []uint64
+	k.HTLCExpiryQueueIterateExpired(ctx, ctx.BlockHeight()-1, func(lockID uint64) (stop bool) {
+		expiredIDs = append(expiredIDs, lockID)
+		return false
+	})
+
+	for _, lockID := range expiredIDs {
+		htlc, err := k.GetHTLC(ctx, lockID)
+		if err != nil {
+			continue
+		}
+
+		refundTags, err := refundHTLC(ctx, k, htlc)
+		if err != nil {
+			lockIDStr := strconv.FormatUint(lockID, 10)
+			ctx.Logger().Error("failed to refund expired hash time lock", "lock-id", lockIDStr, "err", err)
+
+			k.HTLCExpiryQueueRemove(ctx, htlc.TimeoutHeight, lockID)
+			resTags = resTags.AppendTags(sdk.NewTags(
+				tags.Action, tags.ActionHTLCRefundFailed,
+				tags.LockID, lockIDStr,
+			))
+			continue
+		}
+
+		resTags = resTags.AppendTags(refundTags)
+	}
+
+	// Installments due beyond MaxPaymentsPerBlock are left for the following blocks, oldest first
+	var dueIDs []uint64
+	k.PaymentQueueIterateDue(ctx, ctx.BlockHeight(), func(paymentID uint64) (stop bool) {
+		dueIDs = append(dueIDs, paymentID)
+		return len(dueIDs) >= MaxPaymentsPerBlock
+	})
+
+	for _, paymentID := range dueIDs {
+		payment, err := k.GetScheduledPayment(ctx, paymentID)
+		if err != nil {
+			continue
+		}
+
+		k.PaymentQueueRemove(ctx, payment.NextPayHeight, paymentID)
+		paymentIDStr := strconv.FormatUint(paymentID, 10)
+
+		// Pay tax and the installment atomically
+		cacheCtx, write := ctx.CacheContext()
+		taxes, err := payTax(cacheCtx, k.bk, k.tk, k.fk, payment.Sender, payment.Amount)
+		if err == nil {
+			_, err = k.bk.SendCoins(cacheCtx, EscrowAddress, payment.Recipient, payment.Amount)
+		}
+
+		if err != nil {
+			resTags = resTags.AppendTags(sdk.NewTags(
+				tags.Action, tags.ActionPaymentFailed,
+				tags.PaymentID, paymentIDStr,
+			))
+
+			payment.Failures++
+			if payment.Failures >= MaxPaymentFailures {
+				resTags = resTags.AppendTags(abortScheduledPayment(ctx, k, payment))
+				continue
+			}
+
+			payment.NextPayHeight = ctx.BlockHeight() + payment.Interval
+			k.StoreScheduledPayment(ctx, payment)
+			k.PaymentQueueInsert(ctx, payment.NextPayHeight, paymentID)
+			continue
+		}
+
+		write()
+		payment.Paid++
+		payment.Failures = 0
+
+		resTags = resTags.AppendTags(sdk.NewTags(
+			tags.Action, tags.ActionPaymentExecuted,
+			tags.PaymentID, paymentIDStr,
+			tags.Tax, taxes.String(),
+		))
+
+		if payment.Paid >= payment.Count {
+			k.DeleteScheduledPayment(ctx, payment)
+
+			resTags = resTags.AppendTags(sdk.NewTags(
+				tags.Action, tags.ActionPaymentCompleted,
+				tags.PaymentID, paymentIDStr,
+			))
+			continue
+		}
+
+		// Installments missed while failing are caught up one per block
+		payment.NextPayHeight = payment.StartHeight + payment.Paid*payment.Interval
+		if payment.NextPayHeight <= ctx.BlockHeight() {
+			payment.NextPayHeight = ctx.BlockHeight() + 1
+		}
+
+		k.StoreScheduledPayment(ctx, payment)
+		k.PaymentQueueInsert(ctx, payment.NextPayHeight, paymentID)
+	}
+
+	return
+}
+
+// abortScheduledPayment refunds the installments of {payment} not yet paid to its sender and
+// removes the payment. A refund that fails leaves the payment in place, with no installment queued.
+func abortScheduledPayment(ctx sdk.Context, k Keeper, payment ScheduledPayment) sdk.Tags {
+	paymentIDStr := strconv.FormatUint(payment.PaymentID, 10)
+
+	remaining, err := payment.Remaining()
+	if err == nil {
+		_, err = k.bk.SendCoins(ctx, EscrowAddress, payment.Sender, remaining)
+	}
+
+	if err != nil {
+		ctx.Logger().Error("failed to refund aborted scheduled payment", "payment-id", paymentIDStr, "err", err)
+		k.StoreScheduledPayment(ctx, payment)
+		return sdk.EmptyTags()
+	}
+
+	k.DeleteScheduledPayment(ctx, payment)
+
+	return sdk.NewTags(
+		tags.Action, tags.ActionPaymentAborted,
+		tags.PaymentID, paymentIDStr,
+		tags.Sender, payment.Sender.String(),
+	)
+}
@@ -0,0 +1,330 @@
+package pay
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+	"github.com/terra-project/core/x/pay/tags"
+	"github.com/terra-project/core/x/treasury"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func setupScheduledPayment(t *testing.T, input testInput, startHeight, interval, count int64) sdk.Coins {
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.NewDecWithPrec(1, 3)) // 0.1%
+
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+	res := NewHandler(input.payKeeper)(input.ctx, NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, startHeight, interval, count))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	return amt
+}
+
+func TestEndBlockerScheduledPayment(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	amt := setupScheduledPayment(t, input, 5, 10, 3)
+
+	tax := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(1).MulRaw(assets.MicroUnit/10))}
+	senderBalance := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Sub(mustMulCoins(amt, 3))
+	recipientBalance := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}
+
+	// Not yet due
+	input.ctx = input.ctx.WithBlockHeight(4)
+	EndBlocker(input.ctx, input.payKeeper)
+	require.Equal(t, recipientBalance, input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+
+	for i, height := range []int64{5, 15, 25} {
+		input.ctx = input.ctx.WithBlockHeight(height)
+		EndBlocker(input.ctx, input.payKeeper)
+
+		senderBalance = senderBalance.Sub(tax)
+		recipientBalance = recipientBalance.Add(amt)
+		require.Equal(t, senderBalance, input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+		require.Equal(t, recipientBalance, input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+		require.Equal(t, mustMulCoins(tax, int64(i+1)), input.feeKeeper.GetCollectedFees(input.ctx))
+		require.Equal(t, mustMulCoins(tax, int64(i+1)), input.treasuryKeeper.PeekTaxProceeds(input.ctx, util.GetEpoch(input.ctx)))
+
+		// Nothing is paid between installments
+		input.ctx = input.ctx.WithBlockHeight(height + 1)
+		EndBlocker(input.ctx, input.payKeeper)
+		require.Equal(t, recipientBalance, input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+	}
+
+	// Completed payments are removed and the escrow is drained
+	_, err := input.payKeeper.GetScheduledPayment(input.ctx, 1)
+	require.NotNil(t, err)
+	require.True(t, input.bankKeeper.GetCoins(input.ctx, EscrowAddress).Empty())
+}
+
+func TestEndBlockerScheduledPaymentRetry(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	amt := setupScheduledPayment(t, input, 5, 10, 2)
+
+	// The sender cannot pay the tax; the installment is retried one interval later
+	senderBalance := input.bankKeeper.GetCoins(input.ctx, addrs[0])
+	err := input.bankKeeper.SetCoins(input.ctx, addrs[0], sdk.Coins{})
+	require.Nil(t, err)
+
+	input.ctx = input.ctx.WithBlockHeight(5)
+	EndBlocker(input.ctx, input.payKeeper)
+
+	payment, err := input.payKeeper.GetScheduledPayment(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), payment.Paid)
+	require.Equal(t, int64(1), payment.Failures)
+	require.Equal(t, int64(15), payment.NextPayHeight)
+	require.Equal(t, mustMulCoins(amt, 2), input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+	require.True(t, input.feeKeeper.GetCollectedFees(input.ctx).Empty())
+
+	// Nothing is retried before then
+	input.ctx = input.ctx.WithBlockHeight(6)
+	require.Equal(t, 0, len(EndBlocker(input.ctx, input.payKeeper)))
+
+	err = input.bankKeeper.SetCoins(input.ctx, addrs[0], senderBalance)
+	require.Nil(t, err)
+
+	input.ctx = input.ctx.WithBlockHeight(15)
+	EndBlocker(input.ctx, input.payKeeper)
+
+	// The installment missed is caught up at the next block
+	payment, err = input.payKeeper.GetScheduledPayment(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), payment.Paid)
+	require.Equal(t, int64(0), payment.Failures)
+	require.Equal(t, int64(16), payment.NextPayHeight)
+	require.Equal(t, amt, input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+}
+
+func TestEndBlockerScheduledPaymentAbort(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	amt := setupScheduledPayment(t, input, 5, 10, 2)
+
+	// The sender can never pay the tax
+	err := input.bankKeeper.SetCoins(input.ctx, addrs[0], sdk.Coins{})
+	require.Nil(t, err)
+
+	for i := int64(0); i < MaxPaymentFailures-1; i++ {
+		input.ctx = input.ctx.WithBlockHeight(5 + i*10)
+		EndBlocker(input.ctx, input.payKeeper)
+
+		payment, err := input.payKeeper.GetScheduledPayment(input.ctx, 1)
+		require.Nil(t, err)
+		require.Equal(t, i+1, payment.Failures)
+		require.Equal(t, input.ctx.BlockHeight()+10, payment.NextPayHeight)
+	}
+
+	// The last failure aborts the payment and refunds the escrow
+	input.ctx = input.ctx.WithBlockHeight(5 + (MaxPaymentFailures-1)*10)
+	EndBlocker(input.ctx, input.payKeeper)
+
+	_, err = input.payKeeper.GetScheduledPayment(input.ctx, 1)
+	require.NotNil(t, err)
+	require.True(t, input.bankKeeper.GetCoins(input.ctx, EscrowAddress).Empty())
+	require.Equal(t, mustMulCoins(amt, 2), input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+
+	// Nothing is left queued
+	input.ctx = input.ctx.WithBlockHeight(input.ctx.BlockHeight() + 10)
+	require.Equal(t, 0, len(EndBlocker(input.ctx, input.payKeeper)))
+}
+
+func TestEndBlockerScheduledPaymentLimit(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.ZeroDec())
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.OneInt())}
+	for i := 0; i <= MaxPaymentsPerBlock; i++ {
+		res := handler(input.ctx, NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, 5, 10, 1))
+		require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	}
+
+	// Installments beyond the limit are paid in the following block
+	recipientBalance := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}
+	input.ctx = input.ctx.WithBlockHeight(5)
+	EndBlocker(input.ctx, input.payKeeper)
+	require.Equal(t, recipientBalance.Add(mustMulCoins(amt, MaxPaymentsPerBlock)), input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+
+	require.Equal(t, 1, countScheduledPayments(input.ctx, input.payKeeper))
+
+	input.ctx = input.ctx.WithBlockHeight(6)
+	EndBlocker(input.ctx, input.payKeeper)
+	require.Equal(t, recipientBalance.Add(mustMulCoins(amt, MaxPaymentsPerBlock+1)), input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+
+	require.Equal(t, 0, countScheduledPayments(input.ctx, input.payKeeper))
+}
+
+func TestEndBlockerHTLCExpiry(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+
+	res := handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage([]byte("a")), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	res = handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage([]byte("b")), 20))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// The first lock expires at its timeout block, but is left to the sender to refund then
+	input.ctx = input.ctx.WithBlockHeight(10)
+	EndBlocker(input.ctx, input.payKeeper)
+	require.Equal(t, mustMulCoins(amt, 2), input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+
+	// It is refunded at the end of the next block
+	input.ctx = input.ctx.WithBlockHeight(11)
+	EndBlocker(input.ctx, input.payKeeper)
+
+	_, err := input.payKeeper.GetHTLC(input.ctx, 1)
+	require.NotNil(t, err)
+	_, err = input.payKeeper.GetHTLC(input.ctx, 2)
+	require.Nil(t, err)
+
+	require.Equal(t, amt, input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Sub(amt), input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+}
+
+func TestEndBlockerHTLCManualRefund(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+
+	res := handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage([]byte("a")), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// The sender asks for the refund in the timeout block, before the end blocker
+	input.ctx = input.ctx.WithBlockHeight(10)
+	res = handler(input.ctx, NewMsgRefundHTLC(1, addrs[0]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	require.Equal(t, 0, len(EndBlocker(input.ctx, input.payKeeper)))
+
+	// Nothing is left to refund afterwards
+	input.ctx = input.ctx.WithBlockHeight(11)
+	require.Equal(t, 0, len(EndBlocker(input.ctx, input.payKeeper)))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}, input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+}
+
+func TestEndBlockerHTLCRefundFailure(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+
+	res := handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage([]byte("a")), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// The escrow does not hold the coins of the lock
+	err := input.bankKeeper.SetCoins(input.ctx, EscrowAddress, sdk.Coins{})
+	require.Nil(t, err)
+
+	// The failed refund is tagged, and the lock kept without halting the chain
+	input.ctx = input.ctx.WithBlockHeight(11)
+	resTags := EndBlocker(input.ctx, input.payKeeper)
+	require.Equal(t, []byte(tags.ActionHTLCRefundFailed), resTags[0].Value)
+
+	_, err = input.payKeeper.GetHTLC(input.ctx, 1)
+	require.Nil(t, err)
+
+	// It is not retried at every block
+	input.ctx = input.ctx.WithBlockHeight(12)
+	require.Equal(t, 0, len(EndBlocker(input.ctx, input.payKeeper)))
+
+	// The sender can still ask for the refund once the escrow holds the coins
+	err = input.bankKeeper.SetCoins(input.ctx, EscrowAddress, amt)
+	require.Nil(t, err)
+
+	res = handler(input.ctx, NewMsgRefundHTLC(1, addrs[0]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+}
+
+func TestExportInitGenesis(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	setupScheduledPayment(t, input, 5, 10, 3)
+	setupScheduledPayment(t, input, 8, 1, 2)
+
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(1).MulRaw(assets.MicroUnit))}
+	res := NewHandler(input.payKeeper)(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage([]byte("a")), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	input.ctx = input.ctx.WithBlockHeight(5)
+	EndBlocker(input.ctx, input.payKeeper)
+
+	genesis := ExportGenesis(input.ctx, input.payKeeper)
+	require.Nil(t, ValidateGenesis(genesis))
+	require.Equal(t, 2, len(genesis.ScheduledPayments))
+	require.Equal(t, 1, len(genesis.HTLCs))
+
+	// The escrow of the new chain must hold the escrowed coins
+	input2 := createTestInput(t)
+	require.Panics(t, func() { InitGenesis(input2.ctx, input2.payKeeper, genesis) })
+
+	err := input2.bankKeeper.SetCoins(input2.ctx, EscrowAddress, input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+	require.Nil(t, err)
+	escrowed, escrowErr := EscrowedCoins(genesis)
+	require.Nil(t, escrowErr)
+	require.Equal(t, escrowed, input2.bankKeeper.GetCoins(input2.ctx, EscrowAddress))
+
+	InitGenesis(input2.ctx, input2.payKeeper, genesis)
+	require.Equal(t, input.cdc.MustMarshalJSON(genesis), input2.cdc.MustMarshalJSON(ExportGenesis(input2.ctx, input2.payKeeper)))
+
+	// Payment ids continue after the imported ones
+	require.Equal(t, uint64(3), input2.payKeeper.NewPaymentID(input2.ctx))
+	require.Equal(t, uint64(2), input2.payKeeper.NewLockID(input2.ctx))
+
+	// Invalid states
+	genesis.ScheduledPayments[1].PaymentID = genesis.ScheduledPayments[0].PaymentID
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	genesis = ExportGenesis(input.ctx, input.payKeeper)
+	genesis.ScheduledPayments[0].Paid = genesis.ScheduledPayments[0].Count
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	genesis = ExportGenesis(input.ctx, input.payKeeper)
+	genesis.ScheduledPayments[0].Count = MaxPaymentCount + 1
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	genesis = ExportGenesis(input.ctx, input.payKeeper)
+	genesis.ScheduledPayments[0].Amount = sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewIntFromBigInt(new(big.Int).Lsh(big.NewInt(1), 254)))}
+	require.NotNil(t, ValidateGenesis(genesis))
+	_, escrowErr = EscrowedCoins(genesis)
+	require.NotNil(t, escrowErr)
+
+	genesis = ExportGenesis(input.ctx, input.payKeeper)
+	genesis.HTLCs[0].HashLock = []byte("short")
+	require.NotNil(t, ValidateGenesis(genesis))
+}
+
+func countScheduledPayments(ctx sdk.Context, k Keeper) (count int) {
+	k.IterateScheduledPayments(ctx, func(ScheduledPayment) (stop bool) {
+		count++
+		return false
+	})
+	return
+}
+
+// mustMulCoins multiplies every coin amount by {n}, panicking on overflow
+func mustMulCoins(coins sdk.Coins, n int64) sdk.Coins {
+	res, ok := mulCoins(coins, n)
+	if !ok {
+		panic("coin amount overflow")
+	}
+	return res
+}
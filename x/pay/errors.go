@@ -0,0 +1,134 @@
+package pay
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultCodespace nolint
+	DefaultCodespace sdk.CodespaceType = "pay"
+
+	// Pay errors
+	CodePaymentNotFound        sdk.CodeType = 1
+	CodeInvalidSender          sdk.CodeType = 2
+	CodeInvalidStartHeight     sdk.CodeType = 3
+	CodeInvalidInterval        sdk.CodeType = 4
+	CodeInvalidCount           sdk.CodeType = 5
+	CodeDuplicatePaymentID     sdk.CodeType = 6
+	CodeInvalidPaymentState    sdk.CodeType = 7
+	CodeHTLCNotFound           sdk.CodeType = 8
+	CodeInvalidHashLock        sdk.CodeType = 9
+	CodeInvalidPreimage        sdk.CodeType = 10
+	CodeInvalidTimeout         sdk.CodeType = 11
+	CodeHTLCExpired            sdk.CodeType = 12
+	CodeHTLCNotExpired         sdk.CodeType = 13
+	CodeInvalidVestingSchedule sdk.CodeType = 14
+	CodeAccountExists          sdk.CodeType = 15
+	CodeNotClawbackAccount     sdk.CodeType = 16
+	CodeInvalidFunder          sdk.CodeType = 17
+	CodeAlreadyClawedBack      sdk.CodeType = 18
+	CodeMaxUnbondingEntries    sdk.CodeType = 19
+	CodeAmountOverflow         sdk.CodeType = 20
+)
+
+// nolint
+func ErrPaymentNotFound(paymentID uint64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodePaymentNotFound, fmt.Sprintf("scheduled payment with id %d not found", paymentID))
+}
+
+// nolint
+func ErrInvalidSender(sender sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidSender, fmt.Sprintf("Sender does not match %s", sender))
+}
+
+// nolint
+func ErrInvalidStartHeight(startHeight, curHeight int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidStartHeight, fmt.Sprintf("Start height %d must not be before the current height %d", startHeight, curHeight))
+}
+
+// nolint
+func ErrInvalidInterval(interval int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidInterval, fmt.Sprintf("Interval must be positive, is %d", interval))
+}
+
+// nolint
+func ErrInvalidCount(count int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidCount, fmt.Sprintf("Count must be positive and at most %d, is %d", MaxPaymentCount, count))
+}
+
+// nolint
+func ErrDuplicatePaymentID(paymentID uint64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeDuplicatePaymentID, fmt.Sprintf("scheduled payment ID is duplicated %d", paymentID))
+}
+
+// nolint
+func ErrInvalidPaymentState(paymentID uint64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidPaymentState, fmt.Sprintf("scheduled payment %d has no installments left", paymentID))
+}
+
+// nolint
+func ErrHTLCNotFound(lockID uint64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCNotFound, fmt.Sprintf("hash time lock with id %d not found", lockID))
+}
+
+// nolint
+func ErrInvalidHashLock(hashLock []byte) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidHashLock, fmt.Sprintf("Hash lock must be %d bytes, is %d", HashLockLength, len(hashLock)))
+}
+
+// nolint
+func ErrInvalidPreimage(lockID uint64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidPreimage, fmt.Sprintf("Preimage does not match the hash lock of %d", lockID))
+}
+
+// nolint
+func ErrInvalidTimeout(timeoutHeight, curHeight int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidTimeout, fmt.Sprintf("Timeout height %d must be after the current height %d", timeoutHeight, curHeight))
+}
+
+// nolint
+func ErrHTLCExpired(lockID uint64, timeoutHeight int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCExpired, fmt.Sprintf("hash time lock %d expired at height %d", lockID, timeoutHeight))
+}
+
+// nolint
+func ErrHTLCNotExpired(lockID uint64, timeoutHeight int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeHTLCNotExpired, fmt.Sprintf("hash time lock %d does not expire until height %d", lockID, timeoutHeight))
+}
+
+// nolint
+func ErrInvalidVestingSchedule(denom string, reason string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidVestingSchedule, fmt.Sprintf("invalid vesting schedule for %s: %s", denom, reason))
+}
+
+// nolint
+func ErrAccountExists(address sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeAccountExists, fmt.Sprintf("account %s already exists", address))
+}
+
+// nolint
+func ErrNotClawbackAccount(address sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeNotClawbackAccount, fmt.Sprintf("account %s is not a clawback vesting account", address))
+}
+
+// nolint
+func ErrInvalidFunder(funder sdk.AccAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidFunder, fmt.Sprintf("Funder does not match %s", funder))
+}
+
+// nolint
+func ErrAlreadyClawedBack(address sdk.AccAddress, clawbackTime int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeAlreadyClawedBack, fmt.Sprintf("vesting of account %s was already clawed back at %d", address, clawbackTime))
+}
+
+// nolint
+func ErrMaxUnbondingEntries(funder sdk.AccAddress, valAddr sdk.ValAddress) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeMaxUnbondingEntries, fmt.Sprintf("funder %s has too many unbonding entries with validator %s", funder, valAddr))
+}
+
+// nolint
+func ErrAmountOverflow(amount sdk.Coins, count int64) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeAmountOverflow, fmt.Sprintf("%d installments of %s overflow", count, amount))
+}
@@ -0,0 +1,222 @@
+package pay
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all pay state that must be provided at genesis
+type GenesisState struct {
+	ScheduledPayments ScheduledPayments `json:"scheduled_payments"`
+	HTLCs             HTLCs             `json:"htlcs"`
+}
+
+// NewGenesisState creates a new GenesisState object
+func NewGenesisState(scheduledPayments ScheduledPayments, htlcs HTLCs) GenesisState {
+	return GenesisState{
+		ScheduledPayments: scheduledPayments,
+		HTLCs:             htlcs,
+	}
+}
+
+// DefaultGenesisState get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		ScheduledPayments: ScheduledPayments{},
+		HTLCs:             HTLCs{},
+	}
+}
+
+// InitGenesis new pay genesis. The escrowed funds are expected to be held by
+// EscrowAddress in the accounts genesis, which must be loaded before.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	escrowed, err := EscrowedCoins(data)
+	if err != nil {
+		panic(err)
+	}
+
+	if balance := keeper.bk.GetCoins(ctx, EscrowAddress); !balance.IsAllGTE(escrowed) {
+		panic(fmt.Sprintf("pay escrow holds %s, less than the %s escrowed by the genesis", balance, escrowed))
+	}
+
+	var lastPaymentID uint64
+	for _, payment := range data.ScheduledPayments {
+		keeper.StoreScheduledPayment(ctx, payment)
+		keeper.PaymentQueueInsert(ctx, payment.NextPayHeight, payment.PaymentID)
+
+		if payment.PaymentID > lastPaymentID {
+			lastPaymentID = payment.PaymentID
+		}
+	}
+
+	if lastPaymentID != 0 {
+		keeper.setLastPaymentID(ctx, lastPaymentID)
+	}
+
+	var lastLockID uint64
+	for _, htlc := range data.HTLCs {
+		keeper.StoreHTLC(ctx, htlc)
+
+		if htlc.LockID > lastLockID {
+			lastLockID = htlc.LockID
+		}
+	}
+
+	if lastLockID != 0 {
+		keeper.setLastLockID(ctx, lastLockID)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	scheduledPayments := ScheduledPayments{}
+	keeper.IterateScheduledPayments(ctx, func(payment ScheduledPayment) (stop bool) {
+		scheduledPayments = append(scheduledPayments, payment)
+		return false
+	})
+
+	htlcs := HTLCs{}
+	keeper.IterateHTLCs(ctx, func(htlc HTLC) (stop bool) {
+		htlcs = append(htlcs, htlc)
+		return false
+	})
+
+	return NewGenesisState(scheduledPayments, htlcs)
+}
+
+// EscrowedCoins returns the coins the escrow must hold for the installments not yet paid and the
+// locks of {data}
+func EscrowedCoins(data GenesisState) (sdk.Coins, error) {
+	escrowed := sdk.Coins{}
+	for _, payment := range data.ScheduledPayments {
+		remaining, err := payment.Remaining()
+		if err != nil {
+			return nil, err
+		}
+
+		escrowed = escrowed.Add(remaining)
+	}
+
+	for _, htlc := range data.HTLCs {
+		escrowed = escrowed.Add(htlc.Amount)
+	}
+
+	return escrowed, nil
+}
+
+// PrepForZeroHeightGenesis makes the heights of the scheduled payments and hash time locks relative
+// to the export, for a chain restarted from it at height zero. Installments and timeouts keep the
+// blocks left to them; none falls before the first block of the restarted chain.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	height := ctx.BlockHeight()
+
+	var payments ScheduledPayments
+	keeper.IterateScheduledPayments(ctx, func(payment ScheduledPayment) (stop bool) {
+		payments = append(payments, payment)
+		return false
+	})
+
+	for _, payment := range payments {
+		keeper.PaymentQueueRemove(ctx, payment.NextPayHeight, payment.PaymentID)
+
+		payment.StartHeight -= height
+		payment.NextPayHeight = rebaseHeight(payment.NextPayHeight, height)
+		keeper.StoreScheduledPayment(ctx, payment)
+		keeper.PaymentQueueInsert(ctx, payment.NextPayHeight, payment.PaymentID)
+	}
+
+	var htlcs HTLCs
+	keeper.IterateHTLCs(ctx, func(htlc HTLC) (stop bool) {
+		htlcs = append(htlcs, htlc)
+		return false
+	})
+
+	for _, htlc := range htlcs {
+		keeper.DeleteHTLC(ctx, htlc)
+
+		htlc.TimeoutHeight = rebaseHeight(htlc.TimeoutHeight, height)
+		keeper.StoreHTLC(ctx, htlc)
+	}
+}
+
+// rebaseHeight returns the height {height} blocks before {target}, at least the first block
+func rebaseHeight(target, height int64) int64 {
+	if target -= height; target < 1 {
+		return 1
+	}
+
+	return target
+}
+
+// ValidateGenesis validates the provided pay genesis state to ensure the
+// expected invariants holds. (i.e. no duplicate payments or locks, installments left to pay)
+func ValidateGenesis(data GenesisState) error {
+	paymentMap := make(map[uint64]bool)
+	for _, payment := range data.ScheduledPayments {
+		if payment.PaymentID == 0 {
+			return fmt.Errorf("scheduled payment id must be positive")
+		}
+
+		// duplicate payment ID check
+		if _, ok := paymentMap[payment.PaymentID]; ok {
+			return ErrDuplicatePaymentID(payment.PaymentID)
+		}
+		paymentMap[payment.PaymentID] = true
+
+		if payment.Sender.Empty() || payment.Recipient.Empty() {
+			return fmt.Errorf("scheduled payment %d must have a sender and a recipient", payment.PaymentID)
+		}
+
+		if !payment.Amount.IsValid() || !payment.Amount.IsAllPositive() {
+			return fmt.Errorf("scheduled payment %d has an invalid amount %s", payment.PaymentID, payment.Amount)
+		}
+
+		if payment.Interval <= 0 {
+			return ErrInvalidInterval(payment.Interval)
+		}
+
+		if payment.Count <= 0 || payment.Count > MaxPaymentCount {
+			return ErrInvalidCount(payment.Count)
+		}
+
+		if _, ok := mulCoins(payment.Amount, payment.Count); !ok {
+			return ErrAmountOverflow(payment.Amount, payment.Count)
+		}
+
+		if payment.Paid < 0 || payment.Paid >= payment.Count {
+			return ErrInvalidPaymentState(payment.PaymentID)
+		}
+
+		if payment.Failures < 0 || payment.Failures >= MaxPaymentFailures {
+			return ErrInvalidPaymentState(payment.PaymentID)
+		}
+	}
+
+	lockMap := make(map[uint64]bool)
+	for _, htlc := range data.HTLCs {
+		if htlc.LockID == 0 {
+			return fmt.Errorf("hash time lock id must be positive")
+		}
+
+		// duplicate lock ID check
+		if _, ok := lockMap[htlc.LockID]; ok {
+			return fmt.Errorf("hash time lock ID is duplicated %d", htlc.LockID)
+		}
+		lockMap[htlc.LockID] = true
+
+		if htlc.Sender.Empty() || htlc.Recipient.Empty() {
+			return fmt.Errorf("hash time lock %d must have a sender and a recipient", htlc.LockID)
+		}
+
+		if !htlc.Amount.IsValid() || !htlc.Amount.IsAllPositive() {
+			return fmt.Errorf("hash time lock %d has an invalid amount %s", htlc.LockID, htlc.Amount)
+		}
+
+		if len(htlc.HashLock) != HashLockLength {
+			return ErrInvalidHashLock(htlc.HashLock)
+		}
+	}
+
+	return nil
+}
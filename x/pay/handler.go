@@ -1,5 +1,7 @@
 // Package pay contains a forked version of the bank module. It only contains
 // a modified message handler to support the payement of stability taxes.
+// It also supports scheduled payments and hash time-locked escrows, which are
+// taxed the same way as their coins are released.
 //
 // Taxes are of the fomula: min(principal * taxRate, taxCap).
 // TaxCap and taxRate are stored by the treasury module.
@@ -7,7 +9,12 @@
 package pay
 
 import (
-	"github.com/terra-project/core/types/util"
+	"bytes"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/x/pay/tags"
 	"github.com/terra-project/core/x/treasury"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -16,15 +23,36 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
-// NewHandler returns a handler for "bank" type messages.
-func NewHandler(k bank.Keeper, tk treasury.Keeper, fk auth.FeeCollectionKeeper) sdk.Handler {
+// NewHandler returns a handler for "bank" and "pay" type messages.
+func NewHandler(k Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		switch msg := msg.(type) {
 		case bank.MsgSend:
-			return handleMsgSend(ctx, k, tk, fk, msg)
+			return handleMsgSend(ctx, k.bk, k.tk, k.fk, msg)
 
 		case bank.MsgMultiSend:
-			return handleMsgMultiSend(ctx, k, tk, fk, msg)
+			return handleMsgMultiSend(ctx, k.bk, k.tk, k.fk, msg)
+
+		case MsgCreateScheduledPayment:
+			return handleMsgCreateScheduledPayment(ctx, k, msg)
+
+		case MsgCancelScheduledPayment:
+			return handleMsgCancelScheduledPayment(ctx, k, msg)
+
+		case MsgLockHTLC:
+			return handleMsgLockHTLC(ctx, k, msg)
+
+		case MsgClaimHTLC:
+			return handleMsgClaimHTLC(ctx, k, msg)
+
+		case MsgRefundHTLC:
+			return handleMsgRefundHTLC(ctx, k, msg)
+
+		case MsgCreateLazyVestingAccount:
+			return handleMsgCreateLazyVestingAccount(ctx, k, msg)
+
+		case MsgClawback:
+			return handleMsgClawback(ctx, k, msg)
 
 		default:
 			errMsg := "Unrecognized bank Msg type: %s" + msg.Type()
@@ -94,25 +122,303 @@ func handleMsgMultiSend(ctx sdk.Context, k bank.Keeper, tk treasury.Keeper, fk a
 	}
 }
 
-// payTax charges the stability tax on MsgSend and MsgMultiSend.
+// handleMsgCreateScheduledPayment escrows the full amount of all installments and queues the first one
+func handleMsgCreateScheduledPayment(ctx sdk.Context, k Keeper, msg MsgCreateScheduledPayment) sdk.Result {
+	if !k.bk.GetSendEnabled(ctx) {
+		return bank.ErrSendDisabled(k.bk.Codespace()).Result()
+	}
+
+	if msg.StartHeight < ctx.BlockHeight() {
+		return ErrInvalidStartHeight(msg.StartHeight, ctx.BlockHeight()).Result()
+	}
+
+	// Escrow the funds for every installment up front; taxes are charged as each one is paid
+	escrow, ok := mulCoins(msg.Amount, msg.Count)
+	if !ok {
+		return ErrAmountOverflow(msg.Amount, msg.Count).Result()
+	}
+
+	_, err := k.bk.SendCoins(ctx, msg.Sender, EscrowAddress, escrow)
+	if err != nil {
+		return err.Result()
+	}
+
+	payment := NewScheduledPayment(
+		k.NewPaymentID(ctx),
+		msg.Sender,
+		msg.Recipient,
+		msg.Amount,
+		msg.StartHeight,
+		msg.Interval,
+		msg.Count,
+	)
+
+	k.StoreScheduledPayment(ctx, payment)
+	k.PaymentQueueInsert(ctx, payment.NextPayHeight, payment.PaymentID)
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionPaymentScheduled,
+			tags.PaymentID, strconv.FormatUint(payment.PaymentID, 10),
+			tags.Sender, msg.Sender.String(),
+			tags.Recipient, msg.Recipient.String(),
+		),
+	}
+}
+
+// handleMsgCancelScheduledPayment refunds the installments not yet paid and removes the payment
+func handleMsgCancelScheduledPayment(ctx sdk.Context, k Keeper, msg MsgCancelScheduledPayment) sdk.Result {
+	payment, err := k.GetScheduledPayment(ctx, msg.PaymentID)
+	if err != nil {
+		return err.Result()
+	}
+
+	// Only the sender can cancel the payment
+	if !payment.Sender.Equals(msg.Sender) {
+		return ErrInvalidSender(msg.Sender).Result()
+	}
+
+	remaining, err := payment.Remaining()
+	if err != nil {
+		return err.Result()
+	}
+
+	_, err = k.bk.SendCoins(ctx, EscrowAddress, payment.Sender, remaining)
+	if err != nil {
+		return err.Result()
+	}
+
+	k.DeleteScheduledPayment(ctx, payment)
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionPaymentCancelled,
+			tags.PaymentID, strconv.FormatUint(payment.PaymentID, 10),
+			tags.Sender, payment.Sender.String(),
+			tags.Recipient, payment.Recipient.String(),
+		),
+	}
+}
+
+// handleMsgLockHTLC escrows the coins of a new hash time lock
+func handleMsgLockHTLC(ctx sdk.Context, k Keeper, msg MsgLockHTLC) sdk.Result {
+	if !k.bk.GetSendEnabled(ctx) {
+		return bank.ErrSendDisabled(k.bk.Codespace()).Result()
+	}
+
+	if msg.TimeoutHeight <= ctx.BlockHeight() {
+		return ErrInvalidTimeout(msg.TimeoutHeight, ctx.BlockHeight()).Result()
+	}
+
+	htlc := NewHTLC(k.NewLockID(ctx), msg.Sender, msg.Recipient, msg.Amount, msg.HashLock, msg.TimeoutHeight)
+
+	_, err := k.bk.SendCoins(ctx, msg.Sender, EscrowAddress, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	k.StoreHTLC(ctx, htlc)
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionHTLCLocked,
+			tags.LockID, strconv.FormatUint(htlc.LockID, 10),
+			tags.Sender, htlc.Sender.String(),
+			tags.Recipient, htlc.Recipient.String(),
+			tags.HashLock, hex.EncodeToString(htlc.HashLock),
+		),
+	}
+}
+
+// handleMsgClaimHTLC releases the escrowed coins to the recipient, less the stability tax
+func handleMsgClaimHTLC(ctx sdk.Context, k Keeper, msg MsgClaimHTLC) sdk.Result {
+	htlc, err := k.GetHTLC(ctx, msg.LockID)
+	if err != nil {
+		return err.Result()
+	}
+
+	if htlc.IsExpired(ctx.BlockHeight()) {
+		return ErrHTLCExpired(htlc.LockID, htlc.TimeoutHeight).Result()
+	}
+
+	if !bytes.Equal(HashPreimage(msg.Preimage), htlc.HashLock) {
+		return ErrInvalidPreimage(htlc.LockID).Result()
+	}
+
+	// The tax is paid out of the escrowed coins
+	taxes, err := payTax(ctx, k.bk, k.tk, k.fk, EscrowAddress, htlc.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	_, err = k.bk.SendCoins(ctx, EscrowAddress, htlc.Recipient, htlc.Amount.Sub(taxes))
+	if err != nil {
+		return err.Result()
+	}
+
+	k.DeleteHTLC(ctx, htlc)
+
+	log := NewLog()
+	log = log.append(LogKeyTax, taxes.String())
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionHTLCClaimed,
+			tags.LockID, strconv.FormatUint(htlc.LockID, 10),
+			tags.Recipient, htlc.Recipient.String(),
+			tags.HashLock, hex.EncodeToString(htlc.HashLock),
+			tags.Preimage, hex.EncodeToString(msg.Preimage),
+		),
+		Log: log.String(),
+	}
+}
+
+// handleMsgRefundHTLC returns the escrowed coins of an expired lock to the sender
+func handleMsgRefundHTLC(ctx sdk.Context, k Keeper, msg MsgRefundHTLC) sdk.Result {
+	htlc, err := k.GetHTLC(ctx, msg.LockID)
+	if err != nil {
+		return err.Result()
+	}
+
+	// Only the sender can ask for the refund
+	if !htlc.Sender.Equals(msg.Sender) {
+		return ErrInvalidSender(msg.Sender).Result()
+	}
+
+	if !htlc.IsExpired(ctx.BlockHeight()) {
+		return ErrHTLCNotExpired(htlc.LockID, htlc.TimeoutHeight).Result()
+	}
+
+	resTags, err := refundHTLC(ctx, k, htlc)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: resTags,
+	}
+}
+
+// refundHTLC returns the escrowed coins of {htlc} to its sender and removes it; no tax is charged
+func refundHTLC(ctx sdk.Context, k Keeper, htlc HTLC) (sdk.Tags, sdk.Error) {
+	_, err := k.bk.SendCoins(ctx, EscrowAddress, htlc.Sender, htlc.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	k.DeleteHTLC(ctx, htlc)
+
+	return sdk.NewTags(
+		tags.Action, tags.ActionHTLCRefunded,
+		tags.LockID, strconv.FormatUint(htlc.LockID, 10),
+		tags.Sender, htlc.Sender.String(),
+	), nil
+}
+
+// handleMsgCreateLazyVestingAccount creates a new lazy graded vesting account funded by the sender,
+// or a clawback vesting account if the sender keeps the right to claw back unvested coins.
+// The funding is taxed as a send.
+func handleMsgCreateLazyVestingAccount(ctx sdk.Context, k Keeper, msg MsgCreateLazyVestingAccount) sdk.Result {
+	if !k.bk.GetSendEnabled(ctx) {
+		return bank.ErrSendDisabled(k.bk.Codespace()).Result()
+	}
+
+	if k.ak.GetAccount(ctx, msg.Recipient) != nil {
+		return ErrAccountExists(msg.Recipient).Result()
+	}
+
+	taxes, err := payTax(ctx, k.bk, k.tk, k.fk, msg.Sender, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	_, _, err = k.bk.SubtractCoins(ctx, msg.Sender, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	baseAccount := auth.NewBaseAccountWithAddress(msg.Recipient)
+	baseAccount.AccountNumber = k.ak.GetNextAccountNumber(ctx)
+	baseAccount.Coins = msg.Amount
+	if msg.Clawback {
+		k.ak.SetAccount(ctx, types.NewBaseClawbackVestingAccount(&baseAccount, msg.LazyVestingSchedules, msg.Sender))
+	} else {
+		k.ak.SetAccount(ctx, types.NewBaseLazyGradedVestingAccount(&baseAccount, msg.LazyVestingSchedules))
+	}
+
+	log := NewLog()
+	log = log.append(LogKeyTax, taxes.String())
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionVestingCreated,
+			tags.Sender, msg.Sender.String(),
+			tags.Recipient, msg.Recipient.String(),
+		),
+		Log: log.String(),
+	}
+}
+
+// handleMsgClawback stops the vesting of a clawback vesting account and returns its unvested coins to the funder.
+// Unvested coins held by the account are returned immediately. Unvested tokens that are delegated are undelegated,
+// and returned to the funder at the end of the unbonding period, or right away if the validator is unbonded.
+// Vested coins stay with the account.
+// No tax is charged on the clawback.
+func handleMsgClawback(ctx sdk.Context, k Keeper, msg MsgClawback) sdk.Result {
+	account, ok := k.ak.GetAccount(ctx, msg.Account).(types.ClawbackVestingAccount)
+	if !ok {
+		return ErrNotClawbackAccount(msg.Account).Result()
+	}
+
+	if !account.GetFunder().Equals(msg.Funder) {
+		return ErrInvalidFunder(msg.Funder).Result()
+	}
+
+	if account.IsClawedBack() {
+		return ErrAlreadyClawedBack(msg.Account, account.GetClawbackTime()).Result()
+	}
+
+	clawedBack, delegatedVesting := account.Clawback(ctx.BlockHeader().Time)
+	k.ak.SetAccount(ctx, account)
+
+	_, _, err := k.bk.AddCoins(ctx, msg.Funder, clawedBack)
+	if err != nil {
+		return err.Result()
+	}
+
+	bondDenom := k.sk.BondDenom(ctx)
+	undelegated, err := clawbackDelegations(ctx, k, msg.Account, msg.Funder, delegatedVesting.AmountOf(bondDenom))
+	if err != nil {
+		return err.Result()
+	}
+
+	log := NewLog()
+	log = log.append(LogKeyClawedBack, clawedBack.String())
+	log = log.append(LogKeyUndelegated, sdk.NewCoin(bondDenom, undelegated).String())
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Action, tags.ActionVestingClawback,
+			tags.Sender, msg.Funder.String(),
+			tags.Account, msg.Account.String(),
+		),
+		Log: log.String(),
+	}
+}
+
+// payTax charges the stability tax on MsgSend, MsgMultiSend, scheduled payment installments, HTLC claims
+// and the funding of vesting accounts.
 func payTax(ctx sdk.Context, bk bank.Keeper, tk treasury.Keeper, fk auth.FeeCollectionKeeper,
 	taxPayer sdk.AccAddress, principal sdk.Coins) (taxes sdk.Coins, err sdk.Error) {
 
-	taxRate := tk.GetTaxRate(ctx, util.GetEpoch(ctx))
+	taxRate := tk.GetTaxRate(ctx, tk.GetEpoch(ctx))
 
 	if taxRate.Equal(sdk.ZeroDec()) {
 		return nil, nil
 	}
 
 	for _, coin := range principal {
-		taxDue := sdk.NewDecFromInt(coin.Amount).Mul(taxRate).TruncateInt()
-
-		// If tax due is greater than the tax cap, cap!
-		taxCap := tk.GetTaxCap(ctx, coin.Denom)
-		if taxDue.GT(taxCap) {
-			taxDue = taxCap
-		}
-
+		taxDue, _ := tk.ComputeTax(ctx, taxRate, coin)
 		if taxDue.Equal(sdk.ZeroInt()) {
 			continue
 		}
@@ -2,187 +2,28 @@ package pay
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/terra-project/core/types"
 	"github.com/terra-project/core/types/assets"
 	"github.com/terra-project/core/types/util"
-	"github.com/terra-project/core/x/market"
-	"github.com/terra-project/core/x/mint"
-	"github.com/terra-project/core/x/oracle"
 	"github.com/terra-project/core/x/treasury"
 
 	"github.com/stretchr/testify/require"
-	abci "github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/crypto"
-	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
-	dbm "github.com/tendermint/tendermint/libs/db"
-	"github.com/tendermint/tendermint/libs/log"
 
-	"github.com/cosmos/cosmos-sdk/codec"
-	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
-	distr "github.com/cosmos/cosmos-sdk/x/distribution"
-	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 )
 
-var (
-	addrs = []sdk.AccAddress{
-		sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
-		sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
-		sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
-	}
-
-	valConsPubKeys = []crypto.PubKey{
-		ed25519.GenPrivKey().PubKey(),
-		ed25519.GenPrivKey().PubKey(),
-		ed25519.GenPrivKey().PubKey(),
-	}
-
-	valConsAddrs = []sdk.ConsAddress{
-		sdk.ConsAddress(valConsPubKeys[0].Address()),
-		sdk.ConsAddress(valConsPubKeys[1].Address()),
-		sdk.ConsAddress(valConsPubKeys[2].Address()),
-	}
-
-	uSDRAmount = sdk.NewInt(1005).MulRaw(assets.MicroUnit)
-)
-
-type testInput struct {
-	ctx            sdk.Context
-	accKeeper      auth.AccountKeeper
-	bankKeeper     bank.Keeper
-	treasuryKeeper treasury.Keeper
-	feeKeeper      auth.FeeCollectionKeeper
-}
-
-func newTestCodec() *codec.Codec {
-	cdc := codec.New()
-
-	bank.RegisterCodec(cdc)
-	auth.RegisterCodec(cdc)
-	sdk.RegisterCodec(cdc)
-	codec.RegisterCrypto(cdc)
-
-	return cdc
-}
-
-func createTestInput(t *testing.T) testInput {
-	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
-	keyParams := sdk.NewKVStoreKey(params.StoreKey)
-	tKeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
-	keyTreasury := sdk.NewKVStoreKey(treasury.StoreKey)
-	keyMint := sdk.NewKVStoreKey(mint.StoreKey)
-	keyOracle := sdk.NewKVStoreKey(oracle.StoreKey)
-	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
-	tKeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
-	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
-	tKeyDistr := sdk.NewTransientStoreKey(distr.TStoreKey)
-	keyFeeCollection := sdk.NewKVStoreKey(auth.FeeStoreKey)
-	keyMarket := sdk.NewKVStoreKey(market.StoreKey)
-
-	cdc := newTestCodec()
-	db := dbm.NewMemDB()
-	ms := store.NewCommitMultiStore(db)
-	ctx := sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
-
-	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tKeyParams, sdk.StoreTypeTransient, db)
-	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyTreasury, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyMint, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyOracle, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tKeyStaking, sdk.StoreTypeTransient, db)
-	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tKeyDistr, sdk.StoreTypeTransient, db)
-	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyMarket, sdk.StoreTypeIAVL, db)
-
-	require.NoError(t, ms.LoadLatestVersion())
-
-	paramsKeeper := params.NewKeeper(cdc, keyParams, tKeyParams)
-	accKeeper := auth.NewAccountKeeper(
-		cdc,
-		keyAcc,
-		paramsKeeper.Subspace(auth.DefaultParamspace),
-		auth.ProtoBaseAccount,
-	)
-
-	bankKeeper := bank.NewBaseKeeper(
-		accKeeper,
-		paramsKeeper.Subspace(bank.DefaultParamspace),
-		bank.DefaultCodespace,
-	)
-
-	stakingKeeper := staking.NewKeeper(
-		cdc,
-		keyStaking, tKeyStaking,
-		bankKeeper, paramsKeeper.Subspace(staking.DefaultParamspace),
-		staking.DefaultCodespace,
-	)
-
-	feeCollectionKeeper := auth.NewFeeCollectionKeeper(
-		cdc,
-		keyFeeCollection,
-	)
-
-	distrKeeper := distr.NewKeeper(
-		cdc, keyDistr, paramsKeeper.Subspace(distr.DefaultParamspace),
-		bankKeeper, &stakingKeeper, feeCollectionKeeper, distr.DefaultCodespace,
-	)
-
-	stakingKeeper.SetPool(ctx, staking.InitialPool())
-	stakingKeeper.SetParams(ctx, staking.DefaultParams())
-
-	mintKeeper := mint.NewKeeper(
-		cdc,
-		keyMint,
-		stakingKeeper,
-		bankKeeper,
-		accKeeper,
-	)
-
-	oracleKeeper := oracle.NewKeeper(
-		cdc,
-		keyOracle,
-		mintKeeper,
-		distrKeeper,
-		feeCollectionKeeper,
-		&stakingKeeper,
-		paramsKeeper.Subspace(oracle.DefaultParamspace),
-	)
-
-	marketKeeper := market.NewKeeper(cdc, keyMarket, oracleKeeper, mintKeeper,
-		paramsKeeper.Subspace(market.DefaultParamspace))
-	marketKeeper.SetParams(ctx, market.DefaultParams())
-
-	treasuryKeeper := treasury.NewKeeper(
-		cdc,
-		keyTreasury,
-		stakingKeeper.GetValidatorSet(),
-		mintKeeper,
-		marketKeeper,
-		paramsKeeper.Subspace(treasury.DefaultParamspace),
-	)
-
-	for _, addr := range addrs {
-		_, _, err := bankKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)})
-		require.NoError(t, err)
-	}
-
-	return testInput{ctx, accKeeper, bankKeeper, treasuryKeeper, feeCollectionKeeper}
-}
-
 func TestHandlerMsgSendTransfersDisabled(t *testing.T) {
 	input := createTestInput(t)
 	input.bankKeeper.SetSendEnabled(input.ctx, false)
 
-	handler := NewHandler(input.bankKeeper, input.treasuryKeeper, input.feeKeeper)
+	handler := NewHandler(input.payKeeper)
 	amt := sdk.NewInt(5)
 	msg := bank.NewMsgSend(addrs[0], addrs[1], sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, amt)})
 
@@ -204,7 +45,7 @@ func TestHandlerMsgSendTransfersEnabled(t *testing.T) {
 	input.treasuryKeeper.SetParams(input.ctx, params)
 	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.ZeroDec()) // 0.0%
 
-	handler := NewHandler(input.bankKeeper, input.treasuryKeeper, input.feeKeeper)
+	handler := NewHandler(input.payKeeper)
 	amt := sdk.NewInt(5).MulRaw(assets.MicroUnit)
 	msg := bank.NewMsgSend(addrs[0], addrs[1], sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, amt)})
 
@@ -228,7 +69,7 @@ func TestHandlerMsgSendTax(t *testing.T) {
 	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.NewDecWithPrec(1, 3)) // 0.1%
 	input.treasuryKeeper.SetParams(input.ctx, params)
 
-	handler := NewHandler(input.bankKeeper, input.treasuryKeeper, input.feeKeeper)
+	handler := NewHandler(input.payKeeper)
 	amt := sdk.NewInt(1000).MulRaw(assets.MicroUnit)
 	msg := bank.NewMsgSend(addrs[0], addrs[1], sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, amt)})
 
@@ -288,7 +129,7 @@ func TestHandlerMsgMultiSendTax(t *testing.T) {
 	params.TaxPolicy.Cap = sdk.NewInt64Coin(assets.MicroSDRDenom, sdk.NewInt(2).MulRaw(assets.MicroUnit).Int64()) // 2 SDR cap
 	input.treasuryKeeper.SetParams(input.ctx, params)
 
-	handler := NewHandler(input.bankKeeper, input.treasuryKeeper, input.feeKeeper)
+	handler := NewHandler(input.payKeeper)
 
 	msg := bank.NewMsgMultiSend(
 		[]bank.Input{
@@ -322,3 +163,522 @@ func TestHandlerMsgMultiSendTax(t *testing.T) {
 	balance = uSDRAmount.Sub(sdk.NewDecFromIntWithPrec(sdk.NewInt(19089), 2).MulInt64(assets.MicroUnit).TruncateInt())
 	require.Equal(t, acc3.GetCoins(), sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, balance)})
 }
+
+func TestHandlerMsgCreateScheduledPayment(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(10)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+
+	// Start height in the past
+	msg := NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, 9, 10, 3)
+	res := handler(input.ctx, msg)
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	// Too many installments, or installments that overflow once escrowed
+	msg = NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, 10, 10, MaxPaymentCount+1)
+	require.NotNil(t, msg.ValidateBasic())
+
+	huge := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewIntFromBigInt(new(big.Int).Lsh(big.NewInt(1), 254)))}
+	msg = NewMsgCreateScheduledPayment(addrs[0], addrs[1], huge, 10, 10, 2)
+	require.NotNil(t, msg.ValidateBasic())
+
+	res = handler(input.ctx, msg)
+	require.Equal(t, CodeAmountOverflow, res.Code)
+
+	// Not enough coins to escrow every installment
+	msg = NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, 10, 10, 11)
+	res = handler(input.ctx, msg)
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	msg = NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, 10, 10, 3)
+	res = handler(input.ctx, msg)
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	payment, err := input.payKeeper.GetScheduledPayment(input.ctx, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), payment.NextPayHeight)
+	require.Equal(t, int64(0), payment.Paid)
+
+	escrowed := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(300).MulRaw(assets.MicroUnit))}
+	require.Equal(t, escrowed, input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Sub(escrowed), input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+}
+
+func TestHandlerMsgCancelScheduledPayment(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.ZeroDec())
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+
+	res := handler(input.ctx, NewMsgCreateScheduledPayment(addrs[0], addrs[1], amt, 5, 10, 3))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// Pay the first installment
+	input.ctx = input.ctx.WithBlockHeight(5)
+	EndBlocker(input.ctx, input.payKeeper)
+
+	// Only the sender can cancel
+	res = handler(input.ctx, NewMsgCancelScheduledPayment(1, addrs[1]))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	res = handler(input.ctx, NewMsgCancelScheduledPayment(1, addrs[0]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	_, err := input.payKeeper.GetScheduledPayment(input.ctx, 1)
+	require.NotNil(t, err)
+
+	// The two unpaid installments are refunded
+	require.True(t, input.bankKeeper.GetCoins(input.ctx, EscrowAddress).Empty())
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Sub(amt), input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Add(amt), input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+
+	// Nothing is paid out after cancellation
+	input.ctx = input.ctx.WithBlockHeight(15)
+	EndBlocker(input.ctx, input.payKeeper)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Add(amt), input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+
+	// Already cancelled
+	res = handler(input.ctx, NewMsgCancelScheduledPayment(1, addrs[0]))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+}
+
+func TestHandlerHTLCClaim(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.NewDecWithPrec(1, 3)) // 0.1%
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+	preimage := []byte("secret")
+
+	// Timeout must be in the future
+	res := handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage(preimage), 1))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	res = handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage(preimage), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	require.Equal(t, amt, input.bankKeeper.GetCoins(input.ctx, EscrowAddress))
+
+	// Wrong preimage
+	res = handler(input.ctx, NewMsgClaimHTLC(1, addrs[2], []byte("guess")))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	// Not expired yet
+	res = handler(input.ctx, NewMsgRefundHTLC(1, addrs[0]))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	// Anyone with the preimage can claim for the recipient
+	res = handler(input.ctx, NewMsgClaimHTLC(1, addrs[2], preimage))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	tax := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(1).MulRaw(assets.MicroUnit/10))}
+	require.Equal(t, tax, input.feeKeeper.GetCollectedFees(input.ctx))
+	require.Equal(t, tax, input.treasuryKeeper.PeekTaxProceeds(input.ctx, util.GetEpoch(input.ctx)))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}.Add(amt).Sub(tax), input.bankKeeper.GetCoins(input.ctx, addrs[1]))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}, input.bankKeeper.GetCoins(input.ctx, addrs[2]))
+	require.True(t, input.bankKeeper.GetCoins(input.ctx, EscrowAddress).Empty())
+
+	// Already claimed
+	res = handler(input.ctx, NewMsgClaimHTLC(1, addrs[2], preimage))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+}
+
+func TestHandlerHTLCRefund(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(100).MulRaw(assets.MicroUnit))}
+	preimage := []byte("secret")
+
+	res := handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage(preimage), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	input.ctx = input.ctx.WithBlockHeight(10)
+
+	// Expired locks cannot be claimed
+	res = handler(input.ctx, NewMsgClaimHTLC(1, addrs[1], preimage))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	// Only the sender can ask for the refund
+	res = handler(input.ctx, NewMsgRefundHTLC(1, addrs[1]))
+	require.False(t, res.IsOK(), "expected failed message execution: %v", res.Log)
+
+	res = handler(input.ctx, NewMsgRefundHTLC(1, addrs[0]))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// No tax is charged on refunds
+	require.Equal(t, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)}, input.bankKeeper.GetCoins(input.ctx, addrs[0]))
+	require.True(t, input.bankKeeper.GetCoins(input.ctx, EscrowAddress).Empty())
+
+	_, err := input.payKeeper.GetHTLC(input.ctx, 1)
+	require.NotNil(t, err)
+}
+
+func TestHandlerMsgCreateLazyVestingAccount(t *testing.T) {
+	input := createTestInput(t)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.ZeroDec())
+
+	stakingParams := staking.DefaultParams()
+	stakingParams.BondDenom = assets.MicroLunaDenom
+	input.stakingKeeper.SetParams(input.ctx, stakingParams)
+
+	lunaAmt := sdk.NewInt(1000).MulRaw(assets.MicroUnit)
+	for _, addr := range addrs[:2] {
+		addLuna(t, input, addr, lunaAmt)
+	}
+
+	handler := NewHandler(input.payKeeper)
+	now := input.ctx.BlockHeader().Time.Unix()
+	schedules := []types.LazyVestingSchedule{
+		types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+			types.NewLazySchedule(now+1000, now+2000, sdk.NewDecWithPrec(5, 1)),
+			types.NewLazySchedule(now+2000, now+3000, sdk.NewDecWithPrec(5, 1)),
+		}),
+	}
+	grant := sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(500).MulRaw(assets.MicroUnit))}
+	grantee := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	// Schedules must be valid, and cover exactly the funded denoms
+	invalidSchedules := [][]types.LazyVestingSchedule{
+		nil,
+		{types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+			types.NewLazySchedule(now, now+1000, sdk.NewDecWithPrec(5, 1)),
+		})},
+		{schedules[0], schedules[0]},
+		{schedules[0], types.NewLazyVestingSchedule(assets.MicroSDRDenom, schedules[0].LazySchedules)},
+	}
+	for _, invalid := range invalidSchedules {
+		err := NewMsgCreateLazyVestingAccount(addrs[0], grantee, grant, invalid, false).ValidateBasic()
+		require.NotNil(t, err)
+	}
+
+	// The grant is moved from the funder to a new vesting account
+	msg := NewMsgCreateLazyVestingAccount(addrs[0], grantee, grant, schedules, false)
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(input.ctx, msg)
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	require.Equal(t, lunaAmt.Sub(grant.AmountOf(assets.MicroLunaDenom)), input.bankKeeper.GetCoins(input.ctx, addrs[0]).AmountOf(assets.MicroLunaDenom))
+
+	account, ok := input.accKeeper.GetAccount(input.ctx, grantee).(*types.BaseLazyGradedVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, grant, account.GetCoins())
+	require.Equal(t, grant, account.GetOriginalVesting())
+	require.Equal(t, schedules, account.GetLazyVestingSchedules())
+	require.True(t, account.SpendableCoins(input.ctx.BlockHeader().Time).IsZero())
+
+	// Existing addresses are rejected
+	res = handler(input.ctx, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, CodeAccountExists, res.Code)
+
+	res = handler(input.ctx, NewMsgCreateLazyVestingAccount(addrs[0], addrs[2], grant, schedules, false))
+	require.False(t, res.IsOK())
+	require.Equal(t, CodeAccountExists, res.Code)
+
+	// Vesting coins cannot be sent ...
+	res = handler(input.ctx, bank.NewMsgSend(grantee, addrs[0], grant))
+	require.False(t, res.IsOK())
+
+	// ... but can be delegated
+	stakingHandler := staking.NewHandler(input.stakingKeeper)
+	valAddr := sdk.ValAddress(addrs[1])
+	commission := staking.NewCommissionMsg(sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1), sdk.NewDec(0))
+	res = stakingHandler(input.ctx, staking.NewMsgCreateValidator(valAddr, valConsPubKeys[1],
+		sdk.NewCoin(assets.MicroLunaDenom, lunaAmt), staking.Description{}, commission, sdk.OneInt()))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	delegation := sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(300).MulRaw(assets.MicroUnit))
+	res = stakingHandler(input.ctx, staking.NewMsgDelegate(grantee, valAddr, delegation))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	account = input.accKeeper.GetAccount(input.ctx, grantee).(*types.BaseLazyGradedVestingAccount)
+	require.Equal(t, sdk.Coins{delegation}, account.GetDelegatedVesting())
+	require.True(t, account.GetDelegatedFree().IsZero())
+	require.Equal(t, grant.Sub(sdk.Coins{delegation}), account.GetCoins())
+}
+
+func TestHandlerMsgClawback(t *testing.T) {
+	input := createTestInput(t)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.ZeroDec())
+
+	stakingParams := staking.DefaultParams()
+	stakingParams.BondDenom = assets.MicroLunaDenom
+	input.stakingKeeper.SetParams(input.ctx, stakingParams)
+
+	lunaAmt := sdk.NewInt(1000).MulRaw(assets.MicroUnit)
+	for _, addr := range addrs[:2] {
+		addLuna(t, input, addr, lunaAmt)
+	}
+
+	handler := NewHandler(input.payKeeper)
+	stakingHandler := staking.NewHandler(input.stakingKeeper)
+
+	// Bond a validator to delegate to
+	valAddr := sdk.ValAddress(addrs[1])
+	commission := staking.NewCommissionMsg(sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1), sdk.NewDec(0))
+	res := stakingHandler(input.ctx, staking.NewMsgCreateValidator(valAddr, valConsPubKeys[1],
+		sdk.NewCoin(assets.MicroLunaDenom, lunaAmt), staking.Description{}, commission, sdk.OneInt()))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	staking.EndBlocker(input.ctx, input.stakingKeeper)
+
+	// Half of the grant has vested
+	now := input.ctx.BlockHeader().Time.Unix()
+	schedules := []types.LazyVestingSchedule{
+		types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+			types.NewLazySchedule(now-1000, now, sdk.NewDecWithPrec(5, 1)),
+			types.NewLazySchedule(now+1000, now+2000, sdk.NewDecWithPrec(5, 1)),
+		}),
+	}
+	grantAmt := sdk.NewInt(500).MulRaw(assets.MicroUnit)
+	grant := sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, grantAmt)}
+	grantee := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	res = handler(input.ctx, NewMsgCreateLazyVestingAccount(addrs[0], grantee, grant, schedules, true))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	account, ok := input.accKeeper.GetAccount(input.ctx, grantee).(*types.BaseClawbackVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, addrs[0], account.GetFunder())
+	require.False(t, account.IsClawedBack())
+
+	// The grantee delegates 200 of the 250 unvested Luna
+	delegation := sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(200).MulRaw(assets.MicroUnit))
+	res = stakingHandler(input.ctx, staking.NewMsgDelegate(grantee, valAddr, delegation))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// Only the funder can claw back, and only clawback vesting accounts
+	res = handler(input.ctx, NewMsgClawback(addrs[2], grantee))
+	require.False(t, res.IsOK())
+	require.Equal(t, CodeInvalidFunder, res.Code)
+
+	res = handler(input.ctx, NewMsgClawback(addrs[0], addrs[1]))
+	require.False(t, res.IsOK())
+	require.Equal(t, CodeNotClawbackAccount, res.Code)
+
+	res = handler(input.ctx, NewMsgClawback(addrs[0], grantee))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// The 50 unvested Luna held by the account are returned right away
+	unvestedHeld := sdk.NewInt(50).MulRaw(assets.MicroUnit)
+	funderLuna := lunaAmt.Sub(grantAmt).Add(unvestedHeld)
+	require.Equal(t, funderLuna, input.bankKeeper.GetCoins(input.ctx, addrs[0]).AmountOf(assets.MicroLunaDenom))
+
+	// The vested coins stay with the account, and are spendable
+	account = input.accKeeper.GetAccount(input.ctx, grantee).(*types.BaseClawbackVestingAccount)
+	require.True(t, account.IsClawedBack())
+	vested := sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(250).MulRaw(assets.MicroUnit))}
+	require.Equal(t, vested, account.GetCoins())
+	require.Equal(t, vested, account.SpendableCoins(input.ctx.BlockHeader().Time))
+	require.True(t, account.GetDelegatedVesting().IsZero())
+
+	// The unvested delegation is undelegated on behalf of the funder
+	_, found := input.stakingKeeper.GetDelegation(input.ctx, grantee, valAddr)
+	require.False(t, found)
+	_, found = input.stakingKeeper.GetUnbondingDelegation(input.ctx, grantee, valAddr)
+	require.False(t, found)
+
+	ubd, found := input.stakingKeeper.GetUnbondingDelegation(input.ctx, addrs[0], valAddr)
+	require.True(t, found)
+	require.Equal(t, 1, len(ubd.Entries))
+	require.Equal(t, delegation.Amount, ubd.Entries[0].Balance)
+
+	// Grants can only be clawed back once
+	res = handler(input.ctx, NewMsgClawback(addrs[0], grantee))
+	require.False(t, res.IsOK())
+	require.Equal(t, CodeAlreadyClawedBack, res.Code)
+
+	// The undelegated tokens are released to the funder after the unbonding period
+	ctx := input.ctx.WithBlockTime(input.ctx.BlockHeader().Time.Add(stakingParams.UnbondingTime))
+	staking.EndBlocker(ctx, input.stakingKeeper)
+
+	require.Equal(t, funderLuna.Add(delegation.Amount), input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(assets.MicroLunaDenom))
+	require.Equal(t, vested, input.bankKeeper.GetCoins(ctx, grantee))
+}
+
+// setupClawbackGrant bonds a validator, grants 500 Luna to a clawback vesting account funded by addrs[0],
+// half of which has vested, and delegates 200 of the unvested Luna of the grantee to the validator.
+func setupClawbackGrant(t *testing.T, input testInput) (valAddr sdk.ValAddress, grantee sdk.AccAddress, delegation sdk.Coin) {
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+	input.treasuryKeeper.SetParams(input.ctx, treasury.DefaultParams())
+	input.treasuryKeeper.SetTaxRate(input.ctx, sdk.ZeroDec())
+
+	stakingParams := staking.DefaultParams()
+	stakingParams.BondDenom = assets.MicroLunaDenom
+	input.stakingKeeper.SetParams(input.ctx, stakingParams)
+
+	lunaAmt := sdk.NewInt(1000).MulRaw(assets.MicroUnit)
+	for _, addr := range addrs[:2] {
+		addLuna(t, input, addr, lunaAmt)
+	}
+
+	handler := NewHandler(input.payKeeper)
+	stakingHandler := staking.NewHandler(input.stakingKeeper)
+
+	valAddr = sdk.ValAddress(addrs[1])
+	commission := staking.NewCommissionMsg(sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 1), sdk.NewDec(0))
+	res := stakingHandler(input.ctx, staking.NewMsgCreateValidator(valAddr, valConsPubKeys[1],
+		sdk.NewCoin(assets.MicroLunaDenom, lunaAmt), staking.Description{}, commission, sdk.OneInt()))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	staking.EndBlocker(input.ctx, input.stakingKeeper)
+
+	// The second half vests well after the unbonding period
+	now := input.ctx.BlockHeader().Time.Unix()
+	later := now + 2*int64(stakingParams.UnbondingTime.Seconds())
+	schedules := []types.LazyVestingSchedule{
+		types.NewLazyVestingSchedule(assets.MicroLunaDenom, []types.LazySchedule{
+			types.NewLazySchedule(now-1000, now, sdk.NewDecWithPrec(5, 1)),
+			types.NewLazySchedule(later, later+1000, sdk.NewDecWithPrec(5, 1)),
+		}),
+	}
+	grant := sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(500).MulRaw(assets.MicroUnit))}
+	grantee = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	res = handler(input.ctx, NewMsgCreateLazyVestingAccount(addrs[0], grantee, grant, schedules, true))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	delegation = sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(200).MulRaw(assets.MicroUnit))
+	res = stakingHandler(input.ctx, staking.NewMsgDelegate(grantee, valAddr, delegation))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	return
+}
+
+func TestHandlerMsgClawbackJailedValidator(t *testing.T) {
+	input := createTestInput(t)
+	valAddr, grantee, delegation := setupClawbackGrant(t, input)
+	funderLuna := input.bankKeeper.GetCoins(input.ctx, addrs[0]).AmountOf(assets.MicroLunaDenom)
+
+	// The validator is jailed, and starts unbonding
+	input.stakingKeeper.Jail(input.ctx, valConsAddrs[1])
+	staking.EndBlocker(input.ctx, input.stakingKeeper)
+
+	validator, found := input.stakingKeeper.GetValidator(input.ctx, valAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.Unbonding, validator.Status)
+
+	res := NewHandler(input.payKeeper)(input.ctx, NewMsgClawback(addrs[0], grantee))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// The unbonding entry matures with the validator, and belongs to the funder
+	_, found = input.stakingKeeper.GetUnbondingDelegation(input.ctx, grantee, valAddr)
+	require.False(t, found)
+
+	ubd, found := input.stakingKeeper.GetUnbondingDelegation(input.ctx, addrs[0], valAddr)
+	require.True(t, found)
+	require.Equal(t, 1, len(ubd.Entries))
+	require.Equal(t, delegation.Amount, ubd.Entries[0].Balance)
+	require.Equal(t, validator.UnbondingCompletionTime, ubd.Entries[0].CompletionTime)
+
+	unvestedHeld := sdk.NewInt(50).MulRaw(assets.MicroUnit)
+	require.Equal(t, funderLuna.Add(unvestedHeld), input.bankKeeper.GetCoins(input.ctx, addrs[0]).AmountOf(assets.MicroLunaDenom))
+
+	ctx := input.ctx.WithBlockTime(validator.UnbondingCompletionTime)
+	staking.EndBlocker(ctx, input.stakingKeeper)
+
+	require.Equal(t, funderLuna.Add(unvestedHeld).Add(delegation.Amount), input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(assets.MicroLunaDenom))
+}
+
+func TestHandlerMsgClawbackUnbondedValidator(t *testing.T) {
+	input := createTestInput(t)
+	valAddr, grantee, delegation := setupClawbackGrant(t, input)
+	funderLuna := input.bankKeeper.GetCoins(input.ctx, addrs[0]).AmountOf(assets.MicroLunaDenom)
+
+	// The validator is jailed, and is unbonded after the unbonding period
+	input.stakingKeeper.Jail(input.ctx, valConsAddrs[1])
+	staking.EndBlocker(input.ctx, input.stakingKeeper)
+
+	ctx := input.ctx.WithBlockTime(input.ctx.BlockHeader().Time.Add(input.stakingKeeper.UnbondingTime(input.ctx)))
+	staking.EndBlocker(ctx, input.stakingKeeper)
+
+	validator, found := input.stakingKeeper.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.Unbonded, validator.Status)
+
+	res := NewHandler(input.payKeeper)(ctx, NewMsgClawback(addrs[0], grantee))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	// The undelegation completes at once, and the tokens go straight to the funder
+	_, found = input.stakingKeeper.GetDelegation(ctx, grantee, valAddr)
+	require.False(t, found)
+	_, found = input.stakingKeeper.GetUnbondingDelegation(ctx, grantee, valAddr)
+	require.False(t, found)
+	_, found = input.stakingKeeper.GetUnbondingDelegation(ctx, addrs[0], valAddr)
+	require.False(t, found)
+
+	unvestedHeld := sdk.NewInt(50).MulRaw(assets.MicroUnit)
+	require.Equal(t, funderLuna.Add(unvestedHeld).Add(delegation.Amount), input.bankKeeper.GetCoins(ctx, addrs[0]).AmountOf(assets.MicroLunaDenom))
+
+	vested := sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(250).MulRaw(assets.MicroUnit))}
+	require.Equal(t, vested, input.bankKeeper.GetCoins(ctx, grantee))
+}
+
+func TestHandlerMsgClawbackMaxEntries(t *testing.T) {
+	input := createTestInput(t)
+	valAddr, grantee, delegation := setupClawbackGrant(t, input)
+	stakingHandler := staking.NewHandler(input.stakingKeeper)
+	handler := NewHandler(input.payKeeper)
+	maxEntries := int(input.stakingKeeper.MaxEntries(input.ctx))
+	unbondAmt := sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(1).MulRaw(assets.MicroUnit))
+
+	// The funder is at the max unbonding entries with the validator
+	res := stakingHandler(input.ctx, staking.NewMsgDelegate(addrs[0], valAddr, delegation))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	for i := 0; i < maxEntries; i++ {
+		res := stakingHandler(input.ctx, staking.NewMsgUndelegate(addrs[0], valAddr, unbondAmt))
+		require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	}
+
+	// So is the grantee, one block later
+	ctx := input.ctx.WithBlockHeight(input.ctx.BlockHeight() + 1).WithBlockTime(input.ctx.BlockHeader().Time.Add(time.Second))
+	for i := 0; i < maxEntries; i++ {
+		res := stakingHandler(ctx, staking.NewMsgUndelegate(grantee, valAddr, unbondAmt))
+		require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	}
+
+	// The funder cannot take the unbonding entries of the grantee; the failed message is discarded
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, NewMsgClawback(addrs[0], grantee))
+	require.False(t, res.IsOK())
+	require.Equal(t, CodeMaxUnbondingEntries, res.Code)
+
+	// Once the unbonding entries of the funder mature, the entries of the grantee don't block the clawback
+	ctx = input.ctx.WithBlockHeight(input.ctx.BlockHeight() + 2).WithBlockTime(input.ctx.BlockHeader().Time.Add(input.stakingKeeper.UnbondingTime(ctx)))
+	staking.EndBlocker(ctx, input.stakingKeeper)
+
+	ubd, found := input.stakingKeeper.GetUnbondingDelegation(ctx, grantee, valAddr)
+	require.True(t, found)
+	require.Equal(t, maxEntries, len(ubd.Entries))
+
+	res = handler(ctx, NewMsgClawback(addrs[0], grantee))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	_, found = input.stakingKeeper.GetDelegation(ctx, grantee, valAddr)
+	require.False(t, found)
+	_, found = input.stakingKeeper.GetUnbondingDelegation(ctx, grantee, valAddr)
+	require.False(t, found)
+
+	// The entries of the grantee are merged into one entry of the funder, and the rest is undelegated
+	ubd, found = input.stakingKeeper.GetUnbondingDelegation(ctx, addrs[0], valAddr)
+	require.True(t, found)
+	require.Equal(t, 2, len(ubd.Entries))
+
+	moved := unbondAmt.Amount.MulRaw(int64(maxEntries))
+	require.Equal(t, moved, ubd.Entries[0].Balance)
+	require.Equal(t, delegation.Amount.Sub(moved), ubd.Entries[1].Balance)
+}
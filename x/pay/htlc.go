@@ -0,0 +1,74 @@
+package pay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HashLockLength is the length of a hash lock; the SHA-256 digest of the preimage
+const HashLockLength = sha256.Size
+
+// MaxPreimageLength is the maximum length of a preimage
+const MaxPreimageLength = 64
+
+// HTLC is a hash time-locked escrow of {Amount} from {Sender} to {Recipient}. It is released to
+// the recipient when the preimage of {HashLock} is revealed before {TimeoutHeight}, and refunded
+// to the sender otherwise.
+type HTLC struct {
+	LockID        uint64         `json:"lock_id"`        // ID of the lock
+	Sender        sdk.AccAddress `json:"sender"`         // Address of the sender
+	Recipient     sdk.AccAddress `json:"recipient"`      // Address of the recipient
+	Amount        sdk.Coins      `json:"amount"`         // Amount escrowed
+	HashLock      []byte         `json:"hash_lock"`      // SHA-256 hash of the preimage
+	TimeoutHeight int64          `json:"timeout_height"` // Block height from which the lock can no longer be claimed
+}
+
+// NewHTLC creates a new HTLC
+func NewHTLC(lockID uint64, sender, recipient sdk.AccAddress, amount sdk.Coins,
+	hashLock []byte, timeoutHeight int64) HTLC {
+	return HTLC{
+		LockID:        lockID,
+		Sender:        sender,
+		Recipient:     recipient,
+		Amount:        amount,
+		HashLock:      hashLock,
+		TimeoutHeight: timeoutHeight,
+	}
+}
+
+// IsExpired returns true if the lock can no longer be claimed at {height}
+func (h HTLC) IsExpired(height int64) bool {
+	return height >= h.TimeoutHeight
+}
+
+// String implements fmt.Stringer
+func (h HTLC) String() string {
+	return fmt.Sprintf(`HTLC
+	LockID: %d
+	Sender: %v
+	Recipient: %v
+	Amount: %v
+	HashLock: %s
+	TimeoutHeight: %d`,
+		h.LockID, h.Sender, h.Recipient, h.Amount, hex.EncodeToString(h.HashLock), h.TimeoutHeight)
+}
+
+// HTLCs is a collection of HTLC
+type HTLCs []HTLC
+
+func (h HTLCs) String() (out string) {
+	for _, val := range h {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// HashPreimage returns the hash lock of {preimage}
+func HashPreimage(preimage []byte) []byte {
+	hash := sha256.Sum256(preimage)
+	return hash[:]
+}
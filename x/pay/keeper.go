@@ -0,0 +1,261 @@
+package pay
+
+import (
+	"github.com/terra-project/core/x/treasury"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// EscrowAddress holds the funds escrowed by the pay module until they are paid out or refunded
+var EscrowAddress = sdk.AccAddress(crypto.AddressHash([]byte("payEscrow")))
+
+// Keeper of the pay store
+type Keeper struct {
+	cdc *codec.Codec
+	key sdk.StoreKey
+
+	ak auth.AccountKeeper
+	bk bank.Keeper
+	sk StakingKeeper
+	tk treasury.Keeper
+	fk auth.FeeCollectionKeeper
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ak auth.AccountKeeper, bk bank.Keeper,
+	sk StakingKeeper, tk treasury.Keeper, fk auth.FeeCollectionKeeper) Keeper {
+	return Keeper{
+		cdc: cdc,
+		key: key,
+		ak:  ak,
+		bk:  bk,
+		sk:  sk,
+		tk:  tk,
+		fk:  fk,
+	}
+}
+
+//-----------------------------------
+// Scheduled payment logic
+
+// NewPaymentID generates a new scheduled payment id; advances sequentially from 1
+func (k Keeper) NewPaymentID(ctx sdk.Context) (paymentID uint64) {
+	store := ctx.KVStore(k.key)
+	if bz := store.Get(keyNextPaymentID); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &paymentID)
+		paymentID++
+	} else {
+		paymentID = 1
+	}
+
+	k.setLastPaymentID(ctx, paymentID)
+	return
+}
+
+// setLastPaymentID records the last scheduled payment id handed out
+func (k Keeper) setLastPaymentID(ctx sdk.Context, paymentID uint64) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(paymentID)
+	store.Set(keyNextPaymentID, bz)
+}
+
+// GetScheduledPayment gets the ScheduledPayment with the given id from the store.
+func (k Keeper) GetScheduledPayment(ctx sdk.Context, paymentID uint64) (res ScheduledPayment, err sdk.Error) {
+	store := ctx.KVStore(k.key)
+
+	if bz := store.Get(keyScheduledPayment(paymentID)); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &res)
+	} else {
+		err = ErrPaymentNotFound(paymentID)
+	}
+	return
+}
+
+// StoreScheduledPayment sets a ScheduledPayment to the store, along with the sender and recipient indexes
+func (k Keeper) StoreScheduledPayment(ctx sdk.Context, payment ScheduledPayment) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(payment)
+	store.Set(keyScheduledPayment(payment.PaymentID), bz)
+
+	bz = k.cdc.MustMarshalBinaryLengthPrefixed(payment.PaymentID)
+	store.Set(keySenderPayment(payment.Sender, payment.PaymentID), bz)
+	store.Set(keyRecipientPayment(payment.Recipient, payment.PaymentID), bz)
+}
+
+// DeleteScheduledPayment deletes a ScheduledPayment and its indexes from the store
+func (k Keeper) DeleteScheduledPayment(ctx sdk.Context, payment ScheduledPayment) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyScheduledPayment(payment.PaymentID))
+	store.Delete(keySenderPayment(payment.Sender, payment.PaymentID))
+	store.Delete(keyRecipientPayment(payment.Recipient, payment.PaymentID))
+	store.Delete(keyPaymentQueue(payment.NextPayHeight, payment.PaymentID))
+}
+
+// IterateScheduledPayments iterates all scheduled payments in the store
+func (k Keeper) IterateScheduledPayments(ctx sdk.Context, handler func(ScheduledPayment) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefixScheduledPayment)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var payment ScheduledPayment
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &payment)
+
+		if handler(payment) {
+			break
+		}
+	}
+}
+
+// IterateScheduledPaymentsBySender iterates the scheduled payments sent by {sender}
+func (k Keeper) IterateScheduledPaymentsBySender(ctx sdk.Context, sender sdk.AccAddress, handler func(ScheduledPayment) (stop bool)) {
+	k.iterateIndexedPayments(ctx, prefixSenderPaymentAddr(sender), handler)
+}
+
+// IterateScheduledPaymentsByRecipient iterates the scheduled payments received by {recipient}
+func (k Keeper) IterateScheduledPaymentsByRecipient(ctx sdk.Context, recipient sdk.AccAddress, handler func(ScheduledPayment) (stop bool)) {
+	k.iterateIndexedPayments(ctx, prefixRecipientPaymentAddr(recipient), handler)
+}
+
+func (k Keeper) iterateIndexedPayments(ctx sdk.Context, prefix []byte, handler func(ScheduledPayment) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var paymentID uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &paymentID)
+
+		payment, err := k.GetScheduledPayment(ctx, paymentID)
+		if err != nil {
+			continue
+		}
+
+		if handler(payment) {
+			break
+		}
+	}
+}
+
+//-----------------------------------
+// Payment queue logic
+
+// PaymentQueueInsert inserts a payment id into the payment queue at {height}
+func (k Keeper) PaymentQueueInsert(ctx sdk.Context, height int64, paymentID uint64) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(paymentID)
+	store.Set(keyPaymentQueue(height, paymentID), bz)
+}
+
+// PaymentQueueRemove removes a payment id from the payment queue
+func (k Keeper) PaymentQueueRemove(ctx sdk.Context, height int64, paymentID uint64) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyPaymentQueue(height, paymentID))
+}
+
+// PaymentQueueIterateDue iterates the payment ids queued at or before {height}
+func (k Keeper) PaymentQueueIterateDue(ctx sdk.Context, height int64, handler func(uint64) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := store.Iterator(prefixPaymentQueue, sdk.PrefixEndBytes(prefixPaymentQueueHeight(height)))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var paymentID uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &paymentID)
+
+		if handler(paymentID) {
+			break
+		}
+	}
+}
+
+//-----------------------------------
+// HTLC logic
+
+// NewLockID generates a new hash time lock id; advances sequentially from 1
+func (k Keeper) NewLockID(ctx sdk.Context) (lockID uint64) {
+	store := ctx.KVStore(k.key)
+	if bz := store.Get(keyNextLockID); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &lockID)
+		lockID++
+	} else {
+		lockID = 1
+	}
+
+	k.setLastLockID(ctx, lockID)
+	return
+}
+
+// setLastLockID records the last hash time lock id handed out
+func (k Keeper) setLastLockID(ctx sdk.Context, lockID uint64) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(lockID)
+	store.Set(keyNextLockID, bz)
+}
+
+// GetHTLC gets the HTLC with the given id from the store.
+func (k Keeper) GetHTLC(ctx sdk.Context, lockID uint64) (res HTLC, err sdk.Error) {
+	store := ctx.KVStore(k.key)
+
+	if bz := store.Get(keyHTLC(lockID)); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &res)
+	} else {
+		err = ErrHTLCNotFound(lockID)
+	}
+	return
+}
+
+// StoreHTLC sets a HTLC to the store and queues it for expiry at its timeout height
+func (k Keeper) StoreHTLC(ctx sdk.Context, htlc HTLC) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(htlc)
+	store.Set(keyHTLC(htlc.LockID), bz)
+
+	bz = k.cdc.MustMarshalBinaryLengthPrefixed(htlc.LockID)
+	store.Set(keyHTLCExpiryQueue(htlc.TimeoutHeight, htlc.LockID), bz)
+}
+
+// DeleteHTLC deletes a HTLC and its expiry queue entry from the store
+func (k Keeper) DeleteHTLC(ctx sdk.Context, htlc HTLC) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyHTLC(htlc.LockID))
+	store.Delete(keyHTLCExpiryQueue(htlc.TimeoutHeight, htlc.LockID))
+}
+
+// IterateHTLCs iterates all HTLCs in the store
+func (k Keeper) IterateHTLCs(ctx sdk.Context, handler func(HTLC) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, append(prefixHTLC, ':'))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var htlc HTLC
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &htlc)
+
+		if handler(htlc) {
+			break
+		}
+	}
+}
+
+// HTLCExpiryQueueRemove removes a lock id from the expiry queue
+func (k Keeper) HTLCExpiryQueueRemove(ctx sdk.Context, height int64, lockID uint64) {
+	store := ctx.KVStore(k.key)
+	store.Delete(keyHTLCExpiryQueue(height, lockID))
+}
+
+// HTLCExpiryQueueIterateExpired iterates the lock ids that expire at or before {height}
+func (k Keeper) HTLCExpiryQueueIterateExpired(ctx sdk.Context, height int64, handler func(uint64) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := store.Iterator(prefixHTLCExpiryQueue, sdk.PrefixEndBytes(prefixHTLCExpiryQueueHeight(height)))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var lockID uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &lockID)
+
+		if handler(lockID) {
+			break
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package pay
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+var (
+	keyNextPaymentID = []byte("new-payment-id")
+	keyNextLockID    = []byte("new-lock-id")
+
+	prefixScheduledPayment = []byte("scheduled-payment")
+	prefixSenderPayment    = []byte("sender-payment")
+	prefixRecipientPayment = []byte("recipient-payment")
+	prefixPaymentQueue     = []byte("payment-queue")
+	prefixHTLC             = []byte("htlc")
+	prefixHTLCExpiryQueue  = []byte("expiry-queue")
+)
+
+func keyScheduledPayment(paymentID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", prefixScheduledPayment, paymentID))
+}
+
+func prefixSenderPaymentAddr(sender sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("%s:%s:", prefixSenderPayment, sender))
+}
+
+func keySenderPayment(sender sdk.AccAddress, paymentID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%s:%d", prefixSenderPayment, sender, paymentID))
+}
+
+func prefixRecipientPaymentAddr(recipient sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("%s:%s:", prefixRecipientPayment, recipient))
+}
+
+func keyRecipientPayment(recipient sdk.AccAddress, paymentID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%s:%d", prefixRecipientPayment, recipient, paymentID))
+}
+
+func prefixPaymentQueueHeight(height int64) []byte {
+	return []byte(fmt.Sprintf("%s:%020d", prefixPaymentQueue, height))
+}
+
+func keyPaymentQueue(height int64, paymentID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%020d:%d", prefixPaymentQueue, height, paymentID))
+}
+
+func keyHTLC(lockID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", prefixHTLC, lockID))
+}
+
+func prefixHTLCExpiryQueueHeight(height int64) []byte {
+	return []byte(fmt.Sprintf("%s:%020d", prefixHTLCExpiryQueue, height))
+}
+
+func keyHTLCExpiryQueue(height int64, lockID uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%020d:%d", prefixHTLCExpiryQueue, height, lockID))
+}
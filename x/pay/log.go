@@ -7,6 +7,12 @@ import (
 const (
 	// LogKeyTax is to record treasury tax for a pay msg
 	LogKeyTax = string("tax")
+
+	// LogKeyClawedBack is to record the unvested coins returned to the funder by a clawback
+	LogKeyClawedBack = string("clawed_back")
+
+	// LogKeyUndelegated is to record the unvested tokens undelegated on behalf of the funder by a clawback
+	LogKeyUndelegated = string("undelegated")
 )
 
 // Log is map type object to organize msg result
@@ -0,0 +1,473 @@
+package pay
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/terra-project/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgCreateScheduledPayment defines a message to schedule {Count} payments of {Amount}
+// from {Sender} to {Recipient}, every {Interval} blocks from {StartHeight}
+type MsgCreateScheduledPayment struct {
+	Sender      sdk.AccAddress `json:"sender"`       // Address of the sender
+	Recipient   sdk.AccAddress `json:"recipient"`    // Address of the recipient
+	Amount      sdk.Coins      `json:"amount"`       // Amount paid per installment
+	StartHeight int64          `json:"start_height"` // Block height of the first installment
+	Interval    int64          `json:"interval"`     // Number of blocks between installments
+	Count       int64          `json:"count"`        // Total number of installments
+}
+
+// NewMsgCreateScheduledPayment creates a MsgCreateScheduledPayment instance
+func NewMsgCreateScheduledPayment(sender, recipient sdk.AccAddress, amount sdk.Coins,
+	startHeight, interval, count int64) MsgCreateScheduledPayment {
+	return MsgCreateScheduledPayment{
+		Sender:      sender,
+		Recipient:   recipient,
+		Amount:      amount,
+		StartHeight: startHeight,
+		Interval:    interval,
+		Count:       count,
+	}
+}
+
+// Route returns msg route
+func (msg MsgCreateScheduledPayment) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgCreateScheduledPayment) Type() string { return "createscheduledpayment" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgCreateScheduledPayment) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgCreateScheduledPayment) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// ValidateBasic validate msg
+func (msg MsgCreateScheduledPayment) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Sender.String())
+	}
+	if len(msg.Recipient) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Recipient.String())
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("payment amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("payment amount must be positive")
+	}
+	if msg.StartHeight <= 0 {
+		return ErrInvalidStartHeight(msg.StartHeight, 0)
+	}
+	if msg.Interval <= 0 {
+		return ErrInvalidInterval(msg.Interval)
+	}
+	if msg.Count <= 0 || msg.Count > MaxPaymentCount {
+		return ErrInvalidCount(msg.Count)
+	}
+	if _, ok := mulCoins(msg.Amount, msg.Count); !ok {
+		return ErrAmountOverflow(msg.Amount, msg.Count)
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgCreateScheduledPayment) String() string {
+	return fmt.Sprintf(`MsgCreateScheduledPayment
+	Sender: %v
+	Recipient: %v
+	Amount: %v
+	StartHeight: %d
+	Interval: %d
+	Count: %d`, msg.Sender, msg.Recipient, msg.Amount, msg.StartHeight, msg.Interval, msg.Count)
+}
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgCancelScheduledPayment defines a message to cancel a scheduled payment and
+// refund the installments not yet paid to the sender
+type MsgCancelScheduledPayment struct {
+	PaymentID uint64         `json:"payment_id"` // ID of the scheduled payment
+	Sender    sdk.AccAddress `json:"sender"`     // Address of the sender
+}
+
+// NewMsgCancelScheduledPayment creates a MsgCancelScheduledPayment instance
+func NewMsgCancelScheduledPayment(paymentID uint64, sender sdk.AccAddress) MsgCancelScheduledPayment {
+	return MsgCancelScheduledPayment{
+		PaymentID: paymentID,
+		Sender:    sender,
+	}
+}
+
+// Route returns msg route
+func (msg MsgCancelScheduledPayment) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgCancelScheduledPayment) Type() string { return "cancelscheduledpayment" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgCancelScheduledPayment) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgCancelScheduledPayment) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// ValidateBasic validate msg
+func (msg MsgCancelScheduledPayment) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Sender.String())
+	}
+	if msg.PaymentID == 0 {
+		return ErrPaymentNotFound(msg.PaymentID)
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgCancelScheduledPayment) String() string {
+	return fmt.Sprintf(`MsgCancelScheduledPayment
+	PaymentID: %d
+	Sender: %v`, msg.PaymentID, msg.Sender)
+}
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgLockHTLC defines a message to escrow {Amount} for {Recipient} under a hash time lock
+type MsgLockHTLC struct {
+	Sender        sdk.AccAddress `json:"sender"`         // Address of the sender
+	Recipient     sdk.AccAddress `json:"recipient"`      // Address of the recipient
+	Amount        sdk.Coins      `json:"amount"`         // Amount to escrow
+	HashLock      []byte         `json:"hash_lock"`      // SHA-256 hash of the preimage
+	TimeoutHeight int64          `json:"timeout_height"` // Block height from which the lock can no longer be claimed
+}
+
+// NewMsgLockHTLC creates a MsgLockHTLC instance
+func NewMsgLockHTLC(sender, recipient sdk.AccAddress, amount sdk.Coins,
+	hashLock []byte, timeoutHeight int64) MsgLockHTLC {
+	return MsgLockHTLC{
+		Sender:        sender,
+		Recipient:     recipient,
+		Amount:        amount,
+		HashLock:      hashLock,
+		TimeoutHeight: timeoutHeight,
+	}
+}
+
+// Route returns msg route
+func (msg MsgLockHTLC) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgLockHTLC) Type() string { return "lockhtlc" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgLockHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgLockHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// ValidateBasic validate msg
+func (msg MsgLockHTLC) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Sender.String())
+	}
+	if len(msg.Recipient) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Recipient.String())
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("lock amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("lock amount must be positive")
+	}
+	if len(msg.HashLock) != HashLockLength {
+		return ErrInvalidHashLock(msg.HashLock)
+	}
+	if msg.TimeoutHeight <= 0 {
+		return ErrInvalidTimeout(msg.TimeoutHeight, 0)
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgLockHTLC) String() string {
+	return fmt.Sprintf(`MsgLockHTLC
+	Sender: %v
+	Recipient: %v
+	Amount: %v
+	HashLock: %s
+	TimeoutHeight: %d`, msg.Sender, msg.Recipient, msg.Amount, hex.EncodeToString(msg.HashLock), msg.TimeoutHeight)
+}
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgClaimHTLC defines a message to release a hash time lock to its recipient by revealing the preimage.
+// Anyone who knows the preimage can claim; the coins always go to the recipient of the lock.
+type MsgClaimHTLC struct {
+	LockID   uint64         `json:"lock_id"`  // ID of the lock
+	Claimer  sdk.AccAddress `json:"claimer"`  // Address of the claimer
+	Preimage []byte         `json:"preimage"` // Preimage of the hash lock
+}
+
+// NewMsgClaimHTLC creates a MsgClaimHTLC instance
+func NewMsgClaimHTLC(lockID uint64, claimer sdk.AccAddress, preimage []byte) MsgClaimHTLC {
+	return MsgClaimHTLC{
+		LockID:   lockID,
+		Claimer:  claimer,
+		Preimage: preimage,
+	}
+}
+
+// Route returns msg route
+func (msg MsgClaimHTLC) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgClaimHTLC) Type() string { return "claimhtlc" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgClaimHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgClaimHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Claimer}
+}
+
+// ValidateBasic validate msg
+func (msg MsgClaimHTLC) ValidateBasic() sdk.Error {
+	if len(msg.Claimer) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Claimer.String())
+	}
+	if msg.LockID == 0 {
+		return ErrHTLCNotFound(msg.LockID)
+	}
+	if len(msg.Preimage) == 0 || len(msg.Preimage) > MaxPreimageLength {
+		return ErrInvalidPreimage(msg.LockID)
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgClaimHTLC) String() string {
+	return fmt.Sprintf(`MsgClaimHTLC
+	LockID: %d
+	Claimer: %v
+	Preimage: %s`, msg.LockID, msg.Claimer, hex.EncodeToString(msg.Preimage))
+}
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgRefundHTLC defines a message to refund an expired hash time lock to its sender
+type MsgRefundHTLC struct {
+	LockID uint64         `json:"lock_id"` // ID of the lock
+	Sender sdk.AccAddress `json:"sender"`  // Address of the sender
+}
+
+// NewMsgRefundHTLC creates a MsgRefundHTLC instance
+func NewMsgRefundHTLC(lockID uint64, sender sdk.AccAddress) MsgRefundHTLC {
+	return MsgRefundHTLC{
+		LockID: lockID,
+		Sender: sender,
+	}
+}
+
+// Route returns msg route
+func (msg MsgRefundHTLC) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgRefundHTLC) Type() string { return "refundhtlc" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgRefundHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgRefundHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// ValidateBasic validate msg
+func (msg MsgRefundHTLC) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Sender.String())
+	}
+	if msg.LockID == 0 {
+		return ErrHTLCNotFound(msg.LockID)
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgRefundHTLC) String() string {
+	return fmt.Sprintf(`MsgRefundHTLC
+	LockID: %d
+	Sender: %v`, msg.LockID, msg.Sender)
+}
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgCreateLazyVestingAccount defines a message to create the lazy graded vesting account {Recipient},
+// funded with {Amount} from {Sender} that vests by {LazyVestingSchedules}.
+// If {Clawback} is set, {Sender} can claw back the coins that have not vested yet.
+type MsgCreateLazyVestingAccount struct {
+	Sender               sdk.AccAddress              `json:"sender"`                 // Address of the funder
+	Recipient            sdk.AccAddress              `json:"recipient"`              // Address of the new account
+	Amount               sdk.Coins                   `json:"amount"`                 // Amount funded, all of it vesting
+	LazyVestingSchedules []types.LazyVestingSchedule `json:"vesting_lazy_schedules"` // Vesting schedule of each funded denom
+	Clawback             bool                        `json:"clawback"`               // Whether the funder can claw back unvested coins
+}
+
+// NewMsgCreateLazyVestingAccount creates a MsgCreateLazyVestingAccount instance
+func NewMsgCreateLazyVestingAccount(sender, recipient sdk.AccAddress, amount sdk.Coins,
+	lazyVestingSchedules []types.LazyVestingSchedule, clawback bool) MsgCreateLazyVestingAccount {
+	return MsgCreateLazyVestingAccount{
+		Sender:               sender,
+		Recipient:            recipient,
+		Amount:               amount,
+		LazyVestingSchedules: lazyVestingSchedules,
+		Clawback:             clawback,
+	}
+}
+
+// Route returns msg route
+func (msg MsgCreateLazyVestingAccount) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgCreateLazyVestingAccount) Type() string { return "createlazyvestingaccount" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgCreateLazyVestingAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgCreateLazyVestingAccount) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// ValidateBasic validate msg
+func (msg MsgCreateLazyVestingAccount) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Sender.String())
+	}
+	if len(msg.Recipient) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Recipient.String())
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("vesting amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("vesting amount must be positive")
+	}
+
+	// Every funded denom vests by exactly one valid schedule
+	scheduled := make(map[string]bool)
+	for _, schedule := range msg.LazyVestingSchedules {
+		if !schedule.IsValid() {
+			return ErrInvalidVestingSchedule(schedule.Denom, "ratios must be positive, end no earlier than they start and sum to 1")
+		}
+		if scheduled[schedule.Denom] {
+			return ErrInvalidVestingSchedule(schedule.Denom, "denom has more than one schedule")
+		}
+		if !msg.Amount.AmountOf(schedule.Denom).IsPositive() {
+			return ErrInvalidVestingSchedule(schedule.Denom, "denom is not funded")
+		}
+		scheduled[schedule.Denom] = true
+	}
+
+	for _, coin := range msg.Amount {
+		if !scheduled[coin.Denom] {
+			return ErrInvalidVestingSchedule(coin.Denom, "funded denom has no schedule")
+		}
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgCreateLazyVestingAccount) String() string {
+	return fmt.Sprintf(`MsgCreateLazyVestingAccount
+	Sender: %v
+	Recipient: %v
+	Amount: %v
+	LazyVestingSchedules: %v
+	Clawback: %v`, msg.Sender, msg.Recipient, msg.Amount, msg.LazyVestingSchedules, msg.Clawback)
+}
+
+//--------------------------------------------------------
+//--------------------------------------------------------
+
+// MsgClawback defines a message for {Funder} to claw back the unvested coins of the
+// clawback vesting account {Account}
+type MsgClawback struct {
+	Funder  sdk.AccAddress `json:"funder"`  // Address of the funder of the account
+	Account sdk.AccAddress `json:"account"` // Address of the clawback vesting account
+}
+
+// NewMsgClawback creates a MsgClawback instance
+func NewMsgClawback(funder, account sdk.AccAddress) MsgClawback {
+	return MsgClawback{
+		Funder:  funder,
+		Account: account,
+	}
+}
+
+// Route returns msg route
+func (msg MsgClawback) Route() string { return RouterKey }
+
+// Type returns msg type
+func (msg MsgClawback) Type() string { return "clawback" }
+
+// GetSignBytes returns sign bytes
+func (msg MsgClawback) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners returns signer
+func (msg MsgClawback) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Funder}
+}
+
+// ValidateBasic validate msg
+func (msg MsgClawback) ValidateBasic() sdk.Error {
+	if len(msg.Funder) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Funder.String())
+	}
+	if len(msg.Account) == 0 {
+		return sdk.ErrInvalidAddress("Invalid address: " + msg.Account.String())
+	}
+
+	return nil
+}
+
+// String stringify the msg
+func (msg MsgClawback) String() string {
+	return fmt.Sprintf(`MsgClawback
+	Funder: %v
+	Account: %v`, msg.Funder, msg.Account)
+}
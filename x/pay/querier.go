@@ -0,0 +1,166 @@
+package pay
+
+import (
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the pay Querier
+const (
+	QueryScheduledPayment  = "scheduled-payment"
+	QuerySenderPayments    = "sender-payments"
+	QueryRecipientPayments = "recipient-payments"
+	QueryHTLC              = "htlc"
+	QueryHTLCs             = "htlcs"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryScheduledPayment:
+			return queryScheduledPayment(ctx, path[1:], req, keeper)
+		case QuerySenderPayments:
+			return querySenderPayments(ctx, path[1:], req, keeper)
+		case QueryRecipientPayments:
+			return queryRecipientPayments(ctx, path[1:], req, keeper)
+		case QueryHTLC:
+			return queryHTLC(ctx, path[1:], req, keeper)
+		case QueryHTLCs:
+			return queryHTLCs(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown pay query endpoint")
+		}
+	}
+}
+
+// nolint: unparam
+func queryScheduledPayment(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	paymentID, strConvertError := strconv.ParseUint(path[0], 10, 64)
+	if strConvertError != nil {
+		return nil, sdk.ErrInternal("PaymentID must be a valid int")
+	}
+
+	payment, pErr := keeper.GetScheduledPayment(ctx, paymentID)
+	if pErr != nil {
+		return nil, pErr
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, payment)
+	if err != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func querySenderPayments(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	sender, addrErr := sdk.AccAddressFromBech32(path[0])
+	if addrErr != nil {
+		return nil, sdk.ErrInvalidAddress(addrErr.Error())
+	}
+
+	payments := ScheduledPayments{}
+	keeper.IterateScheduledPaymentsBySender(ctx, sender, func(payment ScheduledPayment) (stop bool) {
+		payments = append(payments, payment)
+		return false
+	})
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, payments)
+	if err != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryRecipientPayments(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	recipient, addrErr := sdk.AccAddressFromBech32(path[0])
+	if addrErr != nil {
+		return nil, sdk.ErrInvalidAddress(addrErr.Error())
+	}
+
+	payments := ScheduledPayments{}
+	keeper.IterateScheduledPaymentsByRecipient(ctx, recipient, func(payment ScheduledPayment) (stop bool) {
+		payments = append(payments, payment)
+		return false
+	})
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, payments)
+	if err != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryHTLC(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	lockID, strConvertError := strconv.ParseUint(path[0], 10, 64)
+	if strConvertError != nil {
+		return nil, sdk.ErrInternal("LockID must be a valid int")
+	}
+
+	htlc, hErr := keeper.GetHTLC(ctx, lockID)
+	if hErr != nil {
+		return nil, hErr
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, htlc)
+	if err != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// QueryHTLCsParams for query 'custom/pay/htlcs'; empty addresses match any
+type QueryHTLCsParams struct {
+	Sender    sdk.AccAddress
+	Recipient sdk.AccAddress
+}
+
+// NewQueryHTLCsParams creates a new instance of QueryHTLCsParams
+func NewQueryHTLCsParams(sender, recipient sdk.AccAddress) QueryHTLCsParams {
+	return QueryHTLCsParams{
+		Sender:    sender,
+		Recipient: recipient,
+	}
+}
+
+// nolint: unparam
+func queryHTLCs(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryHTLCsParams
+	if len(req.Data) != 0 {
+		err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+	}
+
+	htlcs := HTLCs{}
+	keeper.IterateHTLCs(ctx, func(htlc HTLC) (stop bool) {
+		if !params.Sender.Empty() && !params.Sender.Equals(htlc.Sender) {
+			return false
+		}
+		if !params.Recipient.Empty() && !params.Recipient.Equals(htlc.Recipient) {
+			return false
+		}
+
+		htlcs = append(htlcs, htlc)
+		return false
+	})
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, htlcs)
+	if err != nil {
+		return nil, sdk.ErrInternal("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
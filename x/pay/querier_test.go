@@ -0,0 +1,95 @@
+package pay
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQueryScheduledPayments(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	setupScheduledPayment(t, input, 5, 10, 3)
+	setupScheduledPayment(t, input, 5, 10, 2)
+
+	querier := NewQuerier(input.payKeeper)
+
+	bz, err := querier(input.ctx, []string{QueryScheduledPayment, "2"}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	var payment ScheduledPayment
+	input.cdc.MustUnmarshalJSON(bz, &payment)
+	require.Equal(t, uint64(2), payment.PaymentID)
+	require.Equal(t, int64(2), payment.Count)
+
+	_, err = querier(input.ctx, []string{QueryScheduledPayment, "3"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	bz, err = querier(input.ctx, []string{QuerySenderPayments, addrs[0].String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	var payments ScheduledPayments
+	input.cdc.MustUnmarshalJSON(bz, &payments)
+	require.Equal(t, 2, len(payments))
+
+	bz, err = querier(input.ctx, []string{QueryRecipientPayments, addrs[1].String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	payments = ScheduledPayments{}
+	input.cdc.MustUnmarshalJSON(bz, &payments)
+	require.Equal(t, 2, len(payments))
+
+	bz, err = querier(input.ctx, []string{QueryRecipientPayments, addrs[0].String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	payments = ScheduledPayments{}
+	input.cdc.MustUnmarshalJSON(bz, &payments)
+	require.Equal(t, 0, len(payments))
+
+	_, err = querier(input.ctx, []string{QuerySenderPayments, fmt.Sprintf("%x", addrs[0])}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestQueryHTLCs(t *testing.T) {
+	input := createTestInput(t)
+	input.ctx = input.ctx.WithBlockHeight(1)
+	input.bankKeeper.SetSendEnabled(input.ctx, true)
+
+	handler := NewHandler(input.payKeeper)
+	amt := sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(1).MulRaw(assets.MicroUnit))}
+
+	res := handler(input.ctx, NewMsgLockHTLC(addrs[0], addrs[1], amt, HashPreimage([]byte("a")), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+	res = handler(input.ctx, NewMsgLockHTLC(addrs[1], addrs[2], amt, HashPreimage([]byte("b")), 10))
+	require.True(t, res.IsOK(), "expected successful message execution: %v", res.Log)
+
+	querier := NewQuerier(input.payKeeper)
+
+	bz, err := querier(input.ctx, []string{QueryHTLC, "2"}, abci.RequestQuery{})
+	require.Nil(t, err)
+
+	var htlc HTLC
+	input.cdc.MustUnmarshalJSON(bz, &htlc)
+	require.Equal(t, addrs[1], htlc.Sender)
+	require.Equal(t, HashPreimage([]byte("b")), htlc.HashLock)
+
+	queryHTLCs := func(params QueryHTLCsParams) HTLCs {
+		bz, err := querier(input.ctx, []string{QueryHTLCs}, abci.RequestQuery{Data: input.cdc.MustMarshalJSON(params)})
+		require.Nil(t, err)
+
+		htlcs := HTLCs{}
+		input.cdc.MustUnmarshalJSON(bz, &htlcs)
+		return htlcs
+	}
+
+	require.Equal(t, 2, len(queryHTLCs(NewQueryHTLCsParams(nil, nil))))
+	require.Equal(t, 1, len(queryHTLCs(NewQueryHTLCsParams(addrs[1], nil))))
+	require.Equal(t, 1, len(queryHTLCs(NewQueryHTLCsParams(nil, addrs[1]))))
+	require.Equal(t, 0, len(queryHTLCs(NewQueryHTLCsParams(addrs[1], addrs[1]))))
+}
@@ -0,0 +1,99 @@
+package pay
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ScheduledPayment pays {Amount} from {Sender} to {Recipient} every {Interval} blocks from
+// {StartHeight}, {Count} times. The funds for all installments are escrowed on creation.
+type ScheduledPayment struct {
+	PaymentID     uint64         `json:"payment_id"`      // ID of the scheduled payment
+	Sender        sdk.AccAddress `json:"sender"`          // Address of the sender; pays the stability tax on each installment
+	Recipient     sdk.AccAddress `json:"recipient"`       // Address of the recipient
+	Amount        sdk.Coins      `json:"amount"`          // Amount paid per installment
+	StartHeight   int64          `json:"start_height"`    // Block height of the first installment
+	Interval      int64          `json:"interval"`        // Number of blocks between installments
+	Count         int64          `json:"count"`           // Total number of installments
+	Paid          int64          `json:"paid"`            // Number of installments paid so far
+	NextPayHeight int64          `json:"next_pay_height"` // Block height at which the next installment is due
+	Failures      int64          `json:"failures"`        // Number of consecutive installments that could not be paid
+}
+
+// NewScheduledPayment creates a new ScheduledPayment with no installments paid
+func NewScheduledPayment(
+	paymentID uint64,
+	sender sdk.AccAddress,
+	recipient sdk.AccAddress,
+	amount sdk.Coins,
+	startHeight int64,
+	interval int64,
+	count int64) ScheduledPayment {
+	return ScheduledPayment{
+		PaymentID:     paymentID,
+		Sender:        sender,
+		Recipient:     recipient,
+		Amount:        amount,
+		StartHeight:   startHeight,
+		Interval:      interval,
+		Count:         count,
+		Paid:          0,
+		NextPayHeight: startHeight,
+	}
+}
+
+// Remaining returns the escrowed amount that is still to be paid out
+func (p ScheduledPayment) Remaining() (sdk.Coins, sdk.Error) {
+	remaining, ok := mulCoins(p.Amount, p.Count-p.Paid)
+	if !ok {
+		return nil, ErrAmountOverflow(p.Amount, p.Count-p.Paid)
+	}
+
+	return remaining, nil
+}
+
+// String implements fmt.Stringer
+func (p ScheduledPayment) String() string {
+	return fmt.Sprintf(`ScheduledPayment
+	PaymentID: %d
+	Sender: %v
+	Recipient: %v
+	Amount: %v
+	StartHeight: %d
+	Interval: %d
+	Count: %d
+	Paid: %d
+	NextPayHeight: %d
+	Failures: %d`,
+		p.PaymentID, p.Sender, p.Recipient, p.Amount, p.StartHeight,
+		p.Interval, p.Count, p.Paid, p.NextPayHeight, p.Failures)
+}
+
+// ScheduledPayments is a collection of ScheduledPayment
+type ScheduledPayments []ScheduledPayment
+
+func (p ScheduledPayments) String() (out string) {
+	for _, val := range p {
+		out += val.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// maxAmountBitLen is the bit length above which sdk.Int arithmetic panics
+const maxAmountBitLen = 255
+
+// mulCoins multiplies every coin amount by {n}. ok is false if an amount overflows sdk.Int.
+func mulCoins(coins sdk.Coins, n int64) (res sdk.Coins, ok bool) {
+	for _, coin := range coins {
+		amount := new(big.Int).Mul(coin.Amount.BigInt(), big.NewInt(n))
+		if amount.BitLen() > maxAmountBitLen {
+			return nil, false
+		}
+
+		res = append(res, sdk.NewCoin(coin.Denom, sdk.NewIntFromBigInt(amount)))
+	}
+	return res, true
+}
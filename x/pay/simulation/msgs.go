@@ -0,0 +1,40 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/terra-project/core/simulation"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// SimulateMsgSend returns an operation sending a random part of a random coin of a random account
+// to another one; the send is taxed by the pay handler
+func SimulateMsgSend(ak auth.AccountKeeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string) (simulation.OperationMsg, error) {
+
+		from := simulation.RandomAcc(r, accs)
+		to := simulation.RandomAcc(r, accs)
+		if from.Equals(to) {
+			return simulation.NoOpMsg(bank.RouterKey), nil
+		}
+
+		acc := ak.GetAccount(ctx, from.Address)
+		if acc == nil {
+			return simulation.NoOpMsg(bank.RouterKey), nil
+		}
+
+		// vesting accounts can only send their spendable coins
+		coin, ok := simulation.RandomCoin(r, acc.SpendableCoins(ctx.BlockHeader().Time))
+		if !ok || !coin.IsPositive() {
+			return simulation.NoOpMsg(bank.RouterKey), nil
+		}
+
+		msg := bank.NewMsgSend(from.Address, to.Address, sdk.Coins{coin})
+		return simulation.DeliverMsg(app, ctx, ak, chainID, msg, from)
+	}
+}
@@ -0,0 +1,31 @@
+package tags
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Pay tags
+var (
+	ActionPaymentScheduled = "payment-scheduled"
+	ActionPaymentCancelled = "payment-cancelled"
+	ActionPaymentExecuted  = "payment-executed"
+	ActionPaymentFailed    = "payment-failed"
+	ActionPaymentCompleted = "payment-completed"
+	ActionPaymentAborted   = "payment-aborted"
+	ActionHTLCLocked       = "htlc-locked"
+	ActionHTLCClaimed      = "htlc-claimed"
+	ActionHTLCRefunded     = "htlc-refunded"
+	ActionHTLCRefundFailed = "htlc-refund-failed"
+	ActionVestingCreated   = "vesting-account-created"
+	ActionVestingClawback  = "vesting-clawed-back"
+
+	Action    = sdk.TagAction
+	PaymentID = "payment-id"
+	Sender    = "sender"
+	Recipient = "recipient"
+	Tax       = "tax"
+	LockID    = "lock-id"
+	HashLock  = "hash-lock"
+	Preimage  = "preimage"
+	Account   = "account"
+)
@@ -0,0 +1,201 @@
+package pay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/terra-project/core/types"
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/x/market"
+	"github.com/terra-project/core/x/mint"
+	"github.com/terra-project/core/x/oracle"
+	"github.com/terra-project/core/x/treasury"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+)
+
+var (
+	addrs = []sdk.AccAddress{
+		sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+		sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+		sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+	}
+
+	valConsPubKeys = []crypto.PubKey{
+		ed25519.GenPrivKey().PubKey(),
+		ed25519.GenPrivKey().PubKey(),
+		ed25519.GenPrivKey().PubKey(),
+	}
+
+	valConsAddrs = []sdk.ConsAddress{
+		sdk.ConsAddress(valConsPubKeys[0].Address()),
+		sdk.ConsAddress(valConsPubKeys[1].Address()),
+		sdk.ConsAddress(valConsPubKeys[2].Address()),
+	}
+
+	uSDRAmount = sdk.NewInt(1005).MulRaw(assets.MicroUnit)
+)
+
+type testInput struct {
+	ctx            sdk.Context
+	cdc            *codec.Codec
+	accKeeper      auth.AccountKeeper
+	bankKeeper     bank.Keeper
+	treasuryKeeper treasury.Keeper
+	feeKeeper      auth.FeeCollectionKeeper
+	stakingKeeper  staking.Keeper
+	payKeeper      Keeper
+}
+
+func newTestCodec() *codec.Codec {
+	cdc := codec.New()
+
+	bank.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	types.RegisterCodec(cdc)
+
+	return cdc
+}
+
+func createTestInput(t *testing.T) testInput {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tKeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keyTreasury := sdk.NewKVStoreKey(treasury.StoreKey)
+	keyMint := sdk.NewKVStoreKey(mint.StoreKey)
+	keyOracle := sdk.NewKVStoreKey(oracle.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tKeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
+	tKeyDistr := sdk.NewTransientStoreKey(distr.TStoreKey)
+	keyFeeCollection := sdk.NewKVStoreKey(auth.FeeStoreKey)
+	keyMarket := sdk.NewKVStoreKey(market.StoreKey)
+	keyPay := sdk.NewKVStoreKey(StoreKey)
+
+	cdc := newTestCodec()
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Now().UTC()}, false, log.NewNopLogger())
+
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyTreasury, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyMint, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyOracle, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyStaking, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyDistr, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyFeeCollection, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyMarket, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyPay, sdk.StoreTypeIAVL, db)
+
+	require.NoError(t, ms.LoadLatestVersion())
+
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tKeyParams)
+	accKeeper := auth.NewAccountKeeper(
+		cdc,
+		keyAcc,
+		paramsKeeper.Subspace(auth.DefaultParamspace),
+		auth.ProtoBaseAccount,
+	)
+
+	bankKeeper := bank.NewBaseKeeper(
+		accKeeper,
+		paramsKeeper.Subspace(bank.DefaultParamspace),
+		bank.DefaultCodespace,
+	)
+
+	stakingKeeper := staking.NewKeeper(
+		cdc,
+		keyStaking, tKeyStaking,
+		bankKeeper, paramsKeeper.Subspace(staking.DefaultParamspace),
+		staking.DefaultCodespace,
+	)
+
+	feeCollectionKeeper := auth.NewFeeCollectionKeeper(
+		cdc,
+		keyFeeCollection,
+	)
+
+	distrKeeper := distr.NewKeeper(
+		cdc, keyDistr, paramsKeeper.Subspace(distr.DefaultParamspace),
+		bankKeeper, &stakingKeeper, feeCollectionKeeper, distr.DefaultCodespace,
+	)
+
+	stakingKeeper.SetPool(ctx, staking.InitialPool())
+	stakingKeeper.SetParams(ctx, staking.DefaultParams())
+
+	mintKeeper := mint.NewKeeper(
+		cdc,
+		keyMint,
+		stakingKeeper,
+		bankKeeper,
+		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
+	)
+
+	mintKeeper.RegisterPermission(market.ModuleName, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+
+	oracleKeeper := oracle.NewKeeper(
+		cdc,
+		keyOracle,
+		mintKeeper,
+		distrKeeper,
+		feeCollectionKeeper,
+		&stakingKeeper,
+		paramsKeeper.Subspace(oracle.DefaultParamspace),
+	)
+
+	marketKeeper := market.NewKeeper(cdc, keyMarket, oracleKeeper, mintKeeper.ForModule(market.ModuleName),
+		paramsKeeper.Subspace(market.DefaultParamspace))
+	marketKeeper.SetParams(ctx, market.DefaultParams())
+
+	treasuryKeeper := treasury.NewKeeper(
+		cdc,
+		keyTreasury,
+		stakingKeeper.GetValidatorSet(),
+		mintKeeper.ForModule(treasury.ModuleName),
+		marketKeeper,
+		paramsKeeper.Subspace(treasury.DefaultParamspace),
+	)
+
+	payKeeper := NewKeeper(cdc, keyPay, accKeeper, bankKeeper, &stakingKeeper, treasuryKeeper, feeCollectionKeeper)
+
+	for _, addr := range addrs {
+		_, _, err := bankKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, uSDRAmount)})
+		require.NoError(t, err)
+	}
+
+	return testInput{ctx, cdc, accKeeper, bankKeeper, treasuryKeeper, feeCollectionKeeper, stakingKeeper, payKeeper}
+}
+
+// addLuna credits {amount} Luna to {addr}, and adds it to the not bonded tokens of the staking pool
+// so that it can be bonded
+func addLuna(t *testing.T, input testInput, addr sdk.AccAddress, amount sdk.Int) {
+	_, _, err := input.bankKeeper.AddCoins(input.ctx, addr, sdk.Coins{sdk.NewCoin(assets.MicroLunaDenom, amount)})
+	require.Nil(t, err)
+
+	pool := input.stakingKeeper.GetPool(input.ctx)
+	pool.NotBondedTokens = pool.NotBondedTokens.Add(amount)
+	input.stakingKeeper.SetPool(input.ctx, pool)
+}
@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/terra-project/core/testutil"
 	"github.com/terra-project/core/x/treasury"
 )
@@ -134,3 +138,76 @@ func TestQueryParams(t *testing.T) {
 	// NoArg check
 	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.NoArgs)), testutil.FS(queryParams.Args))
 }
+
+func TestSimulatePolicy(t *testing.T) {
+	cdc, _, _, _ := testutil.PrepareCmdTest()
+
+	simulatePolicy := GetCmdSimulatePolicy(cdc)
+
+	// Name check
+	require.Equal(t, "simulate", simulatePolicy.Name())
+
+	// NoArg check
+	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.NoArgs)), testutil.FS(simulatePolicy.Args))
+
+	// Check Flags
+	scenarioFlag := simulatePolicy.Flag(flagScenario)
+	require.NotNil(t, scenarioFlag)
+	require.Equal(t, []string{"true"}, scenarioFlag.Annotations[cobra.BashCompOneRequiredFlag])
+}
+
+func TestParseScenario(t *testing.T) {
+	scenarioFile, err := ioutil.TempFile("", "scenario")
+	require.Nil(t, err)
+
+	_, err = scenarioFile.WriteString(`{
+  "params": {"window_probation": "2", "reward_policy": {"rate_max": "0.5"}},
+  "tax_rate": "0.002",
+  "epochs": [
+    {"tax_proceeds": "1000", "seigniorage": "500", "bonded": "1000000"}
+  ]
+}`)
+	require.Nil(t, err)
+	require.Nil(t, scenarioFile.Close())
+
+	params, taxRate, rewardWeight, epochs, err := parseScenario(scenarioFile.Name())
+	require.Nil(t, err)
+
+	// Overridden fields are replaced, the rest stay at their defaults
+	defaultGenesis := treasury.DefaultGenesisState()
+	require.True(t, params.WindowProbation.Equal(sdk.NewInt(2)))
+	require.True(t, params.RewardPolicy.RateMax.Equal(sdk.NewDecWithPrec(5, 1)))
+	require.True(t, params.RewardPolicy.RateMin.Equal(defaultGenesis.Params.RewardPolicy.RateMin))
+	require.True(t, params.WindowLong.Equal(defaultGenesis.Params.WindowLong))
+	require.True(t, taxRate.Equal(sdk.NewDecWithPrec(2, 3)))
+	require.True(t, rewardWeight.Equal(defaultGenesis.GenesisRewardWeight))
+
+	require.Equal(t, 1, len(epochs))
+	require.True(t, epochs[0].TaxProceeds.Equal(sdk.NewInt(1000)))
+	require.True(t, epochs[0].Seigniorage.Equal(sdk.NewInt(500)))
+	require.True(t, epochs[0].Bonded.Equal(sdk.NewInt(1000000)))
+
+	// Missing file
+	require.Nil(t, os.Remove(scenarioFile.Name()))
+	_, _, _, _, err = parseScenario(scenarioFile.Name())
+	require.NotNil(t, err)
+}
+
+func TestQueryEstimateTax(t *testing.T) {
+	cdc, _, _, _ := testutil.PrepareCmdTest()
+
+	queryEstimateTax := GetCmdQueryEstimateTax(cdc)
+
+	// Name check
+	require.Equal(t, treasury.QueryEstimateTax, queryEstimateTax.Name())
+
+	// NoArg check
+	require.Equal(t, testutil.FS(cobra.PositionalArgs(cobra.NoArgs)), testutil.FS(queryEstimateTax.Args))
+
+	// Check Flags
+	coinsFlag := queryEstimateTax.Flag(flagCoins)
+	require.NotNil(t, coinsFlag)
+
+	txFlag := queryEstimateTax.Flag(flagTx)
+	require.NotNil(t, txFlag)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
 	"strconv"
 	"strings"
 
@@ -13,12 +14,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 const (
 	flagDenom = "denom"
 	flagDay   = "day"
 	flagEpoch = "epoch"
+	flagCoins = "coins"
+	flagTx    = "tx"
 )
 
 // GetCmdQueryTaxRate implements the query taxrate command.
@@ -348,3 +352,68 @@ func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryEstimateTax implements the query estimate-tax command.
+func GetCmdQueryEstimateTax(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   treasury.QueryEstimateTax,
+		Args:  cobra.NoArgs,
+		Short: "Estimate the stability tax due on a send",
+		Long: strings.TrimSpace(`
+Estimate the stability tax due on a send, per coin and in total, at the current tax rate and tax caps. 
+Either give the coins to be sent, or an unsigned transaction whose send messages will be taxed separately.
+The tax is charged to the sender on top of the transaction fee, which only pays for the gas.
+
+$ terracli query treasury estimate-tax --coins="1000000ukrw,1000usdr"
+$ terracli query treasury estimate-tax --tx=unsigned.json
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			coinsStr := viper.GetString(flagCoins)
+			txFile := viper.GetString(flagTx)
+			if (len(coinsStr) == 0) == (len(txFile) == 0) {
+				return fmt.Errorf("exactly one of --%s or --%s must be given", flagCoins, flagTx)
+			}
+
+			var coins sdk.Coins
+			var stdTx auth.StdTx
+			if len(coinsStr) != 0 {
+				var err error
+				coins, err = sdk.ParseCoins(coinsStr)
+				if err != nil {
+					return err
+				}
+			} else {
+				bz, err := ioutil.ReadFile(txFile)
+				if err != nil {
+					return err
+				}
+
+				if err := cdc.UnmarshalJSON(bz, &stdTx); err != nil {
+					return err
+				}
+			}
+
+			params := treasury.NewQueryEstimateTaxParams(coins, stdTx)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", treasury.QuerierRoute, treasury.QueryEstimateTax), bz)
+			if err != nil {
+				return err
+			}
+
+			var estimate treasury.QueryEstimateTaxResponse
+			cdc.MustUnmarshalJSON(res, &estimate)
+			return cliCtx.PrintOutput(estimate)
+		},
+	}
+
+	cmd.Flags().String(flagCoins, "", "the coins to be sent, e.g. 1000000ukrw")
+	cmd.Flags().String(flagTx, "", "path to an unsigned transaction file whose sends are estimated")
+
+	return cmd
+}
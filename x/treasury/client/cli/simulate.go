@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/terra-project/core/x/treasury"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagScenario = "scenario"
+)
+
+type scenarioEpoch struct {
+	TaxProceeds string `json:"tax_proceeds"`
+	Seigniorage string `json:"seigniorage"`
+	Bonded      string `json:"bonded"`
+}
+
+type scenario struct {
+	Params       json.RawMessage `json:"params"`
+	TaxRate      string          `json:"tax_rate"`
+	RewardWeight string          `json:"reward_weight"`
+	Epochs       []scenarioEpoch `json:"epochs"`
+}
+
+// GetCmdSimulatePolicy implements the simulate command.
+func GetCmdSimulatePolicy(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Args:  cobra.NoArgs,
+		Short: "Project the tax rate and reward weight for a scenario of epochs",
+		Long: strings.TrimSpace(`
+Replay the treasury policy updates offline over a scenario of epochs and print the
+tax rate and reward weight that would be set at the end of each epoch. No node connection is required.
+
+The scenario file lists per-epoch tax proceeds and seigniorage (in usdr) and total bonded uluna.
+"params" overrides individual fields of the default treasury params, and "tax_rate" and
+"reward_weight" override the genesis values; all three are optional.
+
+{
+  "params": {"window_probation": "2", "mining_increment": "1.05"},
+  "tax_rate": "0.001",
+  "reward_weight": "0.05",
+  "epochs": [
+    {"tax_proceeds": "1000000000", "seigniorage": "500000000", "bonded": "100000000000000"},
+    {"tax_proceeds": "1200000000", "seigniorage": "400000000", "bonded": "100000000000000"}
+  ]
+}
+
+$ terracli query treasury simulate --scenario=scenario.json --output=json
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params, taxRate, rewardWeight, epochs, err := parseScenario(viper.GetString(flagScenario))
+			if err != nil {
+				return err
+			}
+
+			results, err := treasury.SimulatePolicy(params, taxRate, rewardWeight, epochs)
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(results)
+		},
+	}
+
+	cmd.Flags().String(flagScenario, "", "scenario file path")
+
+	cmd.MarkFlagRequired(flagScenario)
+
+	return cmd
+}
+
+func parseScenario(scenarioFile string) (params treasury.Params, taxRate, rewardWeight sdk.Dec,
+	epochs []treasury.SimulationEpoch, err error) {
+	contents, err := ioutil.ReadFile(scenarioFile)
+	if err != nil {
+		return
+	}
+
+	var s scenario
+	if err = json.Unmarshal(contents, &s); err != nil {
+		return
+	}
+
+	genesis := treasury.DefaultGenesisState()
+
+	// Only the fields given in the scenario replace the defaults
+	params = genesis.Params
+	if len(s.Params) != 0 {
+		if err = json.Unmarshal(s.Params, &params); err != nil {
+			return
+		}
+	}
+
+	taxRate = genesis.GenesisTaxRate
+	if len(s.TaxRate) != 0 {
+		if taxRate, err = sdk.NewDecFromStr(s.TaxRate); err != nil {
+			return
+		}
+	}
+
+	rewardWeight = genesis.GenesisRewardWeight
+	if len(s.RewardWeight) != 0 {
+		if rewardWeight, err = sdk.NewDecFromStr(s.RewardWeight); err != nil {
+			return
+		}
+	}
+
+	if len(s.Epochs) == 0 {
+		err = fmt.Errorf("scenario must contain at least one epoch")
+		return
+	}
+
+	for i, e := range s.Epochs {
+		epoch := treasury.SimulationEpoch{}
+
+		var ok bool
+		if epoch.TaxProceeds, ok = sdk.NewIntFromString(e.TaxProceeds); !ok {
+			err = fmt.Errorf("epoch %d: the given tax_proceeds {%s} is not a valid integer", i, e.TaxProceeds)
+			return
+		}
+
+		if epoch.Seigniorage, ok = sdk.NewIntFromString(e.Seigniorage); !ok {
+			err = fmt.Errorf("epoch %d: the given seigniorage {%s} is not a valid integer", i, e.Seigniorage)
+			return
+		}
+
+		if epoch.Bonded, ok = sdk.NewIntFromString(e.Bonded); !ok {
+			err = fmt.Errorf("epoch %d: the given bonded {%s} is not a valid integer", i, e.Bonded)
+			return
+		}
+
+		epochs = append(epochs, epoch)
+	}
+
+	return
+}
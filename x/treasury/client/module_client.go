@@ -36,6 +36,8 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 		treasuryCli.GetCmdQuerySeigniorageProceeds(mc.cdc),
 		treasuryCli.GetCmdQueryCurrentEpoch(mc.cdc),
 		treasuryCli.GetCmdQueryParams(mc.cdc),
+		treasuryCli.GetCmdQueryEstimateTax(mc.cdc),
+		treasuryCli.GetCmdSimulatePolicy(mc.cdc),
 	)...)
 
 	return treasuryQueryCmd
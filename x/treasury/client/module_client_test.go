@@ -22,6 +22,8 @@ var (
 		"current-epoch":        true,
 		"issuance":             true,
 		"tax-proceeds":         true,
+		"simulate":             true,
+		"estimate-tax":         true,
 	}
 )
 
@@ -1,7 +1,6 @@
 package treasury
 
 import (
-	"github.com/terra-project/core/types/util"
 	"github.com/terra-project/core/x/treasury/tags"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -10,16 +9,15 @@ import (
 // not enough data collected to update variables
 func isProbationPeriod(ctx sdk.Context, k Keeper) bool {
 
-	// Look 1 block into the future ... at the last block of the epoch, trigger
-	futureCtx := ctx.WithBlockHeight(ctx.BlockHeight() + 1)
-	futureEpoch := util.GetEpoch(futureCtx)
+	// Look 1 block into the future ... at the last block of the epoch, the next block opens the next epoch
+	futureEpoch := k.GetEpoch(ctx).Add(sdk.OneInt())
 
 	return futureEpoch.LT(k.GetParams(ctx).WindowProbation)
 }
 
 // EndBlocker called to adjust macro weights (tax, mining reward) and settle outstanding claims.
 func EndBlocker(ctx sdk.Context, k Keeper) (resTags sdk.Tags) {
-	if !util.IsPeriodLastBlock(ctx, util.BlocksPerEpoch) {
+	if !k.mtk.IsEpochLastBlock(ctx) {
 		return resTags
 	}
 
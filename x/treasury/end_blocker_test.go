@@ -23,7 +23,7 @@ func TestEndBlockerTiming(t *testing.T) {
 	for i := params.WindowProbation.Int64(); i < params.WindowProbation.Int64()+12; i++ {
 		if i%params.WindowShort.Int64() == 0 {
 			input.ctx = input.ctx.WithBlockHeight(i*util.BlocksPerEpoch - 1)
-			input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
+			input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
 
 			tTags := EndBlocker(input.ctx, input.treasuryKeeper)
 
@@ -45,7 +45,7 @@ func reset(input testInput) testInput {
 
 	// Give everyone some luna
 	for _, addr := range addrs {
-		err := input.mintKeeper.Mint(input.ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
+		err := input.mintKeeper.ForModule(faucet).Mint(input.ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
 		if err != nil {
 			panic(err)
 		}
@@ -72,7 +72,7 @@ func updatePolicy(input testInput, startIndex int,
 		input.treasuryKeeper.RecordTaxProceeds(input.ctx, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, taxRevenue)})
 
 		seigniorageRevenue := seigniorageRevenues[i]
-		input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, seigniorageRevenue))
+		input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, seigniorageRevenue))
 
 		// Call endblocker
 		EndBlocker(input.ctx, input.treasuryKeeper)
@@ -7,6 +7,9 @@ type MintKeeper interface {
 	PeekEpochSeigniorage(ctx sdk.Context, epoch sdk.Int) (seignioragePool sdk.Int)
 	Mint(ctx sdk.Context, recipient sdk.AccAddress, coin sdk.Coin) (err sdk.Error)
 	GetIssuance(ctx sdk.Context, denom string, day sdk.Int) (issuance sdk.Int)
+	GetDay(ctx sdk.Context) sdk.Int
+	GetEpoch(ctx sdk.Context) sdk.Int
+	IsEpochLastBlock(ctx sdk.Context) bool
 }
 
 // expected market keeper
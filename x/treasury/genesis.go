@@ -3,24 +3,45 @@ package treasury
 import (
 	"fmt"
 
-	"github.com/terra-project/core/types/util"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // GenesisState - all treasury state that must be provided at genesis
 type GenesisState struct {
-	Params              Params  `json:"params"` // treasury params
-	GenesisTaxRate      sdk.Dec `json:"tax_rate"`
-	GenesisRewardWeight sdk.Dec `json:"reward_weight"`
+	Params              Params         `json:"params"` // treasury params
+	GenesisTaxRate      sdk.Dec        `json:"tax_rate"`
+	GenesisRewardWeight sdk.Dec        `json:"reward_weight"`
+	EpochHistory        []EpochHistory `json:"epoch_history"` // per-epoch history, starting at epoch 0
+	TaxCaps             sdk.Coins      `json:"tax_caps"`      // cached tax caps per denom
+}
+
+// EpochHistory holds the policy variables and tax proceeds recorded for a single epoch
+type EpochHistory struct {
+	Epoch        sdk.Int   `json:"epoch"`
+	TaxRate      sdk.Dec   `json:"tax_rate"`
+	RewardWeight sdk.Dec   `json:"reward_weight"`
+	TaxProceeds  sdk.Coins `json:"tax_proceeds"`
+}
+
+// NewEpochHistory constructs a new epoch history entry
+func NewEpochHistory(epoch sdk.Int, taxRate, rewardWeight sdk.Dec, taxProceeds sdk.Coins) EpochHistory {
+	return EpochHistory{
+		Epoch:        epoch,
+		TaxRate:      taxRate,
+		RewardWeight: rewardWeight,
+		TaxProceeds:  taxProceeds,
+	}
 }
 
 // NewGenesisState constructs a new genesis state
-func NewGenesisState(params Params, taxRate, rewardWeight sdk.Dec) GenesisState {
+func NewGenesisState(params Params, taxRate, rewardWeight sdk.Dec,
+	epochHistory []EpochHistory, taxCaps sdk.Coins) GenesisState {
 	return GenesisState{
 		Params:              params,
 		GenesisTaxRate:      taxRate,
 		GenesisRewardWeight: rewardWeight,
+		EpochHistory:        epochHistory,
+		TaxCaps:             taxCaps,
 	}
 }
 
@@ -31,6 +52,8 @@ func DefaultGenesisState() GenesisState {
 		Params:              params,
 		GenesisTaxRate:      sdk.NewDecWithPrec(1, 3), // 0.1%
 		GenesisRewardWeight: sdk.NewDecWithPrec(5, 2), // 5%
+		EpochHistory:        []EpochHistory{},
+		TaxCaps:             sdk.Coins{},
 	}
 }
 
@@ -40,6 +63,20 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	keeper.SetTaxRate(ctx, data.GenesisTaxRate)
 	keeper.setTaxCap(ctx, data.Params.TaxPolicy.Cap.Denom, data.Params.TaxPolicy.Cap.Amount)
 	keeper.SetRewardWeight(ctx, data.GenesisRewardWeight)
+
+	// Restore the per-epoch history so the rolling indicators pick up where they left off
+	for _, history := range data.EpochHistory {
+		keeper.setTaxRate(ctx, history.Epoch, history.TaxRate)
+		keeper.setRewardWeight(ctx, history.Epoch, history.RewardWeight)
+
+		if !history.TaxProceeds.Empty() {
+			keeper.setTaxProceeds(ctx, history.Epoch, history.TaxProceeds)
+		}
+	}
+
+	for _, taxCap := range data.TaxCaps {
+		keeper.setTaxCap(ctx, taxCap.Denom, taxCap.Amount)
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper. The
@@ -47,9 +84,38 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 	params := k.GetParams(ctx)
 	taxRate := k.GetTaxRate(ctx, sdk.ZeroInt())
-	rewardWeight := k.GetRewardWeight(ctx, util.GetEpoch(ctx))
+	rewardWeight := k.GetRewardWeight(ctx, k.GetEpoch(ctx))
+
+	// Epochs without a stored value fall back to the previous epoch, so the history comes out gap-free
+	epochHistory := []EpochHistory{}
+	curEpoch := k.GetEpoch(ctx)
+	for epoch := sdk.ZeroInt(); epoch.LTE(curEpoch); epoch = epoch.Add(sdk.OneInt()) {
+		epochHistory = append(epochHistory, NewEpochHistory(
+			epoch,
+			k.GetTaxRate(ctx, epoch),
+			k.GetRewardWeight(ctx, epoch),
+			k.PeekTaxProceeds(ctx, epoch),
+		))
+	}
 
-	return NewGenesisState(params, taxRate, rewardWeight)
+	taxCaps := sdk.Coins{}
+	k.IterateTaxCaps(ctx, func(denom string, taxCap sdk.Int) (stop bool) {
+		taxCaps = append(taxCaps, sdk.NewCoin(denom, taxCap))
+		return false
+	})
+
+	return NewGenesisState(params, taxRate, rewardWeight, epochHistory, taxCaps)
+}
+
+// PrepForZeroHeightGenesis updates the policy as at the end of the current epoch, for a chain
+// restarted from an export at height zero, which starts on the next epoch.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	if isProbationPeriod(ctx, keeper) {
+		return
+	}
+
+	updateTaxPolicy(ctx, keeper)
+	updateRewardPolicy(ctx, keeper)
 }
 
 // ValidateGenesis validates the provided treasury genesis state to ensure the
@@ -67,5 +133,36 @@ func ValidateGenesis(data GenesisState) error {
 			data.Params.RewardPolicy.RateMin, data.Params.RewardPolicy.RateMax, data.GenesisRewardWeight)
 	}
 
+	for i, history := range data.EpochHistory {
+		if !history.Epoch.Equal(sdk.NewInt(int64(i))) {
+			return fmt.Errorf("treasury epoch history must be contiguous from epoch 0; expected epoch %d, got %s",
+				i, history.Epoch)
+		}
+
+		if history.TaxRate.IsNil() || history.TaxRate.IsNegative() {
+			return fmt.Errorf("treasury tax rate of epoch %s must be >= 0, is %s", history.Epoch, history.TaxRate)
+		}
+
+		if history.RewardWeight.IsNil() || history.RewardWeight.IsNegative() {
+			return fmt.Errorf("treasury reward weight of epoch %s must be >= 0, is %s", history.Epoch, history.RewardWeight)
+		}
+
+		if !history.TaxProceeds.Empty() && !history.TaxProceeds.IsValid() {
+			return fmt.Errorf("treasury tax proceeds of epoch %s are invalid: %s", history.Epoch, history.TaxProceeds)
+		}
+	}
+
+	denoms := make(map[string]bool)
+	for _, taxCap := range data.TaxCaps {
+		if denoms[taxCap.Denom] {
+			return fmt.Errorf("treasury tax cap for %s is duplicated", taxCap.Denom)
+		}
+		denoms[taxCap.Denom] = true
+
+		if taxCap.Amount.IsNegative() {
+			return fmt.Errorf("treasury tax cap for %s must be >= 0, is %s", taxCap.Denom, taxCap.Amount)
+		}
+	}
+
 	return validateParams(data.Params)
 }
@@ -0,0 +1,82 @@
+package treasury
+
+import (
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportInitGenesis(t *testing.T) {
+	input := createTestInput(t)
+
+	// Build up a few epochs of history; epoch 2 is left without any stored value
+	for epoch := int64(0); epoch < 5; epoch++ {
+		input.ctx = input.ctx.WithBlockHeight(epoch * util.BlocksPerEpoch)
+		if epoch == 2 {
+			continue
+		}
+
+		input.treasuryKeeper.SetTaxRate(input.ctx, sdk.NewDecWithPrec(epoch+1, 3))
+		input.treasuryKeeper.SetRewardWeight(input.ctx, sdk.NewDecWithPrec(epoch+5, 2))
+		input.treasuryKeeper.RecordTaxProceeds(input.ctx, sdk.Coins{sdk.NewInt64Coin(assets.MicroSDRDenom, (epoch+1)*1000)})
+	}
+	input.treasuryKeeper.setTaxCap(input.ctx, assets.MicroKRWDenom, sdk.NewInt(1200000))
+
+	genesis := ExportGenesis(input.ctx, input.treasuryKeeper)
+	require.Nil(t, ValidateGenesis(genesis))
+	require.Equal(t, 5, len(genesis.EpochHistory))
+
+	// The gap carries over the values of the previous epoch
+	require.True(t, genesis.EpochHistory[2].TaxRate.Equal(genesis.EpochHistory[1].TaxRate))
+	require.True(t, genesis.EpochHistory[2].RewardWeight.Equal(genesis.EpochHistory[1].RewardWeight))
+	require.True(t, genesis.EpochHistory[2].TaxProceeds.Empty())
+	require.True(t, genesis.EpochHistory[4].TaxProceeds.IsEqual(sdk.Coins{sdk.NewInt64Coin(assets.MicroSDRDenom, 5000)}))
+	require.True(t, genesis.TaxCaps.AmountOf(assets.MicroKRWDenom).Equal(sdk.NewInt(1200000)))
+
+	// Import into a fresh chain at the same height and export again
+	input2 := createTestInput(t)
+	input2.ctx = input2.ctx.WithBlockHeight(input.ctx.BlockHeight())
+	InitGenesis(input2.ctx, input2.treasuryKeeper, genesis)
+
+	genesis2 := ExportGenesis(input2.ctx, input2.treasuryKeeper)
+	require.Equal(t, input.cdc.MustMarshalJSON(genesis), input2.cdc.MustMarshalJSON(genesis2))
+
+	// Rolling indicators see the imported history
+	for epoch := int64(0); epoch < 5; epoch++ {
+		require.Equal(t,
+			TaxRewardsForEpoch(input.ctx, input.treasuryKeeper, sdk.NewInt(epoch)),
+			TaxRewardsForEpoch(input2.ctx, input2.treasuryKeeper, sdk.NewInt(epoch)))
+	}
+}
+
+func TestValidateGenesisHistory(t *testing.T) {
+	genesis := DefaultGenesisState()
+	genesis.EpochHistory = []EpochHistory{
+		NewEpochHistory(sdk.NewInt(0), genesis.GenesisTaxRate, genesis.GenesisRewardWeight, sdk.Coins{}),
+		NewEpochHistory(sdk.NewInt(1), genesis.GenesisTaxRate, genesis.GenesisRewardWeight, sdk.Coins{}),
+	}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	// Gap between epochs
+	genesis.EpochHistory[1].Epoch = sdk.NewInt(2)
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	// History not starting at genesis
+	genesis.EpochHistory = genesis.EpochHistory[1:]
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	// Negative tax rate
+	genesis.EpochHistory = []EpochHistory{
+		NewEpochHistory(sdk.NewInt(0), sdk.NewDec(-1), genesis.GenesisRewardWeight, sdk.Coins{}),
+	}
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	// Duplicate tax caps
+	genesis.EpochHistory = []EpochHistory{}
+	genesis.TaxCaps = sdk.Coins{sdk.NewInt64Coin(assets.MicroKRWDenom, 1), sdk.NewInt64Coin(assets.MicroKRWDenom, 2)}
+	require.NotNil(t, ValidateGenesis(genesis))
+}
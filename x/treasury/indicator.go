@@ -2,7 +2,6 @@ package treasury
 
 import (
 	"github.com/terra-project/core/types/assets"
-	"github.com/terra-project/core/types/util"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -88,13 +87,9 @@ func UnitLunaIndicator(ctx sdk.Context, k Keeper, epoch sdk.Int,
 // If current epoch < epochs, we return the best we can and return SumIndicator(currentEpoch)
 func SumIndicator(ctx sdk.Context, k Keeper, epochs sdk.Int,
 	indicatorFunction func(sdk.Context, Keeper, sdk.Int) sdk.Dec) sdk.Dec {
-	sum := sdk.ZeroDec()
-	var i sdk.Int
-	curEpoch := util.GetEpoch(ctx)
-	for i = curEpoch; i.GTE(sdk.ZeroInt()) && i.GT(curEpoch.Sub(epochs)); i = i.Sub(sdk.OneInt()) {
-		val := indicatorFunction(ctx, k, i)
-		sum = sum.Add(val)
-	}
+	sum, _ := windowSum(k.GetEpoch(ctx), epochs, func(epoch sdk.Int) sdk.Dec {
+		return indicatorFunction(ctx, k, epoch)
+	})
 
 	return sum
 }
@@ -103,15 +98,28 @@ func SumIndicator(ctx sdk.Context, k Keeper, epochs sdk.Int,
 // If current epoch < epochs, we return the best we can and return RollingAverageIndicator(currentEpoch)
 func RollingAverageIndicator(ctx sdk.Context, k Keeper, epochs sdk.Int,
 	indicatorFunction func(sdk.Context, Keeper, sdk.Int) sdk.Dec) sdk.Dec {
-	sum := sdk.ZeroDec()
+	return windowAverage(k.GetEpoch(ctx), epochs, func(epoch sdk.Int) sdk.Dec {
+		return indicatorFunction(ctx, k, epoch)
+	})
+}
+
+// windowSum returns the sum of the indicator over the window of {epochs} ending at {curEpoch},
+// along with the number of epochs actually summed; epochs before genesis are skipped.
+func windowSum(curEpoch, epochs sdk.Int, indicator func(sdk.Int) sdk.Dec) (sum sdk.Dec, computedEpochs sdk.Int) {
+	sum = sdk.ZeroDec()
 	var i sdk.Int
-	curEpoch := util.GetEpoch(ctx)
 	for i = curEpoch; i.GTE(sdk.ZeroInt()) && i.GT(curEpoch.Sub(epochs)); i = i.Sub(sdk.OneInt()) {
-		val := indicatorFunction(ctx, k, i)
+		val := indicator(i)
 		sum = sum.Add(val)
 	}
 
-	computedEpochs := curEpoch.Sub(i)
+	computedEpochs = curEpoch.Sub(i)
+	return
+}
+
+// windowAverage returns the average of the indicator over the window of {epochs} ending at {curEpoch}
+func windowAverage(curEpoch, epochs sdk.Int, indicator func(sdk.Int) sdk.Dec) sdk.Dec {
+	sum, computedEpochs := windowSum(curEpoch, epochs, indicator)
 	if computedEpochs.Equal(sdk.ZeroInt()) {
 		return sum
 	}
@@ -49,7 +49,7 @@ func TestSeigniorageRewardsForEpoch(t *testing.T) {
 	input.ctx = input.ctx.WithBlockHeight(util.BlocksPerEpoch)
 
 	// Add seigniorage
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sAmt))
+	input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sAmt))
 
 	// Get seigniorage rewards
 	seigniorageProceeds := SeigniorageRewardsForEpoch(input.ctx, input.treasuryKeeper, util.GetEpoch(input.ctx))
@@ -77,7 +77,7 @@ func TestMiningRewardsForEpoch(t *testing.T) {
 	})
 
 	// Add seigniorage
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, amt))
+	input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, amt))
 
 	tProceeds := TaxRewardsForEpoch(input.ctx, input.treasuryKeeper, util.GetEpoch(input.ctx))
 	sProceeds := SeigniorageRewardsForEpoch(input.ctx, input.treasuryKeeper, util.GetEpoch(input.ctx))
@@ -175,7 +175,7 @@ func TestRollingAverageIndicator(t *testing.T) {
 	for i := int64(201); i <= 500; i++ {
 		input.ctx = input.ctx.WithBlockHeight(util.BlocksPerEpoch * i)
 		input.treasuryKeeper.RecordTaxProceeds(input.ctx, sdk.Coins{sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(i).MulRaw(assets.MicroUnit))})
-		input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(i).MulRaw(assets.MicroUnit)))
+		input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(i).MulRaw(assets.MicroUnit)))
 
 		input.treasuryKeeper.SetRewardWeight(input.ctx, sdk.OneDec())
 	}
@@ -1,7 +1,8 @@
 package treasury
 
 import (
-	"github.com/terra-project/core/types/util"
+	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -34,15 +35,25 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, valset sdk.ValidatorSet,
 	}
 }
 
+// GetEpoch returns the current epoch, starting from 0
+func (k Keeper) GetEpoch(ctx sdk.Context) sdk.Int {
+	return k.mtk.GetEpoch(ctx)
+}
+
 //-----------------------------------
 // Reward weight logic
 
 // SetRewardWeight sets the ratio of the treasury that goes to mining rewards, i.e.
 // supply of Luna that is burned. You can only set the reward weight of the current epoch.
 func (k Keeper) SetRewardWeight(ctx sdk.Context, weight sdk.Dec) {
+	k.setRewardWeight(ctx, k.GetEpoch(ctx), weight)
+}
+
+// setRewardWeight sets the reward weight of the given epoch; used to restore history at genesis
+func (k Keeper) setRewardWeight(ctx sdk.Context, epoch sdk.Int, weight sdk.Dec) {
 	store := ctx.KVStore(k.key)
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(weight)
-	store.Set(keyRewardWeight(util.GetEpoch(ctx)), bz)
+	store.Set(keyRewardWeight(epoch), bz)
 }
 
 // GetRewardWeight returns the mining reward weight
@@ -92,9 +103,14 @@ func (k Keeper) SetParams(ctx sdk.Context, params Params) {
 
 // SetTaxRate sets the tax rate; called from the treasury.
 func (k Keeper) SetTaxRate(ctx sdk.Context, rate sdk.Dec) {
+	k.setTaxRate(ctx, k.GetEpoch(ctx), rate)
+}
+
+// setTaxRate sets the tax rate of the given epoch; used to restore history at genesis
+func (k Keeper) setTaxRate(ctx sdk.Context, epoch sdk.Int, rate sdk.Dec) {
 	store := ctx.KVStore(k.key)
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(rate)
-	store.Set(keyTaxRate(util.GetEpoch(ctx)), bz)
+	store.Set(keyTaxRate(epoch), bz)
 }
 
 // GetTaxRate gets the tax rate
@@ -149,12 +165,48 @@ func (k Keeper) GetTaxCap(ctx sdk.Context, denom string) (taxCap sdk.Int) {
 	return
 }
 
+// ComputeTax returns the stability tax due on {coin} at {taxRate}, along with the tax cap of its denom.
+// Taxes are of the formula: min(principal * taxRate, taxCap).
+func (k Keeper) ComputeTax(ctx sdk.Context, taxRate sdk.Dec, coin sdk.Coin) (taxDue sdk.Int, taxCap sdk.Int) {
+	taxDue = sdk.NewDecFromInt(coin.Amount).Mul(taxRate).TruncateInt()
+
+	// If tax due is greater than the tax cap, cap!
+	taxCap = k.GetTaxCap(ctx, coin.Denom)
+	if taxDue.GT(taxCap) {
+		taxDue = taxCap
+	}
+
+	return
+}
+
+// IterateTaxCaps iterates over the tax caps cached for each denom
+func (k Keeper) IterateTaxCaps(ctx sdk.Context, handler func(denom string, taxCap sdk.Int) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefixTaxCap)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		denom := strings.TrimPrefix(string(iter.Key()), fmt.Sprintf("%s:", prefixTaxCap))
+
+		var taxCap sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &taxCap)
+
+		if handler(denom, taxCap) {
+			break
+		}
+	}
+}
+
 // RecordTaxProceeds add tax proceeds that have been added this epoch
 func (k Keeper) RecordTaxProceeds(ctx sdk.Context, delta sdk.Coins) {
-	epoch := util.GetEpoch(ctx)
+	epoch := k.GetEpoch(ctx)
 	proceeds := k.PeekTaxProceeds(ctx, epoch)
 	proceeds = proceeds.Add(delta)
 
+	k.setTaxProceeds(ctx, epoch, proceeds)
+}
+
+// setTaxProceeds sets the tax proceeds of the given epoch; used to restore history at genesis
+func (k Keeper) setTaxProceeds(ctx sdk.Context, epoch sdk.Int, proceeds sdk.Coins) {
 	store := ctx.KVStore(k.key)
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(proceeds)
 	store.Set(keyTaxProceeds(epoch), bz)
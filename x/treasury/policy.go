@@ -1,8 +1,6 @@
 package treasury
 
 import (
-	"github.com/terra-project/core/types/util"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -10,19 +8,11 @@ import (
 func updateTaxPolicy(ctx sdk.Context, k Keeper) (newTaxRate sdk.Dec) {
 	params := k.GetParams(ctx)
 
-	oldTaxRate := k.GetTaxRate(ctx, util.GetEpoch(ctx))
-	inc := params.MiningIncrement
+	oldTaxRate := k.GetTaxRate(ctx, k.GetEpoch(ctx))
 	tlYear := RollingAverageIndicator(ctx, k, params.WindowLong, TRL)
 	tlMonth := RollingAverageIndicator(ctx, k, params.WindowShort, TRL)
 
-	// No revenues, hike as much as possible.
-	if tlMonth.Equal(sdk.ZeroDec()) {
-		newTaxRate = params.TaxPolicy.RateMax
-	} else {
-		newTaxRate = oldTaxRate.Mul(tlYear.Mul(inc)).Quo(tlMonth)
-	}
-
-	newTaxRate = params.TaxPolicy.Clamp(oldTaxRate, newTaxRate)
+	newTaxRate = ComputeTaxRate(params, oldTaxRate, tlYear, tlMonth)
 
 	// Set the new tax rate to the store
 	k.SetTaxRate(ctx, newTaxRate)
@@ -33,13 +23,41 @@ func updateTaxPolicy(ctx sdk.Context, k Keeper) (newTaxRate sdk.Dec) {
 func updateRewardPolicy(ctx sdk.Context, k Keeper) (newRewardWeight sdk.Dec) {
 	params := k.GetParams(ctx)
 
-	curEpoch := util.GetEpoch(ctx)
+	curEpoch := k.GetEpoch(ctx)
 	oldWeight := k.GetRewardWeight(ctx, curEpoch)
-	sbTarget := params.SeigniorageBurdenTarget
 
 	seigniorageSum := SumIndicator(ctx, k, params.WindowShort, SeigniorageRewardsForEpoch)
 	totalSum := SumIndicator(ctx, k, params.WindowShort, MiningRewardForEpoch)
 
+	newRewardWeight = ComputeRewardWeight(params, oldWeight, seigniorageSum, totalSum)
+
+	// Set the new reward weight
+	k.SetRewardWeight(ctx, newRewardWeight)
+	return
+}
+
+// ComputeTaxRate returns the clamped tax rate for the next epoch, given the previous
+// tax rate and the long and short rolling averages of tax rewards per unit luna.
+// t(t+1) = t(t) * (TL_year(t) + INC) / TL_month(t)
+func ComputeTaxRate(params Params, oldTaxRate, tlYear, tlMonth sdk.Dec) (newTaxRate sdk.Dec) {
+	inc := params.MiningIncrement
+
+	// No revenues, hike as much as possible.
+	if tlMonth.Equal(sdk.ZeroDec()) {
+		newTaxRate = params.TaxPolicy.RateMax
+	} else {
+		newTaxRate = oldTaxRate.Mul(tlYear.Mul(inc)).Quo(tlMonth)
+	}
+
+	return params.TaxPolicy.Clamp(oldTaxRate, newTaxRate)
+}
+
+// ComputeRewardWeight returns the clamped reward weight for the next epoch, given the previous
+// reward weight and the seigniorage and total mining rewards summed over the short window.
+// w(t+1) = w(t)*SB_target/SB_rolling(t)
+func ComputeRewardWeight(params Params, oldWeight, seigniorageSum, totalSum sdk.Dec) (newRewardWeight sdk.Dec) {
+	sbTarget := params.SeigniorageBurdenTarget
+
 	// No revenues; hike as much as possible
 	if totalSum.Equal(sdk.ZeroDec()) || seigniorageSum.Equal(sdk.ZeroDec()) {
 		newRewardWeight = params.RewardPolicy.RateMax
@@ -49,9 +67,5 @@ func updateRewardPolicy(ctx sdk.Context, k Keeper) (newRewardWeight sdk.Dec) {
 		newRewardWeight = oldWeight.Mul(sbTarget.Quo(sb))
 	}
 
-	newRewardWeight = params.RewardPolicy.Clamp(oldWeight, newRewardWeight)
-
-	// Set the new reward weight
-	k.SetRewardWeight(ctx, newRewardWeight)
-	return
+	return params.RewardPolicy.Clamp(oldWeight, newRewardWeight)
 }
@@ -1,14 +1,15 @@
 package treasury
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
-	"github.com/terra-project/core/types/util"
-
 	"github.com/cosmos/cosmos-sdk/codec"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -22,6 +23,7 @@ const (
 	QueryParams              = "params"
 	QueryIssuance            = "issuance"
 	QueryTaxProceeds         = "tax-proceeds"
+	QueryEstimateTax         = "estimate-tax"
 )
 
 // NewQuerier is the module level router for state queries
@@ -44,6 +46,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryCurrentEpoch(ctx, req, keeper)
 		case QueryParams:
 			return queryParams(ctx, req, keeper)
+		case QueryEstimateTax:
+			return queryEstimateTax(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown treasury query endpoint")
 		}
@@ -117,7 +121,7 @@ func queryIssuance(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 
 	var day int64
 	if len(dayStr) == 0 {
-		day = ctx.BlockHeight() / util.BlocksPerDay
+		day = keeper.mtk.GetDay(ctx).Int64()
 	} else {
 		day, _ = strconv.ParseInt(dayStr, 10, 64)
 	}
@@ -216,7 +220,7 @@ func (r QueryCurrentEpochResponse) String() (out string) {
 }
 
 func queryCurrentEpoch(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
-	curEpoch := util.GetEpoch(ctx)
+	curEpoch := keeper.GetEpoch(ctx)
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, QueryCurrentEpochResponse{CurrentEpoch: curEpoch})
 	if err != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
@@ -231,3 +235,100 @@ func queryParams(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte,
 	}
 	return bz, nil
 }
+
+// QueryEstimateTaxParams for query 'custom/treasury/estimate-tax'. Coins are taxed as a single send
+// if given; otherwise the sends in Tx are taxed the way the pay module charges them.
+type QueryEstimateTaxParams struct {
+	Coins sdk.Coins  `json:"coins"`
+	Tx    auth.StdTx `json:"tx"`
+}
+
+// NewQueryEstimateTaxParams creates a new instance of QueryEstimateTaxParams
+func NewQueryEstimateTaxParams(coins sdk.Coins, tx auth.StdTx) QueryEstimateTaxParams {
+	return QueryEstimateTaxParams{
+		Coins: coins,
+		Tx:    tx,
+	}
+}
+
+// TaxEstimate is the stability tax due on a single coin of a send
+type TaxEstimate struct {
+	Principal sdk.Coin `json:"principal"`
+	TaxCap    sdk.Int  `json:"tax_cap"`
+	Tax       sdk.Coin `json:"tax"`
+}
+
+// JSON response format
+type QueryEstimateTaxResponse struct {
+	TaxRate   sdk.Dec       `json:"tax_rate"`
+	Estimates []TaxEstimate `json:"estimates"`
+	Total     sdk.Coins     `json:"total"`
+}
+
+func (r QueryEstimateTaxResponse) String() (out string) {
+	out = fmt.Sprintf("TaxRate: %s\n", r.TaxRate)
+	for _, estimate := range r.Estimates {
+		out += fmt.Sprintf("  %s: %s (cap %s)\n", estimate.Principal, estimate.Tax, estimate.TaxCap)
+	}
+	out += fmt.Sprintf("Total: %s", r.Total)
+	return strings.TrimSpace(out)
+}
+
+// nolint: unparam
+func queryEstimateTax(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryEstimateTaxParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	// Each principal is taxed separately, as the cap applies per send
+	var principals []sdk.Coins
+	if len(params.Coins) != 0 {
+		if !params.Coins.IsValid() {
+			return nil, sdk.ErrInvalidCoins(params.Coins.String())
+		}
+
+		principals = append(principals, params.Coins)
+	} else {
+		for _, msg := range params.Tx.GetMsgs() {
+			switch msg := msg.(type) {
+			case bank.MsgSend:
+				principals = append(principals, msg.Amount)
+			case bank.MsgMultiSend:
+				for _, input := range msg.Inputs {
+					principals = append(principals, input.Coins)
+				}
+			}
+		}
+	}
+
+	taxRate := keeper.GetTaxRate(ctx, keeper.GetEpoch(ctx))
+	response := QueryEstimateTaxResponse{
+		TaxRate:   taxRate,
+		Estimates: []TaxEstimate{},
+		Total:     sdk.Coins{},
+	}
+
+	for _, principal := range principals {
+		for _, coin := range principal {
+			taxDue, taxCap := keeper.ComputeTax(ctx, taxRate, coin)
+
+			response.Estimates = append(response.Estimates, TaxEstimate{
+				Principal: coin,
+				TaxCap:    taxCap,
+				Tax:       sdk.NewCoin(coin.Denom, taxDue),
+			})
+
+			if taxDue.IsPositive() {
+				response.Total = response.Total.Add(sdk.NewCoins(sdk.NewCoin(coin.Denom, taxDue)))
+			}
+		}
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, response)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
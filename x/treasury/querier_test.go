@@ -12,6 +12,8 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 )
 
 const custom = "custom"
@@ -156,6 +158,23 @@ func getQueriedParams(t *testing.T, ctx sdk.Context, cdc *codec.Codec, querier s
 	return params
 }
 
+func getQueriedEstimateTax(t *testing.T, ctx sdk.Context, cdc *codec.Codec, querier sdk.Querier, params QueryEstimateTaxParams) QueryEstimateTaxResponse {
+	query := abci.RequestQuery{
+		Path: strings.Join([]string{custom, QuerierRoute, QueryEstimateTax}, "/"),
+		Data: cdc.MustMarshalJSON(params),
+	}
+
+	bz, err := querier(ctx, []string{QueryEstimateTax}, query)
+	require.Nil(t, err)
+	require.NotNil(t, bz)
+
+	var response QueryEstimateTaxResponse
+	err2 := cdc.UnmarshalJSON(bz, &response)
+	require.Nil(t, err2)
+
+	return response
+}
+
 func TestQueryParams(t *testing.T) {
 	input := createTestInput(t)
 	querier := NewQuerier(input.treasuryKeeper)
@@ -237,7 +256,7 @@ func TestQuerySeigniorageProceeds(t *testing.T) {
 
 	input.ctx = input.ctx.WithBlockHeight(util.BlocksPerEpoch)
 	seigniorageProceeds := sdk.NewCoin(assets.MicroLunaDenom, sdk.NewInt(10).MulRaw(assets.MicroUnit))
-	input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, seigniorageProceeds.Amount))
+	input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroLunaDenom, seigniorageProceeds.Amount))
 
 	queriedSeigniorageProceeds := getQueriedSeigniorageProceeds(t, input.ctx, input.cdc, querier, util.GetEpoch(input.ctx))
 
@@ -249,10 +268,52 @@ func TestQueryIssuance(t *testing.T) {
 	querier := NewQuerier(input.treasuryKeeper)
 
 	issuance := sdk.NewInt(1000).MulRaw(assets.MicroUnit)
-	err := input.mintKeeper.Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, issuance))
+	err := input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewCoin(assets.MicroSDRDenom, issuance))
 	require.Nil(t, err)
 
 	queriedIssuance := getQueriedIssuance(t, input.ctx, input.cdc, querier, assets.MicroSDRDenom)
 
 	require.Equal(t, issuance, queriedIssuance)
 }
+
+func TestQueryEstimateTax(t *testing.T) {
+	input := createTestInput(t)
+	querier := NewQuerier(input.treasuryKeeper)
+
+	taxRate := sdk.NewDecWithPrec(1, 2)
+	input.treasuryKeeper.SetTaxRate(input.ctx, taxRate)
+	taxCap := input.treasuryKeeper.GetTaxCap(input.ctx, assets.MicroSDRDenom)
+
+	// Plain coins are taxed as a single send; the large principal hits the cap
+	coins := sdk.NewCoins(
+		sdk.NewCoin(assets.MicroKRWDenom, sdk.NewInt(1000)),
+		sdk.NewCoin(assets.MicroSDRDenom, taxCap.MulRaw(1000)),
+	)
+	response := getQueriedEstimateTax(t, input.ctx, input.cdc, querier, NewQueryEstimateTaxParams(coins, auth.StdTx{}))
+	require.Equal(t, taxRate, response.TaxRate)
+	require.Equal(t, 2, len(response.Estimates))
+	require.Equal(t, sdk.NewCoin(assets.MicroKRWDenom, sdk.NewInt(10)), response.Estimates[0].Tax)
+	require.Equal(t, sdk.NewCoin(assets.MicroSDRDenom, taxCap), response.Estimates[1].Tax)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewCoin(assets.MicroKRWDenom, sdk.NewInt(10)),
+		sdk.NewCoin(assets.MicroSDRDenom, taxCap),
+	), response.Total)
+
+	// Each send in a tx is taxed separately
+	sendCoins := sdk.NewCoins(sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(1000)))
+	tx := auth.NewStdTx([]sdk.Msg{
+		bank.NewMsgSend(addrs[0], addrs[1], sendCoins),
+		bank.NewMsgMultiSend(
+			[]bank.Input{bank.NewInput(addrs[0], sendCoins), bank.NewInput(addrs[1], sendCoins)},
+			[]bank.Output{bank.NewOutput(addrs[2], sendCoins.Add(sendCoins))},
+		),
+	}, auth.StdFee{}, nil, "")
+	response = getQueriedEstimateTax(t, input.ctx, input.cdc, querier, NewQueryEstimateTaxParams(nil, tx))
+	require.Equal(t, 3, len(response.Estimates))
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(assets.MicroSDRDenom, sdk.NewInt(30))), response.Total)
+
+	// Nothing to tax
+	response = getQueriedEstimateTax(t, input.ctx, input.cdc, querier, NewQueryEstimateTaxParams(nil, auth.StdTx{}))
+	require.Equal(t, 0, len(response.Estimates))
+	require.True(t, response.Total.Empty())
+}
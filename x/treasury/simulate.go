@@ -0,0 +1,106 @@
+package treasury
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SimulationEpoch holds the economic activity observed during a single epoch of a policy simulation
+type SimulationEpoch struct {
+	TaxProceeds sdk.Int `json:"tax_proceeds"` // tax proceeds collected in the epoch, in usdr
+	Seigniorage sdk.Int `json:"seigniorage"`  // seigniorage minted in the epoch, valued in usdr
+	Bonded      sdk.Int `json:"bonded"`       // total bonded uluna at the end of the epoch
+}
+
+// SimulationResult holds the policy variables in force after the end blocker of an epoch has run
+type SimulationResult struct {
+	Epoch        int64   `json:"epoch"`
+	TaxRate      sdk.Dec `json:"tax_rate"`
+	RewardWeight sdk.Dec `json:"reward_weight"`
+	Updated      bool    `json:"updated"` // false during the probation period
+}
+
+// SimulationResults is a per-epoch projection of the treasury policy
+type SimulationResults []SimulationResult
+
+// String implements fmt.Stringer
+func (results SimulationResults) String() string {
+	out := fmt.Sprintf("%-8s %-24s %-24s %s\n", "Epoch", "TaxRate", "RewardWeight", "Updated")
+	for _, result := range results {
+		out += fmt.Sprintf("%-8d %-24s %-24s %t\n", result.Epoch, result.TaxRate, result.RewardWeight, result.Updated)
+	}
+
+	return strings.TrimSpace(out)
+}
+
+// SimulatePolicy replays the treasury end blocker over the given epochs, starting at genesis with the
+// given tax rate and reward weight, and returns the tax rate and reward weight stored for each epoch.
+// The same policy math as the keeper is used; tax proceeds and seigniorage are taken as usdr amounts.
+func SimulatePolicy(params Params, taxRate, rewardWeight sdk.Dec, epochs []SimulationEpoch) (SimulationResults, error) {
+	if err := validateParams(params); err != nil {
+		return nil, err
+	}
+
+	for i, epoch := range epochs {
+		if epoch.TaxProceeds.IsNegative() {
+			return nil, fmt.Errorf("epoch %d: tax_proceeds must be >= 0", i)
+		}
+
+		if epoch.Seigniorage.IsNegative() {
+			return nil, fmt.Errorf("epoch %d: seigniorage must be >= 0", i)
+		}
+
+		if !epoch.Bonded.IsPositive() {
+			return nil, fmt.Errorf("epoch %d: bonded must be > 0", i)
+		}
+	}
+
+	taxRates := make([]sdk.Dec, len(epochs))
+	rewardWeights := make([]sdk.Dec, len(epochs))
+	results := make(SimulationResults, len(epochs))
+
+	for cur := range epochs {
+		curEpoch := sdk.NewInt(int64(cur))
+
+		// Policy variables carry over from the previous epoch until updated
+		taxRates[cur] = taxRate
+		rewardWeights[cur] = rewardWeight
+
+		// Same probation check as the end blocker; look 1 epoch into the future
+		updated := !curEpoch.AddRaw(1).LT(params.WindowProbation)
+		if updated {
+			bonded := epochs[cur].Bonded
+
+			trl := func(epoch sdk.Int) sdk.Dec {
+				return sdk.NewDecFromInt(epochs[epoch.Int64()].TaxProceeds).QuoInt(bonded)
+			}
+			seigniorageRewards := func(epoch sdk.Int) sdk.Dec {
+				return rewardWeights[epoch.Int64()].MulInt(epochs[epoch.Int64()].Seigniorage)
+			}
+			miningRewards := func(epoch sdk.Int) sdk.Dec {
+				return sdk.NewDecFromInt(epochs[epoch.Int64()].TaxProceeds).Add(seigniorageRewards(epoch))
+			}
+
+			tlYear := windowAverage(curEpoch, params.WindowLong, trl)
+			tlMonth := windowAverage(curEpoch, params.WindowShort, trl)
+			taxRate = ComputeTaxRate(params, taxRates[cur], tlYear, tlMonth)
+			taxRates[cur] = taxRate
+
+			seigniorageSum, _ := windowSum(curEpoch, params.WindowShort, seigniorageRewards)
+			totalSum, _ := windowSum(curEpoch, params.WindowShort, miningRewards)
+			rewardWeight = ComputeRewardWeight(params, rewardWeights[cur], seigniorageSum, totalSum)
+			rewardWeights[cur] = rewardWeight
+		}
+
+		results[cur] = SimulationResult{
+			Epoch:        curEpoch.Int64(),
+			TaxRate:      taxRate,
+			RewardWeight: rewardWeight,
+			Updated:      updated,
+		}
+	}
+
+	return results, nil
+}
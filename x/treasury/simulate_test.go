@@ -0,0 +1,105 @@
+package treasury
+
+import (
+	"testing"
+
+	"github.com/terra-project/core/types/assets"
+	"github.com/terra-project/core/types/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatePolicyProbation(t *testing.T) {
+	genesis := DefaultGenesisState()
+	params := genesis.Params
+
+	epochs := make([]SimulationEpoch, params.WindowProbation.Int64()+1)
+	for i := range epochs {
+		epochs[i] = SimulationEpoch{
+			TaxProceeds: sdk.NewInt(1000000),
+			Seigniorage: sdk.NewInt(1000000),
+			Bonded:      sdk.NewInt(1000000000),
+		}
+	}
+
+	results, err := SimulatePolicy(params, genesis.GenesisTaxRate, genesis.GenesisRewardWeight, epochs)
+	require.Nil(t, err)
+	require.Equal(t, len(epochs), len(results))
+
+	// Policy variables are untouched until the end of the probation period
+	probationEnd := params.WindowProbation.Int64() - 1
+	for _, result := range results[:probationEnd] {
+		require.False(t, result.Updated)
+		require.True(t, genesis.GenesisTaxRate.Equal(result.TaxRate))
+		require.True(t, genesis.GenesisRewardWeight.Equal(result.RewardWeight))
+	}
+
+	for _, result := range results[probationEnd:] {
+		require.True(t, result.Updated)
+	}
+}
+
+func TestSimulatePolicyInvalidEpoch(t *testing.T) {
+	genesis := DefaultGenesisState()
+
+	epochs := []SimulationEpoch{
+		{TaxProceeds: sdk.NewInt(1000000), Seigniorage: sdk.NewInt(1000000), Bonded: sdk.ZeroInt()},
+	}
+
+	_, err := SimulatePolicy(genesis.Params, genesis.GenesisTaxRate, genesis.GenesisRewardWeight, epochs)
+	require.NotNil(t, err)
+
+	epochs[0].Bonded = sdk.NewInt(1000000000)
+	epochs[0].TaxProceeds = sdk.NewInt(-1)
+
+	_, err = SimulatePolicy(genesis.Params, genesis.GenesisTaxRate, genesis.GenesisRewardWeight, epochs)
+	require.NotNil(t, err)
+}
+
+// The simulator must reproduce the tax rates and reward weights set by the end blocker
+func TestSimulatePolicyMatchesEndBlocker(t *testing.T) {
+	input := createTestInput(t)
+	input.oracleKeeper.SetLunaSwapRate(input.ctx, assets.MicroSDRDenom, sdk.NewDec(1))
+
+	params := input.treasuryKeeper.GetParams(input.ctx)
+	params.WindowShort = sdk.NewInt(2)
+	params.WindowLong = sdk.NewInt(4)
+	params.WindowProbation = sdk.NewInt(2)
+	input.treasuryKeeper.SetParams(input.ctx, params)
+
+	taxProceeds := []int64{1000000, 3000000, 2000000, 500000, 0, 8000000, 4000000, 4000000}
+	seigniorage := []int64{5000000, 0, 1000000, 7000000, 2000000, 0, 3000000, 9000000}
+
+	bonded := input.treasuryKeeper.valset.TotalBondedTokens(input.ctx)
+	epochs := make([]SimulationEpoch, len(taxProceeds))
+	for i := range taxProceeds {
+		epochs[i] = SimulationEpoch{
+			TaxProceeds: sdk.NewInt(taxProceeds[i]),
+			Seigniorage: sdk.NewInt(seigniorage[i]),
+			Bonded:      bonded,
+		}
+
+		// Run the epoch on chain; activity is recorded at the last block of the epoch
+		input.ctx = input.ctx.WithBlockHeight(int64(i+1)*util.BlocksPerEpoch - 1)
+		if taxProceeds[i] > 0 {
+			input.treasuryKeeper.RecordTaxProceeds(input.ctx, sdk.Coins{sdk.NewInt64Coin(assets.MicroSDRDenom, taxProceeds[i])})
+		}
+		if seigniorage[i] > 0 {
+			err := input.mintKeeper.ForModule(faucet).Mint(input.ctx, addrs[0], sdk.NewInt64Coin(assets.MicroLunaDenom, seigniorage[i]))
+			require.Nil(t, err)
+		}
+
+		EndBlocker(input.ctx, input.treasuryKeeper)
+	}
+
+	genesis := DefaultGenesisState()
+	results, err := SimulatePolicy(params, genesis.GenesisTaxRate, genesis.GenesisRewardWeight, epochs)
+	require.Nil(t, err)
+
+	for i, result := range results {
+		epoch := sdk.NewInt(int64(i))
+		require.True(t, input.treasuryKeeper.GetTaxRate(input.ctx, epoch).Equal(result.TaxRate), "epoch %d", i)
+		require.True(t, input.treasuryKeeper.GetRewardWeight(input.ctx, epoch).Equal(result.RewardWeight), "epoch %d", i)
+	}
+}
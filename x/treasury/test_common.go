@@ -55,6 +55,9 @@ var (
 	uLunaAmt = sdk.NewInt(1000).MulRaw(assets.MicroUnit)
 )
 
+// faucet is the module the tests fund accounts as; it may mint and burn every denom
+const faucet = "faucet"
+
 type testInput struct {
 	ctx            sdk.Context
 	cdc            *codec.Codec
@@ -71,6 +74,7 @@ func newTestCodec() *codec.Codec {
 
 	RegisterCodec(cdc)
 	auth.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 
@@ -153,11 +157,17 @@ func createTestInput(t *testing.T) testInput {
 		stakingKeeper,
 		bankKeeper,
 		accKeeper,
+		paramsKeeper.Subspace(mint.DefaultParamspace),
 	)
 
+	mintKeeper.RegisterPermission(faucet, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+	mintKeeper.RegisterPermission(market.ModuleName, mint.NewPermission([]string{mint.OperationMint, mint.OperationBurn},
+		append([]string{assets.MicroLunaDenom}, assets.TerraDenoms...)))
+
 	sh := staking.NewHandler(stakingKeeper)
 	for i, addr := range addrs {
-		err2 := mintKeeper.Mint(ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
+		err2 := mintKeeper.ForModule(faucet).Mint(ctx, addr, sdk.NewCoin(assets.MicroLunaDenom, uLunaAmt))
 		require.NoError(t, err2)
 
 		// Add validators
@@ -185,7 +195,7 @@ func createTestInput(t *testing.T) testInput {
 		cdc,
 		keyMarket,
 		oracleKeeper,
-		mintKeeper,
+		mintKeeper.ForModule(market.ModuleName),
 		paramsKeeper.Subspace(market.DefaultParamspace))
 
 	marketKeeper.SetParams(ctx, market.DefaultParams())
@@ -194,7 +204,7 @@ func createTestInput(t *testing.T) testInput {
 		cdc,
 		keyTreasury,
 		stakingKeeper.GetValidatorSet(),
-		mintKeeper,
+		mintKeeper.ForModule(ModuleName),
 		marketKeeper,
 		paramsKeeper.Subspace(DefaultParamspace),
 	)